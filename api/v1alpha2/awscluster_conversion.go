@@ -60,6 +60,13 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.ImageLookupFormat = restored.Spec.ImageLookupFormat
 	dst.Spec.ImageLookupOrg = restored.Spec.ImageLookupOrg
 	dst.Spec.ImageLookupBaseOS = restored.Spec.ImageLookupBaseOS
+	dst.Spec.AdditionalTrustedCAs = restored.Spec.AdditionalTrustedCAs
+	dst.Spec.RegistryCredentials = restored.Spec.RegistryCredentials
+	dst.Spec.RegistryMirrors = restored.Spec.RegistryMirrors
+	dst.Spec.DefaultInstanceProfiles = restored.Spec.DefaultInstanceProfiles
+	dst.Spec.HealthReporting = restored.Spec.HealthReporting
+	dst.Spec.NTP = restored.Spec.NTP
+	dst.Spec.DeletionOrder = restored.Spec.DeletionOrder
 
 	// If src ControlPlaneLoadBalancer is nil, do not copy restored ControlPlaneLoadBalancer into it.
 	if src.Spec.ControlPlaneLoadBalancer != nil {
@@ -69,14 +76,20 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 			dst.Spec.ControlPlaneLoadBalancer.CrossZoneLoadBalancing = restored.Spec.ControlPlaneLoadBalancer.CrossZoneLoadBalancing
 			dst.Spec.ControlPlaneLoadBalancer.Subnets = restored.Spec.ControlPlaneLoadBalancer.Subnets
 			dst.Spec.ControlPlaneLoadBalancer.AdditionalSecurityGroups = restored.Spec.ControlPlaneLoadBalancer.AdditionalSecurityGroups
+			dst.Spec.ControlPlaneLoadBalancer.DNSRecord = restored.Spec.ControlPlaneLoadBalancer.DNSRecord
 		}
 	}
 
 	dst.Spec.NetworkSpec.CNI = restored.Spec.NetworkSpec.CNI
 	dst.Status.FailureDomains = restored.Status.FailureDomains
+	dst.Status.DeletedResources = restored.Status.DeletedResources
 	dst.Status.Network.APIServerELB.AvailabilityZones = restored.Status.Network.APIServerELB.AvailabilityZones
+	dst.Status.Network.APIServerELB.CanonicalHostedZoneID = restored.Status.Network.APIServerELB.CanonicalHostedZoneID
 	dst.Status.Network.APIServerELB.Attributes.CrossZoneLoadBalancing = restored.Status.Network.APIServerELB.Attributes.CrossZoneLoadBalancing
 	dst.Spec.NetworkSpec.SecurityGroupOverrides = restored.Spec.NetworkSpec.SecurityGroupOverrides
+	dst.Spec.NetworkSpec.SubnetGroups = restored.Spec.NetworkSpec.SubnetGroups
+	dst.Status.Network.SubnetGroups = restored.Status.Network.SubnetGroups
+	restoreSubnets(restored.Spec.NetworkSpec.Subnets, dst.Spec.NetworkSpec.Subnets)
 
 	restoreInstance(restored.Status.Bastion, dst.Status.Bastion)
 
@@ -97,12 +110,38 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.NetworkSpec.VPC.AvailabilityZoneSelection != nil {
 		dst.Spec.NetworkSpec.VPC.AvailabilityZoneSelection = restored.Spec.NetworkSpec.VPC.AvailabilityZoneSelection
 	}
+	dst.Spec.NetworkSpec.VPC.InstanceTenancy = restored.Spec.NetworkSpec.VPC.InstanceTenancy
+	dst.Status.Network.VPCInstanceTenancy = restored.Status.Network.VPCInstanceTenancy
+	dst.Spec.NetworkSpec.BlackholeRoutes = restored.Spec.NetworkSpec.BlackholeRoutes
+	dst.Status.Network.BlackholeNetworkInterfaceID = restored.Status.Network.BlackholeNetworkInterfaceID
+	dst.Status.Network.InternetGatewayNotRequired = restored.Status.Network.InternetGatewayNotRequired
+	dst.Spec.NetworkSpec.NatGatewayMonitoring = restored.Spec.NetworkSpec.NatGatewayMonitoring
+	dst.Spec.NetworkSpec.VPC.DHCPOptionsSetID = restored.Spec.NetworkSpec.VPC.DHCPOptionsSetID
+	dst.Status.Network.DHCPOptionsSet = restored.Status.Network.DHCPOptionsSet
+	dst.Spec.NetworkSpec.VPC.OwnerAccountID = restored.Spec.NetworkSpec.VPC.OwnerAccountID
+	dst.Status.Network.SharedVPC = restored.Status.Network.SharedVPC
 	// Manually convert conditions
 	dst.SetConditions(restored.GetConditions())
 
 	return nil
 }
 
+// restoreSubnets restores the v1alpha3-only subnet fields, matching subnets by position and ID.
+func restoreSubnets(restored, dst infrav1alpha3.Subnets) {
+	for i := range dst {
+		if i >= len(restored) || restored[i] == nil || dst[i] == nil || restored[i].ID != dst[i].ID {
+			continue
+		}
+		dst[i].OutpostARN = restored[i].OutpostARN
+		dst[i].IPv6CidrBlock = restored[i].IPv6CidrBlock
+		dst[i].AssignIPv6AddressOnCreation = restored[i].AssignIPv6AddressOnCreation
+		dst[i].PrivateIPPool = restored[i].PrivateIPPool
+		dst[i].SubnetGroup = restored[i].SubnetGroup
+		dst[i].NetworkBorderGroup = restored[i].NetworkBorderGroup
+		dst[i].NatGatewayNetworkBorderGroup = restored[i].NatGatewayNetworkBorderGroup
+	}
+}
+
 func restoreInstance(restored, dst *infrav1alpha3.Instance) {
 	if restored != nil {
 		dst.AvailabilityZone = restored.AvailabilityZone
@@ -115,6 +154,14 @@ func restoreInstance(restored, dst *infrav1alpha3.Instance) {
 		}
 
 		dst.Tenancy = restored.Tenancy
+		dst.PlacementGroupName = restored.PlacementGroupName
+		dst.CapacityReservationPreference = restored.CapacityReservationPreference
+		dst.CapacityReservationID = restored.CapacityReservationID
+		dst.HostID = restored.HostID
+		dst.HostAffinity = restored.HostAffinity
+		dst.InstanceMetadataOptions = restored.InstanceMetadataOptions
+		dst.VolumeTags = restored.VolumeTags
+		dst.VolumeIDs = restored.VolumeIDs
 	}
 }
 
@@ -227,6 +274,11 @@ func Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(in *infrav1alpha3.Classi
 	return autoConvert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(in, out, s)
 }
 
+// Convert_v1alpha3_Network_To_v1alpha2_Network.
+func Convert_v1alpha3_Network_To_v1alpha2_Network(in *infrav1alpha3.Network, out *Network, s apiconversion.Scope) error {
+	return autoConvert_v1alpha3_Network_To_v1alpha2_Network(in, out, s)
+}
+
 // Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec.
 func Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in *infrav1alpha3.AWSLoadBalancerSpec, out *AWSLoadBalancerSpec, s apiconversion.Scope) error {
 	return autoConvert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in, out, s)
@@ -241,7 +293,58 @@ func Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(in *infrav1alpha3.VPCSpec, out
 	return autoConvert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(in, out, s)
 }
 
+// Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec.
+func Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in *infrav1alpha3.SubnetSpec, out *SubnetSpec, s apiconversion.Scope) error {
+	return autoConvert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in, out, s)
+}
+
+// Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec converts this NetworkSpec to the Hub version (v1alpha3).
+// Subnets are converted one by one, since the generated conversion of the list requires a scope.
+func Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(in *NetworkSpec, out *infrav1alpha3.NetworkSpec, s apiconversion.Scope) error {
+	inCopy := *in
+	inCopy.Subnets = nil
+	if err := autoConvert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(&inCopy, out, s); err != nil {
+		return err
+	}
+
+	out.Subnets = nil
+	if in.Subnets != nil {
+		out.Subnets = make(infrav1alpha3.Subnets, len(in.Subnets))
+		for i, subnet := range in.Subnets {
+			if subnet == nil {
+				continue
+			}
+			out.Subnets[i] = &infrav1alpha3.SubnetSpec{}
+			if err := Convert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec(subnet, out.Subnets[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec
 func Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(in *infrav1alpha3.NetworkSpec, out *NetworkSpec, s apiconversion.Scope) error {
-	return autoConvert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(in, out, s)
+	inCopy := *in
+	inCopy.Subnets = nil
+	if err := autoConvert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(&inCopy, out, s); err != nil {
+		return err
+	}
+
+	out.Subnets = nil
+	if in.Subnets != nil {
+		out.Subnets = make(Subnets, len(in.Subnets))
+		for i, subnet := range in.Subnets {
+			if subnet == nil {
+				continue
+			}
+			out.Subnets[i] = &SubnetSpec{}
+			if err := Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(subnet, out.Subnets[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
@@ -86,6 +86,32 @@ func restoreAWSMachineSpec(restored, dst *infrav1alpha3.AWSMachineSpec, src *AWS
 	}
 
 	dst.Tenancy = restored.Tenancy
+	dst.NodeLabelTags = restored.NodeLabelTags
+	dst.NVIDIADriver = restored.NVIDIADriver
+	dst.LoadIPVSModules = restored.LoadIPVSModules
+	dst.Sysctls = restored.Sysctls
+	dst.ImageGC = restored.ImageGC
+	dst.ContainerLogRotation = restored.ContainerLogRotation
+	dst.SubnetGroup = restored.SubnetGroup
+	dst.CapacityFallback = restored.CapacityFallback
+	dst.NodeLabels = restored.NodeLabels
+	dst.NodeTaints = restored.NodeTaints
+	dst.EvictionThresholds = restored.EvictionThresholds
+	dst.MonitoringTargetGroup = restored.MonitoringTargetGroup
+	dst.InstanceMetadataOptions = restored.InstanceMetadataOptions
+	dst.InstanceRequirements = restored.InstanceRequirements
+	dst.PlacementGroupName = restored.PlacementGroupName
+	dst.CapacityReservationPreference = restored.CapacityReservationPreference
+	dst.CapacityReservationID = restored.CapacityReservationID
+	dst.HostPlacement = restored.HostPlacement
+	dst.ContainerRuntimeVolume = restored.ContainerRuntimeVolume
+	dst.AdditionalBootConfig = restored.AdditionalBootConfig
+	dst.VolumeTags = restored.VolumeTags
+	dst.OutpostARN = restored.OutpostARN
+	dst.AMIEncryptionKey = restored.AMIEncryptionKey
+	dst.TerminationLogUpload = restored.TerminationLogUpload
+	dst.ExcludeClusterTags = restored.ExcludeClusterTags
+	dst.SubnetTags = restored.SubnetTags
 
 	if restored.CloudInit.SecureSecretsBackend != "" {
 		if src.CloudInit != nil {
@@ -96,6 +122,16 @@ func restoreAWSMachineSpec(restored, dst *infrav1alpha3.AWSMachineSpec, src *AWS
 
 func restoreAWSMachineStatus(restored, dst *infrav1alpha3.AWSMachineStatus) {
 	dst.Interruptible = restored.Interruptible
+	dst.AssignedPrivateIP = restored.AssignedPrivateIP
+	dst.BootstrapDataHash = restored.BootstrapDataHash
+	dst.OutpostARN = restored.OutpostARN
+	dst.ImageID = restored.ImageID
+	dst.AvailabilityZone = restored.AvailabilityZone
+	dst.InstanceMetadataOptions = restored.InstanceMetadataOptions
+	dst.InstanceType = restored.InstanceType
+	dst.TerminationLogLocation = restored.TerminationLogLocation
+	dst.CapacityReservationEndTime = restored.CapacityReservationEndTime
+	dst.RootVolume = restored.RootVolume
 }
 
 // ConvertFrom converts from the Hub version (v1alpha3) to this version.
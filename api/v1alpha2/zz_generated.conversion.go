@@ -199,16 +199,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.Network)(nil), (*Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_Network_To_v1alpha2_Network(a.(*v1alpha3.Network), b.(*Network), scope)
-	}); err != nil {
-		return err
-	}
-	if err := s.AddGeneratedConversionFunc((*NetworkSpec)(nil), (*v1alpha3.NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(a.(*NetworkSpec), b.(*v1alpha3.NetworkSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*RouteTable)(nil), (*v1alpha3.RouteTable)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_RouteTable_To_v1alpha3_RouteTable(a.(*RouteTable), b.(*v1alpha3.RouteTable), scope)
 	}); err != nil {
@@ -234,11 +224,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(a.(*v1alpha3.SubnetSpec), b.(*SubnetSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VPCSpec)(nil), (*v1alpha3.VPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(a.(*VPCSpec), b.(*v1alpha3.VPCSpec), scope)
 	}); err != nil {
@@ -274,6 +259,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*NetworkSpec)(nil), (*v1alpha3.NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(a.(*NetworkSpec), b.(*v1alpha3.NetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.AWSClusterSpec)(nil), (*AWSClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(a.(*v1alpha3.AWSClusterSpec), b.(*AWSClusterSpec), scope)
 	}); err != nil {
@@ -324,6 +314,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.Network)(nil), (*Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Network_To_v1alpha2_Network(a.(*v1alpha3.Network), b.(*Network), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(a.(*v1alpha3.SubnetSpec), b.(*SubnetSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VPCSpec)(nil), (*VPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(a.(*v1alpha3.VPCSpec), b.(*VPCSpec), scope)
 	}); err != nil {
@@ -451,6 +451,13 @@ func autoConvert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(in *v1alpha3
 	// WARNING: in.ImageLookupOrg requires manual conversion: does not exist in peer-type
 	// WARNING: in.ImageLookupBaseOS requires manual conversion: does not exist in peer-type
 	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalTrustedCAs requires manual conversion: does not exist in peer-type
+	// WARNING: in.RegistryCredentials requires manual conversion: does not exist in peer-type
+	// WARNING: in.RegistryMirrors requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthReporting requires manual conversion: does not exist in peer-type
+	// WARNING: in.NTP requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeletionOrder requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultInstanceProfiles requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -472,6 +479,7 @@ func autoConvert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(in *v1al
 	// WARNING: in.FailureDomains requires manual conversion: does not exist in peer-type
 	// WARNING: in.Bastion requires manual conversion: inconvertible types (*sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3.Instance vs sigs.k8s.io/cluster-api-provider-aws/api/v1alpha2.Instance)
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeletedResources requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -490,6 +498,7 @@ func autoConvert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in
 	// WARNING: in.CrossZoneLoadBalancing requires manual conversion: does not exist in peer-type
 	// WARNING: in.Subnets requires manual conversion: does not exist in peer-type
 	// WARNING: in.AdditionalSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSRecord requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -598,23 +607,49 @@ func autoConvert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(in *v1alpha3
 	// WARNING: in.ImageLookupFormat requires manual conversion: does not exist in peer-type
 	out.ImageLookupOrg = in.ImageLookupOrg
 	// WARNING: in.ImageLookupBaseOS requires manual conversion: does not exist in peer-type
+	// WARNING: in.AMIEncryptionKey requires manual conversion: does not exist in peer-type
 	out.InstanceType = in.InstanceType
+	// WARNING: in.InstanceRequirements requires manual conversion: does not exist in peer-type
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
+	// WARNING: in.ExcludeClusterTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.VolumeTags requires manual conversion: does not exist in peer-type
 	out.IAMInstanceProfile = in.IAMInstanceProfile
 	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
 	out.AdditionalSecurityGroups = *(*[]AWSResourceReference)(unsafe.Pointer(&in.AdditionalSecurityGroups))
 	// WARNING: in.FailureDomain requires manual conversion: does not exist in peer-type
 	out.Subnet = (*AWSResourceReference)(unsafe.Pointer(in.Subnet))
+	// WARNING: in.OutpostARN requires manual conversion: does not exist in peer-type
 	if err := v1.Convert_Pointer_string_To_string(&in.SSHKeyName, &out.SSHKeyName, s); err != nil {
 		return err
 	}
 	// WARNING: in.RootVolume requires manual conversion: does not exist in peer-type
 	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
+	// WARNING: in.ContainerRuntimeVolume requires manual conversion: does not exist in peer-type
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
 	// WARNING: in.UncompressedUserData requires manual conversion: does not exist in peer-type
 	// WARNING: in.CloudInit requires manual conversion: inconvertible types (sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3.CloudInit vs *sigs.k8s.io/cluster-api-provider-aws/api/v1alpha2.CloudInit)
 	// WARNING: in.SpotMarketOptions requires manual conversion: does not exist in peer-type
 	// WARNING: in.Tenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostPlacement requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupName requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationPreference requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationID requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceMetadataOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeLabelTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.NVIDIADriver requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadIPVSModules requires manual conversion: does not exist in peer-type
+	// WARNING: in.Sysctls requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImageGC requires manual conversion: does not exist in peer-type
+	// WARNING: in.ContainerLogRotation requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityFallback requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeLabels requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeTaints requires manual conversion: does not exist in peer-type
+	// WARNING: in.EvictionThresholds requires manual conversion: does not exist in peer-type
+	// WARNING: in.MonitoringTargetGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalBootConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.TerminationLogUpload requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -635,6 +670,16 @@ func autoConvert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(in *v1al
 	// WARNING: in.FailureReason requires manual conversion: does not exist in peer-type
 	// WARNING: in.FailureMessage requires manual conversion: does not exist in peer-type
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	// WARNING: in.AssignedPrivateIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapDataHash requires manual conversion: does not exist in peer-type
+	// WARNING: in.OutpostARN requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImageID requires manual conversion: does not exist in peer-type
+	// WARNING: in.AvailabilityZone requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceMetadataOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.TerminationLogLocation requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationEndTime requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootVolume requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -758,6 +803,7 @@ func autoConvert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(
 	out.ID = (*string)(unsafe.Pointer(in.ID))
 	out.ARN = (*string)(unsafe.Pointer(in.ARN))
 	out.Filters = *(*[]v1alpha3.Filter)(unsafe.Pointer(&in.Filters))
+	out.FilterSelectionScheme = (*v1alpha3.FilterSelectionScheme)(unsafe.Pointer(in.FilterSelectionScheme))
 	return nil
 }
 
@@ -770,6 +816,7 @@ func autoConvert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(
 	out.ID = (*string)(unsafe.Pointer(in.ID))
 	out.ARN = (*string)(unsafe.Pointer(in.ARN))
 	out.Filters = *(*[]Filter)(unsafe.Pointer(&in.Filters))
+	out.FilterSelectionScheme = (*FilterSelectionScheme)(unsafe.Pointer(in.FilterSelectionScheme))
 	return nil
 }
 
@@ -831,6 +878,7 @@ func Convert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB(in *ClassicELB, out *v1a
 func autoConvert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(in *v1alpha3.ClassicELB, out *ClassicELB, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DNSName = in.DNSName
+	// WARNING: in.CanonicalHostedZoneID requires manual conversion: does not exist in peer-type
 	out.Scheme = ClassicELBScheme(in.Scheme)
 	// WARNING: in.AvailabilityZones requires manual conversion: does not exist in peer-type
 	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
@@ -1021,9 +1069,17 @@ func autoConvert_v1alpha3_Instance_To_v1alpha2_Instance(in *v1alpha3.Instance, o
 	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
 	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
+	// WARNING: in.VolumeTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.VolumeIDs requires manual conversion: does not exist in peer-type
 	// WARNING: in.AvailabilityZone requires manual conversion: does not exist in peer-type
 	// WARNING: in.SpotMarketOptions requires manual conversion: does not exist in peer-type
 	// WARNING: in.Tenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupName requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationPreference requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceMetadataOptions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1045,34 +1101,55 @@ func autoConvert_v1alpha3_Network_To_v1alpha2_Network(in *v1alpha3.Network, out
 	if err := Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(&in.APIServerELB, &out.APIServerELB, s); err != nil {
 		return err
 	}
+	// WARNING: in.SubnetGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.VPCInstanceTenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.BlackholeNetworkInterfaceID requires manual conversion: does not exist in peer-type
+	// WARNING: in.InternetGatewayNotRequired requires manual conversion: does not exist in peer-type
+	// WARNING: in.DHCPOptionsSet requires manual conversion: does not exist in peer-type
+	// WARNING: in.SharedVPC requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_Network_To_v1alpha2_Network is an autogenerated conversion function.
-func Convert_v1alpha3_Network_To_v1alpha2_Network(in *v1alpha3.Network, out *Network, s conversion.Scope) error {
-	return autoConvert_v1alpha3_Network_To_v1alpha2_Network(in, out, s)
-}
-
 func autoConvert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(in *NetworkSpec, out *v1alpha3.NetworkSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
 		return err
 	}
-	out.Subnets = *(*v1alpha3.Subnets)(unsafe.Pointer(&in.Subnets))
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(v1alpha3.Subnets, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Subnets = nil
+	}
 	return nil
 }
 
-// Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec is an autogenerated conversion function.
-func Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(in *NetworkSpec, out *v1alpha3.NetworkSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(in *v1alpha3.NetworkSpec, out *NetworkSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
 		return err
 	}
-	out.Subnets = *(*Subnets)(unsafe.Pointer(&in.Subnets))
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(Subnets, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Subnets = nil
+	}
 	// WARNING: in.CNI requires manual conversion: does not exist in peer-type
 	// WARNING: in.SecurityGroupOverrides requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.BlackholeRoutes requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayMonitoring requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1146,14 +1223,16 @@ func autoConvert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in *v1alpha3.SubnetS
 	out.RouteTableID = (*string)(unsafe.Pointer(in.RouteTableID))
 	out.NatGatewayID = (*string)(unsafe.Pointer(in.NatGatewayID))
 	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	// WARNING: in.OutpostARN requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6CidrBlock requires manual conversion: does not exist in peer-type
+	// WARNING: in.AssignIPv6AddressOnCreation requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateIPPool requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkBorderGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayNetworkBorderGroup requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec is an autogenerated conversion function.
-func Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in *v1alpha3.SubnetSpec, out *SubnetSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(in *VPCSpec, out *v1alpha3.VPCSpec, s conversion.Scope) error {
 	out.ID = in.ID
 	out.CidrBlock = in.CidrBlock
@@ -1174,5 +1253,8 @@ func autoConvert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(in *v1alpha3.VPCSpec, out
 	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
 	// WARNING: in.AvailabilityZoneUsageLimit requires manual conversion: does not exist in peer-type
 	// WARNING: in.AvailabilityZoneSelection requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceTenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.DHCPOptionsSetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.OwnerAccountID requires manual conversion: does not exist in peer-type
 	return nil
 }
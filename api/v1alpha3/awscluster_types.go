@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha3
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
@@ -82,6 +83,92 @@ type AWSClusterSpec struct {
 	// Bastion contains options to configure the bastion host.
 	// +optional
 	Bastion Bastion `json:"bastion"`
+
+	// AdditionalTrustedCAs is a reference to a key in a Secret, in the same namespace as the AWSCluster,
+	// holding one or more PEM-encoded CA certificates. When set, the certificates are rendered into the
+	// bootstrap user data of every machine in the cluster and added to the node's trust store before
+	// the node joins the cluster.
+	// +optional
+	AdditionalTrustedCAs *corev1.SecretKeySelector `json:"additionalTrustedCAs,omitempty"`
+
+	// RegistryCredentials is a reference to a key in a Secret, in the same namespace as the AWSCluster,
+	// holding container registry credentials in the Docker config JSON format, such as the
+	// .dockerconfigjson key of a Secret of type kubernetes.io/dockerconfigjson. When set, the credentials
+	// are rendered into the containerd configuration in the bootstrap user data of every machine in the
+	// cluster, so that images can be pulled from the registries as soon as the node boots.
+	// +optional
+	RegistryCredentials *corev1.SecretKeySelector `json:"registryCredentials,omitempty"`
+
+	// RegistryMirrors configures mirrors containerd pulls images through instead of pulling them from
+	// the upstream registries. When set, the mirrors and their TLS settings are rendered into the
+	// containerd registry host configuration in the bootstrap user data of every machine in the cluster.
+	// +optional
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+
+	// HealthReporting configures an external endpoint that the controller POSTs the lifecycle and
+	// health transitions of the cluster's machines to: instance created, running, unhealthy and
+	// terminated. Delivery is best effort and never blocks reconciliation.
+	// +optional
+	HealthReporting *HealthReportingSpec `json:"healthReporting,omitempty"`
+
+	// NTP configures the time servers the cluster's machines synchronize their clocks with. When set,
+	// the servers are rendered into the chrony configuration in the bootstrap user data of every
+	// machine in the cluster, replacing the time sources of the image, before the node joins the cluster.
+	// +optional
+	NTP *NTPSpec `json:"ntp,omitempty"`
+
+	// DeletionOrder overrides the order in which the cluster's resources are torn down when the cluster
+	// is deleted. Resources that are not listed are deleted after the listed ones, in the default order:
+	// LoadBalancer, Bastion, SecurityGroups, Network. The security groups can only be deleted once the
+	// load balancer and bastion host using them are gone, and the network is always deleted last.
+	// +optional
+	DeletionOrder []ClusterResource `json:"deletionOrder,omitempty"`
+
+	// DefaultInstanceProfiles are the IAM instance profiles the cluster's machines are launched with, by
+	// role, unless their AWSMachine sets an instance profile. The instance profiles must exist.
+	// +optional
+	DefaultInstanceProfiles *DefaultInstanceProfiles `json:"defaultInstanceProfiles,omitempty"`
+}
+
+// ClusterResource is a kind of resource managed for the cluster.
+// +kubebuilder:validation:Enum=LoadBalancer;Bastion;SecurityGroups;Network
+type ClusterResource string
+
+var (
+	// ClusterResourceLoadBalancer is the API server load balancer.
+	ClusterResourceLoadBalancer = ClusterResource("LoadBalancer")
+
+	// ClusterResourceBastion is the bastion host.
+	ClusterResourceBastion = ClusterResource("Bastion")
+
+	// ClusterResourceSecurityGroups are the security groups of the cluster.
+	ClusterResourceSecurityGroups = ClusterResource("SecurityGroups")
+
+	// ClusterResourceNetwork is the VPC, its subnets, gateways and route tables.
+	ClusterResourceNetwork = ClusterResource("Network")
+)
+
+// DefaultClusterDeletionOrder is the order in which the cluster's resources are deleted when no
+// deletion order is defined.
+var DefaultClusterDeletionOrder = []ClusterResource{
+	ClusterResourceLoadBalancer,
+	ClusterResourceBastion,
+	ClusterResourceSecurityGroups,
+	ClusterResourceNetwork,
+}
+
+// ResolvedDeletionOrder returns the order in which the cluster's resources are deleted: the
+// resources of the deletion order first, followed by the remaining ones in the default order.
+func (s *AWSClusterSpec) ResolvedDeletionOrder() []ClusterResource {
+	order := make([]ClusterResource, 0, len(DefaultClusterDeletionOrder))
+	seen := map[ClusterResource]bool{}
+	for _, resource := range append(append([]ClusterResource{}, s.DeletionOrder...), DefaultClusterDeletionOrder...) {
+		if !seen[resource] {
+			seen[resource] = true
+			order = append(order, resource)
+		}
+	}
+	return order
 }
 
 type Bastion struct {
@@ -138,6 +225,21 @@ type AWSLoadBalancerSpec struct {
 	// This is optional - if not provided new security groups will be created for the load balancer
 	// +optional
 	AdditionalSecurityGroups []string `json:"additionalSecurityGroups,omitempty"`
+
+	// DNSRecord configures a Route53 alias record pointing at the load balancer. When set, the record
+	// name is used as the control plane endpoint instead of the load balancer's DNS name, so that the
+	// endpoint stays the same if the load balancer is ever recreated.
+	// +optional
+	DNSRecord *LoadBalancerDNSRecord `json:"dnsRecord,omitempty"`
+}
+
+// LoadBalancerDNSRecord defines a Route53 alias record managed for the control plane load balancer.
+type LoadBalancerDNSRecord struct {
+	// HostedZoneID is the ID of the Route53 hosted zone the record is created in.
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// Name is the fully qualified domain name of the record, e.g. api.my-cluster.example.com.
+	Name string `json:"name"`
 }
 
 // AWSClusterStatus defines the observed state of AWSCluster
@@ -148,6 +250,11 @@ type AWSClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Bastion        *Instance                `json:"bastion,omitempty"`
 	Conditions     clusterv1.Conditions     `json:"conditions,omitempty"`
+
+	// DeletedResources lists the resources torn down so far while the cluster is being deleted,
+	// in the order they were deleted.
+	// +optional
+	DeletedResources []ClusterResource `json:"deletedResources,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -51,6 +51,23 @@ func (r *AWSCluster) ValidateCreate() error {
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, isValidSSHKey(r.Spec.SSHKeyName)...)
+	allErrs = append(allErrs, isValidSecretKeySelector(r.Spec.AdditionalTrustedCAs, field.NewPath("spec", "additionalTrustedCAs"))...)
+	allErrs = append(allErrs, isValidSecretKeySelector(r.Spec.RegistryCredentials, field.NewPath("spec", "registryCredentials"))...)
+	allErrs = append(allErrs, r.Spec.ValidateRegistryMirrors(field.NewPath("spec", "registryMirrors"))...)
+	allErrs = append(allErrs, r.Spec.HealthReporting.Validate(field.NewPath("spec", "healthReporting"))...)
+	allErrs = append(allErrs, r.Spec.NTP.Validate(field.NewPath("spec", "ntp"))...)
+	allErrs = append(allErrs, r.Spec.DefaultInstanceProfiles.Validate(field.NewPath("spec", "defaultInstanceProfiles"))...)
+	allErrs = append(allErrs, r.validateSubnetPrivateIPPools()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetGroups(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateEgress(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.NatGatewayMonitoring.Validate(field.NewPath("spec", "networkSpec", "natGatewayMonitoring"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkBorderGroups(field.NewPath("spec", "networkSpec"), r.Spec.Region)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateBlackholeRoutes(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateInstanceTenancy(nil, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateDHCPOptionsSet(nil, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSharedVPC(nil, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.ValidateDeletionOrder(field.NewPath("spec", "deletionOrder"))...)
+	allErrs = append(allErrs, r.validateControlPlaneDNSRecord()...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -89,6 +106,13 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		)
 	}
 
+	if !reflect.DeepEqual(existingLoadBalancer.DNSRecord, newLoadBalancer.DNSRecord) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "dnsRecord"),
+				newLoadBalancer.DNSRecord, "field is immutable"),
+		)
+	}
+
 	if !reflect.DeepEqual(oldC.Spec.ControlPlaneEndpoint, clusterv1.APIEndpoint{}) &&
 		!reflect.DeepEqual(r.Spec.ControlPlaneEndpoint, oldC.Spec.ControlPlaneEndpoint) {
 		allErrs = append(allErrs,
@@ -97,10 +121,48 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 	}
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, isValidSecretKeySelector(r.Spec.AdditionalTrustedCAs, field.NewPath("spec", "additionalTrustedCAs"))...)
+	allErrs = append(allErrs, isValidSecretKeySelector(r.Spec.RegistryCredentials, field.NewPath("spec", "registryCredentials"))...)
+	allErrs = append(allErrs, r.Spec.ValidateRegistryMirrors(field.NewPath("spec", "registryMirrors"))...)
+	allErrs = append(allErrs, r.Spec.HealthReporting.Validate(field.NewPath("spec", "healthReporting"))...)
+	allErrs = append(allErrs, r.Spec.NTP.Validate(field.NewPath("spec", "ntp"))...)
+	allErrs = append(allErrs, r.Spec.DefaultInstanceProfiles.Validate(field.NewPath("spec", "defaultInstanceProfiles"))...)
+	allErrs = append(allErrs, r.validateSubnetPrivateIPPools()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetGroups(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateEgress(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.NatGatewayMonitoring.Validate(field.NewPath("spec", "networkSpec", "natGatewayMonitoring"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkBorderGroups(field.NewPath("spec", "networkSpec"), r.Spec.Region)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateBlackholeRoutes(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateInstanceTenancy(&oldC.Spec.NetworkSpec, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateDHCPOptionsSet(&oldC.Spec.NetworkSpec, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSharedVPC(&oldC.Spec.NetworkSpec, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.ValidateDeletionOrder(field.NewPath("spec", "deletionOrder"))...)
+	allErrs = append(allErrs, r.validateControlPlaneDNSRecord()...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
+func (r *AWSCluster) validateControlPlaneDNSRecord() field.ErrorList {
+	if r.Spec.ControlPlaneLoadBalancer == nil {
+		return nil
+	}
+	return r.Spec.ControlPlaneLoadBalancer.DNSRecord.Validate(field.NewPath("spec", "controlPlaneLoadBalancer", "dnsRecord"))
+}
+
+func (r *AWSCluster) validateSubnetPrivateIPPools() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, subnet := range r.Spec.NetworkSpec.Subnets {
+		if subnet.PrivateIPPool == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "networkSpec", "subnets").Index(i).Child("privateIPPool")
+		allErrs = append(allErrs, subnet.PrivateIPPool.Validate(subnet.CidrBlock, fldPath)...)
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) Default() {
 	SetDefaults_Bastion(&r.Spec.Bastion)
 	SetDefaults_NetworkSpec(&r.Spec.NetworkSpec)
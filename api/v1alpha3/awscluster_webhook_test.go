@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
@@ -51,6 +52,408 @@ func TestAWSCluster_ValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "health reporting URL is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					HealthReporting: &HealthReportingSpec{URL: "ops.example.com/hooks/nodes"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health reporting should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					HealthReporting: &HealthReportingSpec{
+						URL:                    "https://ops.example.com/hooks/nodes",
+						AuthorizationSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ops-token"}, Key: "authorization"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "default instance profiles should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DefaultInstanceProfiles: &DefaultInstanceProfiles{
+						ControlPlane: "control-plane.cluster-api-provider-aws.sigs.k8s.io",
+						Node:         "nodes.cluster-api-provider-aws.sigs.k8s.io",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "default instance profile with an invalid name is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DefaultInstanceProfiles: &DefaultInstanceProfiles{
+						Node: "arn:aws:iam::123456789012:instance-profile/nodes",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "registry mirror with a CA should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					RegistryMirrors: []RegistryMirror{{
+						Registry: "docker.io",
+						Endpoint: "https://mirror.example.com:5000",
+						TLS: &RegistryMirrorTLS{
+							CASecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "mirror-ca"}, Key: "ca.crt"},
+						},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "registry mirror with a CA and skipping verification is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					RegistryMirrors: []RegistryMirror{{
+						Registry: "docker.io",
+						Endpoint: "https://mirror.example.com:5000",
+						TLS: &RegistryMirrorTLS{
+							CASecretRef:        &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "mirror-ca"}, Key: "ca.crt"},
+							InsecureSkipVerify: true,
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "registry mirror with TLS settings on an http endpoint is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					RegistryMirrors: []RegistryMirror{{
+						Registry: "docker.io",
+						Endpoint: "http://mirror.example.com:5000",
+						TLS:      &RegistryMirrorTLS{InsecureSkipVerify: true},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NTP servers should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NTP: &NTPSpec{Servers: []string{"ntp.example.com", "10.0.0.10"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "NTP server that is not a hostname is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NTP: &NTPSpec{Servers: []string{"ntp.example.com:123"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NTP without servers and without the Amazon Time Sync Service is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NTP: &NTPSpec{AmazonTimeSync: aws.Bool(false)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet group without a default route is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						SubnetGroups: []SubnetGroupSpec{{Name: "isolated"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet referencing an undefined subnet group is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{{CidrBlock: "10.0.0.0/24", SubnetGroup: "isolated"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet group should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						SubnetGroups: []SubnetGroupSpec{{Name: "isolated", DefaultRoute: RouteTarget{TransitGatewayID: aws.String("tgw-01")}}},
+						Subnets:      Subnets{{CidrBlock: "10.0.0.0/24", SubnetGroup: "isolated"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "private-only cluster without a subnet group is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{{CidrBlock: "10.0.0.0/24"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "private-only cluster in an existing VPC is left to the controller",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC:     VPCSpec{ID: "vpc-01"},
+						Subnets: Subnets{{ID: "subnet-01"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "NAT gateway alarm action that is not an ARN is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NatGatewayMonitoring: &NatGatewayMonitoringSpec{AlarmActions: []string{"alerts"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NAT gateway alarm action ARN should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NatGatewayMonitoring: &NatGatewayMonitoringSpec{AlarmActions: []string{"arn:aws:sns:us-west-2:123456789012:alerts"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "network border group on a private subnet is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-west-2",
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{{CidrBlock: "10.0.0.0/24", NetworkBorderGroup: aws.String("us-west-2-lax-1")}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "network border group of another region is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-west-2",
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{{CidrBlock: "10.0.0.0/24", IsPublic: true, NetworkBorderGroup: aws.String("us-east-1-bos-1")}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "network border group should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-west-2",
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{{CidrBlock: "10.0.0.0/24", IsPublic: true, NetworkBorderGroup: aws.String("us-west-2-lax-1")}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "deletion order listing a resource twice is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DeletionOrder: []ClusterResource{ClusterResourceBastion, ClusterResourceBastion},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "deletion order deleting security groups before the load balancer is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DeletionOrder: []ClusterResource{ClusterResourceSecurityGroups},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "deletion order should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DeletionOrder: []ClusterResource{ClusterResourceBastion, ClusterResourceLoadBalancer},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "control plane DNS record without a hosted zone is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						DNSRecord: &LoadBalancerDNSRecord{Name: "api.example.com"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance tenancy of an existing VPC is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{ID: "vpc-1", InstanceTenancy: "dedicated"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance tenancy of a managed VPC should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{InstanceTenancy: "dedicated"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "DHCP options set of a managed VPC should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{DHCPOptionsSetID: aws.String("dopt-0123456789abcdef0")},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "DHCP options set of an existing VPC is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{ID: "vpc-1", DHCPOptionsSetID: aws.String("dopt-0123456789abcdef0")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "DHCP options set reference that is not an ID is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{DHCPOptionsSetID: aws.String("corp-dhcp")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "VPC shared by another account should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{ID: "vpc-1", OwnerAccountID: "111111111111"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shared VPC without an ID is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{OwnerAccountID: "111111111111"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "shared VPC owner that is not an account ID is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{ID: "vpc-1", OwnerAccountID: "network-account"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "blackhole route overlapping the VPC is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC:             VPCSpec{CidrBlock: "10.0.0.0/16"},
+						BlackholeRoutes: []BlackholeRoute{{DestinationCIDRBlock: "10.0.0.0/8"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "blackhole route replacing the default route is not valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						BlackholeRoutes: []BlackholeRoute{{DestinationCIDRBlock: "0.0.0.0/0"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "blackhole route outside of the VPC should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC:             VPCSpec{CidrBlock: "10.0.0.0/16"},
+						BlackholeRoutes: []BlackholeRoute{{DestinationCIDRBlock: "192.0.2.0/24"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "control plane DNS record should be valid",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						DNSRecord: &LoadBalancerDNSRecord{HostedZoneID: "Z0123456789", Name: "api.example.com"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,6 +527,42 @@ func TestAWSCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "VPC instance tenancy is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{InstanceTenancy: "dedicated"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{InstanceTenancy: "default"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer dnsRecord is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						DNSRecord: &LoadBalancerDNSRecord{HostedZoneID: "Z0123456789", Name: "api.example.com"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						DNSRecord: &LoadBalancerDNSRecord{HostedZoneID: "Z0123456789", Name: "k8s.example.com"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "controlPlaneEndpoint is immutable",
 			oldCluster: &AWSCluster{
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha3
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/errors"
@@ -26,6 +27,27 @@ const (
 	// MachineFinalizer allows ReconcileAWSMachine to clean up AWS resources associated with AWSMachine before
 	// removing it from the apiserver.
 	MachineFinalizer = "awsmachine.infrastructure.cluster.x-k8s.io"
+
+	// SkipQuorumCheckAnnotation can be set on a control plane AWSMachine to terminate its instance
+	// without waiting for the rest of the control plane to maintain etcd quorum.
+	SkipQuorumCheckAnnotation = "awsmachine.infrastructure.cluster.x-k8s.io/skip-quorum-check"
+
+	// StatusChecksImpairedSinceAnnotation records when the EC2 status checks of the machine's instance
+	// started reporting it as impaired.
+	StatusChecksImpairedSinceAnnotation = "awsmachine.infrastructure.cluster.x-k8s.io/status-checks-impaired-since"
+
+	// ImpairedAvailabilityZoneAnnotation records the availability zone of an instance that was marked
+	// as failed after its status checks stayed impaired, so that replacements can avoid placing
+	// machines in it.
+	ImpairedAvailabilityZoneAnnotation = "awsmachine.infrastructure.cluster.x-k8s.io/impaired-availability-zone"
+
+	// ImpairedAvailabilityZoneUntilAnnotation records until when, in RFC 3339 format, the availability
+	// zone in ImpairedAvailabilityZoneAnnotation should be avoided.
+	ImpairedAvailabilityZoneUntilAnnotation = "awsmachine.infrastructure.cluster.x-k8s.io/impaired-availability-zone-until"
+
+	// ReportedHealthAnnotation records the last health transition of the machine that was reported to
+	// the cluster's health reporting endpoint, so that every transition is only reported once.
+	ReportedHealthAnnotation = "awsmachine.infrastructure.cluster.x-k8s.io/reported-health"
 )
 
 // SecretBackend defines variants for backend secret storage.
@@ -71,16 +93,44 @@ type AWSMachineSpec struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// AMIEncryptionKey is the KMS key the AMI must be encrypted with before an instance is launched from it.
+	// Can be either a KMS key ID or ARN. If the resolved AMI is not encrypted with this key, an encrypted
+	// copy of it is made in the cluster's region and the instance is launched from the copy.
+	// +optional
+	AMIEncryptionKey string `json:"amiEncryptionKey,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
+	// +optional
 	InstanceType string `json:"instanceType,omitempty"`
 
+	// InstanceRequirements describes the instance type to create by its attributes rather than
+	// its name. Out of the matching instance types, the one offered in the most of the machine's
+	// availability zones is picked, cheaper (smaller) instance types first. The instance type is
+	// picked when the instance is created and recorded on the status. Mutually exclusive with
+	// InstanceType.
+	// +optional
+	InstanceRequirements *InstanceRequirements `json:"instanceRequirements,omitempty"`
+
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
 	// AWS provider. If both the AWSCluster and the AWSMachine specify the same tag name with different values, the
 	// AWSMachine's value takes precedence.
 	// +optional
 	AdditionalTags Tags `json:"additionalTags,omitempty"`
 
-	// IAMInstanceProfile is a name of an IAM instance profile to assign to the instance
+	// ExcludeClusterTags is a list of keys of the AWSCluster's additional tags that are not applied to
+	// the instance and its volumes, e.g. cost allocation tags that should not cover spot instances. Tags
+	// of the same name in the AWSMachine's AdditionalTags are still applied.
+	// +optional
+	ExcludeClusterTags []string `json:"excludeClusterTags,omitempty"`
+
+	// VolumeTags is an optional set of tags to add to the EBS volumes of the instance only, e.g. for
+	// snapshot policies to select them by. When set, the volumes are tagged with the additional tags
+	// of the AWSCluster and AWSMachine, merged with these tags, which take precedence.
+	// +optional
+	VolumeTags Tags `json:"volumeTags,omitempty"`
+
+	// IAMInstanceProfile is the name or the ARN of an IAM instance profile to assign to the instance.
+	// Defaults to the AWSCluster's default instance profile for the machine's role, if any.
 	// +optional
 	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
 
@@ -109,6 +159,12 @@ type AWSMachineSpec struct {
 	// +optional
 	Subnet *AWSResourceReference `json:"subnet,omitempty"`
 
+	// OutpostARN is the ARN of the AWS Outpost to launch the instance on. The instance is placed
+	// into one of the cluster's subnets on the Outpost, or into the referenced subnet if it is on
+	// the Outpost. If not specified, the instance is placed into a subnet in the region.
+	// +optional
+	OutpostARN string `json:"outpostArn,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the instance. Valid values are empty string (do not use SSH keys), a valid SSH key name, or omitted (use the default SSH key name)
 	// +optional
 	SSHKeyName *string `json:"sshKeyName,omitempty"`
@@ -121,6 +177,13 @@ type AWSMachineSpec struct {
 	// +optional
 	NonRootVolumes []*Volume `json:"nonRootVolumes,omitempty"`
 
+	// ContainerRuntimeVolume is an additional volume dedicated to the storage of the container
+	// runtime, such as images and container layers. It is attached along with the non root volumes,
+	// then formatted and mounted at the data root of the container runtime by the bootstrap user data
+	// before the node joins the cluster.
+	// +optional
+	ContainerRuntimeVolume *ContainerRuntimeVolume `json:"containerRuntimeVolume,omitempty"`
+
 	// NetworkInterfaces is a list of ENIs to associate with the instance.
 	// A maximum of 2 may be specified.
 	// +optional
@@ -147,6 +210,119 @@ type AWSMachineSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=default;dedicated;host
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// HostPlacement configures the Dedicated Host the instance is launched on. Requires the host tenancy.
+	// +optional
+	HostPlacement *HostPlacement `json:"hostPlacement,omitempty"`
+
+	// PlacementGroupName is the name of an existing spread placement group to launch the instance in,
+	// so that it does not share underlying hardware with the other instances of the group. A spread
+	// placement group holds at most seven running instances per availability zone, the instance is
+	// not launched if its availability zone is full. Cannot be used with the host tenancy.
+	// +optional
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// CapacityReservationPreference controls whether the instance consumes reserved capacity: open
+	// launches it into any open capacity reservation with matching attributes, none never launches it
+	// into a capacity reservation and targeted launches it into the capacity reservation given by
+	// CapacityReservationID. Defaults to the EC2 behavior, which is open.
+	// +optional
+	// +kubebuilder:validation:Enum:=open;none;targeted
+	CapacityReservationPreference CapacityReservationPreference `json:"capacityReservationPreference,omitempty"`
+
+	// CapacityReservationID is the ID of the capacity reservation the instance is launched into with
+	// the targeted preference. The machine's subnet or failure domain must be in the availability
+	// zone of the reservation.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// InstanceMetadataOptions configures the instance metadata service of the instance. Disabling
+	// its HTTP endpoint is only supported for worker machines whose nodes neither run the in-tree
+	// AWS cloud provider nor otherwise depend on the instance profile, e.g. with IRSA.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// NodeLabelTags maps labels of the Kubernetes node backing this machine to tags on the EC2 instance.
+	// Each key is a node label and its value is the tag key the label's value is applied under. Tags are
+	// added, updated and removed as the labels change on the node.
+	// +optional
+	NodeLabelTags map[string]string `json:"nodeLabelTags,omitempty"`
+
+	// NVIDIADriver configures the installation of the NVIDIA driver and container toolkit on GPU instances.
+	// +optional
+	NVIDIADriver *NVIDIADriverOptions `json:"nvidiaDriver,omitempty"`
+
+	// LoadIPVSModules loads the kernel modules kube-proxy needs to run in IPVS mode before the kubelet
+	// starts. It does not configure kube-proxy, whose mode is set in the cluster's kube-proxy configuration.
+	// +optional
+	LoadIPVSModules bool `json:"loadIPVSModules,omitempty"`
+
+	// Sysctls is a list of kernel parameters to set on the node before the kubelet starts.
+	// Only parameters from a set of well-known networking, file system and memory tunables are allowed.
+	// +optional
+	Sysctls []Sysctl `json:"sysctls,omitempty"`
+
+	// ImageGC configures the disk usage thresholds at which the kubelet garbage collects unused
+	// container images from containerd on the node.
+	// +optional
+	ImageGC *ImageGCOptions `json:"imageGC,omitempty"`
+
+	// ContainerLogRotation configures how the kubelet rotates the container logs on the node.
+	// +optional
+	ContainerLogRotation *ContainerLogRotationOptions `json:"containerLogRotation,omitempty"`
+
+	// SubnetGroup is the name of a subnet group, defined in the cluster's network spec, to launch
+	// the instance into. Machines without a subnet group are only launched into subnets outside
+	// of any group. Ignored when a subnet is set explicitly.
+	// +optional
+	SubnetGroup string `json:"subnetGroup,omitempty"`
+
+	// SubnetTags selects the subnet to launch the instance into among the cluster's subnets in its
+	// failure domain, e.g. to pick a tier when there are several subnets per availability zone. Exactly
+	// one subnet of the failure domain must carry all of the given tags. Cannot be used with Subnet.
+	// +optional
+	SubnetTags Tags `json:"subnetTags,omitempty"`
+
+	// CapacityFallback, when set, retries the launch of the instance in other availability zones of
+	// the cluster when the chosen zone does not have enough capacity for the instance type. It only
+	// applies when neither a subnet nor a failure domain is set for the machine.
+	// +optional
+	CapacityFallback *CapacityFallbackOptions `json:"capacityFallback,omitempty"`
+
+	// NodeLabels are labels the node registers with, rendered into the kubelet's --node-labels flag
+	// in the bootstrap data, in addition to any labels set in the bootstrap configuration. Labels in
+	// the kubernetes.io and k8s.io namespaces are only allowed with the kubelet.kubernetes.io and
+	// node.kubernetes.io prefixes, as the kubelet refuses to set any other.
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are taints the node registers with, rendered into the kubelet's --register-with-taints
+	// flag in the bootstrap data, so that no workload is scheduled onto the node before it is configured.
+	// +optional
+	NodeTaints []corev1.Taint `json:"nodeTaints,omitempty"`
+
+	// EvictionThresholds configures the amount of memory and disk space left on the node below
+	// which the kubelet starts evicting pods.
+	// +optional
+	EvictionThresholds *EvictionThresholdsOptions `json:"evictionThresholds,omitempty"`
+
+	// MonitoringTargetGroup is a target group, separate from any application target groups, the
+	// instance is registered with on its metrics port while it is running, e.g. for Prometheus to
+	// scrape nodes through an internal Network Load Balancer.
+	// +optional
+	MonitoringTargetGroup *MonitoringTargetGroup `json:"monitoringTargetGroup,omitempty"`
+
+	// AdditionalBootConfig declares systemd units and install scripts, e.g. for node agents that have
+	// to run from early boot on, that are added to the bootstrap user data as a separate cloud-init
+	// part and set up before the bootstrap commands run.
+	// +optional
+	AdditionalBootConfig *AdditionalBootConfig `json:"additionalBootConfig,omitempty"`
+
+	// TerminationLogUpload uploads the journal of the instance to S3 before the instance is terminated
+	// on deletion, for post-mortem analysis. The upload is best effort: the instance is terminated once
+	// it completes, fails or times out.
+	// +optional
+	TerminationLogUpload *TerminationLogUpload `json:"terminationLogUpload,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -235,6 +411,53 @@ type AWSMachineStatus struct {
 	// Conditions defines current service state of the AWSMachine.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// AssignedPrivateIP is the primary private IP assigned to the instance from the private IP pool
+	// of its subnet, if the subnet has one.
+	// +optional
+	AssignedPrivateIP string `json:"assignedPrivateIP,omitempty"`
+
+	// BootstrapDataHash is the hex-encoded SHA-256 hash of the user data the instance was launched
+	// with, including the additional node configuration merged into the bootstrap data. It is used
+	// to tell when the bootstrap data changed after the instance was launched.
+	// +optional
+	BootstrapDataHash *string `json:"bootstrapDataHash,omitempty"`
+
+	// OutpostARN is the ARN of the AWS Outpost the instance was launched on, if any.
+	// +optional
+	OutpostARN string `json:"outpostArn,omitempty"`
+
+	// ImageID is the ID of the AMI the instance was launched from. It differs from the resolved AMI
+	// when the instance was launched from an encrypted copy of it.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// AvailabilityZone is the availability zone the instance was launched in.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// InstanceMetadataOptions are the options of the instance metadata service as reported by AWS.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// InstanceType is the type the instance was launched as.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// TerminationLogLocation is the S3 location the journal of the instance was uploaded to before it
+	// was terminated.
+	// +optional
+	TerminationLogLocation string `json:"terminationLogLocation,omitempty"`
+
+	// CapacityReservationEndTime is when the time-bounded capacity reservation the instance runs in
+	// ends. EC2 terminates the instance at that time.
+	// +optional
+	CapacityReservationEndTime *metav1.Time `json:"capacityReservationEndTime,omitempty"`
+
+	// RootVolume is the root volume of the instance, as last observed while converting it to the
+	// volume type of the spec.
+	// +optional
+	RootVolume *RootVolumeStatus `json:"rootVolume,omitempty"`
 }
 
 // +kubebuilder:object:root=true
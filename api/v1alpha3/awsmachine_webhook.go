@@ -23,6 +23,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -53,7 +54,27 @@ func (r *AWSMachine) ValidateCreate() error {
 	allErrs = append(allErrs, r.validateRootVolume()...)
 	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, isValidSSHKey(r.Spec.SSHKeyName)...)
+	allErrs = append(allErrs, ValidateIAMInstanceProfile(r.Spec.IAMInstanceProfile, field.NewPath("spec", "iamInstanceProfile"))...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, isValidSysctls(r.Spec.Sysctls, field.NewPath("spec", "sysctls"))...)
+	allErrs = append(allErrs, isValidImageGC(r.Spec.ImageGC, field.NewPath("spec", "imageGC"))...)
+	allErrs = append(allErrs, isValidContainerLogRotation(r.Spec.ContainerLogRotation, field.NewPath("spec", "containerLogRotation"))...)
+	allErrs = append(allErrs, isValidCapacityFallback(r.Spec.CapacityFallback, field.NewPath("spec", "capacityFallback"))...)
+	allErrs = append(allErrs, isValidNodeLabels(r.Spec.NodeLabels, field.NewPath("spec", "nodeLabels"))...)
+	allErrs = append(allErrs, isValidNodeTaints(r.Spec.NodeTaints, field.NewPath("spec", "nodeTaints"))...)
+	allErrs = append(allErrs, isValidEvictionThresholds(r.Spec.EvictionThresholds, field.NewPath("spec", "evictionThresholds"))...)
+	allErrs = append(allErrs, isValidMonitoringTargetGroup(r.Spec.MonitoringTargetGroup, field.NewPath("spec", "monitoringTargetGroup"))...)
+	_, controlPlane := r.Labels[clusterv1.MachineControlPlaneLabelName]
+	allErrs = append(allErrs, isValidInstanceMetadataOptions(r.Spec.InstanceMetadataOptions, controlPlane, field.NewPath("spec", "instanceMetadataOptions"))...)
+	allErrs = append(allErrs, isValidInstanceRequirements(r.Spec.InstanceRequirements, r.Spec.InstanceType, field.NewPath("spec", "instanceRequirements"))...)
+	allErrs = append(allErrs, isValidPlacementGroupName(r.Spec.PlacementGroupName, r.Spec.Tenancy, field.NewPath("spec", "placementGroupName"))...)
+	allErrs = append(allErrs, isValidSpotMarketOptions(r.Spec.SpotMarketOptions, controlPlane, field.NewPath("spec", "spotMarketOptions"))...)
+	allErrs = append(allErrs, isValidCapacityReservation(r.Spec.CapacityReservationPreference, r.Spec.CapacityReservationID, r.Spec.SpotMarketOptions, field.NewPath("spec"))...)
+	allErrs = append(allErrs, isValidHostPlacement(r.Spec.HostPlacement, r.Spec.Tenancy, field.NewPath("spec", "hostPlacement"))...)
+	allErrs = append(allErrs, isValidContainerRuntimeVolume(r.Spec.ContainerRuntimeVolume, r.Spec.NonRootVolumes, field.NewPath("spec", "containerRuntimeVolume"))...)
+	allErrs = append(allErrs, isValidAdditionalBootConfig(r.Spec.AdditionalBootConfig, field.NewPath("spec", "additionalBootConfig"))...)
+	allErrs = append(allErrs, isValidTerminationLogUpload(r.Spec.TerminationLogUpload, field.NewPath("spec", "terminationLogUpload"))...)
+	allErrs = append(allErrs, isValidSubnetTags(r.Spec.SubnetTags, r.Spec.Subnet, field.NewPath("spec", "subnetTags"))...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -92,10 +113,26 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 	delete(oldAWSMachineSpec, "additionalTags")
 	delete(newAWSMachineSpec, "additionalTags")
 
+	// allow changes to volumeTags
+	delete(oldAWSMachineSpec, "volumeTags")
+	delete(newAWSMachineSpec, "volumeTags")
+
+	// allow changes to nodeLabelTags
+	delete(oldAWSMachineSpec, "nodeLabelTags")
+	delete(newAWSMachineSpec, "nodeLabelTags")
+
 	// allow changes to additionalSecurityGroups
 	delete(oldAWSMachineSpec, "additionalSecurityGroups")
 	delete(newAWSMachineSpec, "additionalSecurityGroups")
 
+	// allow converting the root volume from gp2 to gp3, which is done in place
+	oldRootVolume, _ := oldAWSMachineSpec["rootVolume"].(map[string]interface{})
+	newRootVolume, _ := newAWSMachineSpec["rootVolume"].(map[string]interface{})
+	if oldRootVolume != nil && newRootVolume != nil && oldRootVolume["type"] == string(VolumeTypeGP2) && newRootVolume["type"] == string(VolumeTypeGP3) {
+		delete(oldRootVolume, "type")
+		delete(newRootVolume, "type")
+	}
+
 	// allow changes to secretPrefix, secretCount, and secureSecretsBackend
 	if cloudInit, ok := oldAWSMachineSpec["cloudInit"].(map[string]interface{}); ok {
 		delete(cloudInit, "secretPrefix")
@@ -18,13 +18,17 @@ package v1alpha3
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
 
 func TestAWSMachine_Create(t *testing.T) {
@@ -191,6 +195,510 @@ func TestAWSMachine_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "allowed sysctls are valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Sysctls: []Sysctl{
+						{Name: "net.netfilter.nf_conntrack_max", Value: "1048576"},
+						{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sysctls outside the allow-list are invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Sysctls: []Sysctl{
+						{Name: "kernel.panic", Value: "1"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sysctls with multi-line values are invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Sysctls: []Sysctl{
+						{Name: "net.core.somaxconn", Value: "1024\nkernel.panic=1"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "image GC thresholds are valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ImageGC: &ImageGCOptions{HighThresholdPercent: pointer.Int32Ptr(75), LowThresholdPercent: pointer.Int32Ptr(60)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "image GC low threshold above the default high threshold is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ImageGC: &ImageGCOptions{LowThresholdPercent: pointer.Int32Ptr(90)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "container log rotation is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ContainerLogRotation: &ContainerLogRotationOptions{MaxSize: "50Mi", MaxFiles: pointer.Int32Ptr(3)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "container log max size that is not a quantity is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ContainerLogRotation: &ContainerLogRotationOptions{MaxSize: "fifty megs"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "eviction thresholds are valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					EvictionThresholds: &EvictionThresholdsOptions{
+						Hard:            &EvictionSignals{MemoryAvailable: "500Mi", NodeFSAvailable: "5%"},
+						Soft:            &EvictionSignals{MemoryAvailable: "1Gi"},
+						SoftGracePeriod: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "eviction threshold that is neither a quantity nor a percentage is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					EvictionThresholds: &EvictionThresholdsOptions{
+						Hard: &EvictionSignals{ImageFSAvailable: "150%"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "soft eviction thresholds without a grace period are invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					EvictionThresholds: &EvictionThresholdsOptions{
+						Soft: &EvictionSignals{MemoryAvailable: "1Gi"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "monitoring target group is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					MonitoringTargetGroup: &MonitoringTargetGroup{
+						ARN:  "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/metrics/0123456789abcdef",
+						Port: 9100,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "disabled instance metadata service on a worker is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceMetadataOptions: &InstanceMetadataOptions{HTTPEndpoint: InstanceMetadataEndpointStateDisabled},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "disabled instance metadata service on a control plane machine is invalid",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+				},
+				Spec: AWSMachineSpec{
+					InstanceMetadataOptions: &InstanceMetadataOptions{HTTPEndpoint: InstanceMetadataEndpointStateDisabled},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance profile name is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					IAMInstanceProfile: "s3-nodes.cluster-api-provider-aws.sigs.k8s.io",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance profile ARN is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					IAMInstanceProfile: "arn:aws:iam::123456789012:instance-profile/teams/s3-nodes",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance profile with spaces is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					IAMInstanceProfile: " ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ARN of another resource as instance profile is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					IAMInstanceProfile: "arn:aws:iam::123456789012:role/s3-nodes",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "spot instance with a max price is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SpotMarketOptions: &SpotMarketOptions{MaxPrice: aws.String("0.05")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "spot instance with a zero max price is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SpotMarketOptions: &SpotMarketOptions{MaxPrice: aws.String("0.00")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "spot instance with a max price that is not a decimal is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SpotMarketOptions: &SpotMarketOptions{MaxPrice: aws.String("1e3")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "spot instance for a control plane machine is invalid",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+				},
+				Spec: AWSMachineSpec{
+					SpotMarketOptions: &SpotMarketOptions{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "disabled instance metadata service with required tokens is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceMetadataOptions: &InstanceMetadataOptions{
+						HTTPEndpoint: InstanceMetadataEndpointStateDisabled,
+						HTTPTokens:   HTTPTokensStateRequired,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance requirements are valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceRequirements: &InstanceRequirements{
+						VCPUCount: IntegerRange{Min: 2, Max: pointer.Int64Ptr(4)},
+						MemoryMiB: IntegerRange{Min: 4096},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance requirements with an instance type are invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "m5.large",
+					InstanceRequirements: &InstanceRequirements{
+						VCPUCount: IntegerRange{Min: 2},
+						MemoryMiB: IntegerRange{Min: 4096},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance requirements with a maximum below the minimum are invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceRequirements: &InstanceRequirements{
+						VCPUCount: IntegerRange{Min: 8, Max: pointer.Int64Ptr(4)},
+						MemoryMiB: IntegerRange{Min: 4096},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "container runtime volume is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ContainerRuntimeVolume: &ContainerRuntimeVolume{
+						Volume:    Volume{DeviceName: "/dev/sdf", Size: 100},
+						MountPath: "/var/lib/docker",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "container runtime volume sharing a device name with a non root volume is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []*Volume{{DeviceName: "/dev/sdf", Size: 20}},
+					ContainerRuntimeVolume: &ContainerRuntimeVolume{
+						Volume: Volume{DeviceName: "/dev/sdf", Size: 100},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "container runtime volume mounted over a system directory is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ContainerRuntimeVolume: &ContainerRuntimeVolume{
+						Volume:    Volume{DeviceName: "/dev/sdf", Size: 100},
+						MountPath: "/var",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional boot config is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalBootConfig: &AdditionalBootConfig{
+						SystemdUnits: []SystemdUnit{{Name: "node-problem-detector.service", Content: "[Unit]\n"}},
+						Scripts:      []BootScript{{Name: "install-log-shipper", Content: "#!/bin/bash\n"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "boot script without an interpreter line is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalBootConfig: &AdditionalBootConfig{
+						Scripts: []BootScript{{Name: "install-log-shipper", Content: "echo install\n"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional boot config larger than the user data limit is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalBootConfig: &AdditionalBootConfig{
+						Scripts: []BootScript{{Name: "install-log-shipper", Content: "#!/bin/bash\n" + strings.Repeat("#", 16384)}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "targeted capacity reservation is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationPreference: CapacityReservationPreferenceTargeted,
+					CapacityReservationID:         aws.String("cr-0123456789abcdef0"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "targeted capacity reservation without an ID is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationPreference: CapacityReservationPreferenceTargeted,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "capacity reservation ID with the open preference is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationPreference: CapacityReservationPreferenceOpen,
+					CapacityReservationID:         aws.String("cr-0123456789abcdef0"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "host placement on a dedicated host is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy:       "host",
+					HostPlacement: &HostPlacement{HostID: aws.String("h-0123456789abcdef0"), Affinity: "host"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "host placement without the host tenancy is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HostPlacement: &HostPlacement{Strategy: HostPlacementStrategyPack},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "host placement with a host ID and the Pack strategy is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy:       "host",
+					HostPlacement: &HostPlacement{HostID: aws.String("h-0123456789abcdef0"), Strategy: HostPlacementStrategyPack},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "termination log upload to a bucket is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					TerminationLogUpload: &TerminationLogUpload{Bucket: "node-logs", KeyPrefix: "terminated", Timeout: &metav1.Duration{Duration: time.Minute}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "termination log upload to an invalid bucket name is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					TerminationLogUpload: &TerminationLogUpload{Bucket: "Node_Logs"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "termination log upload with a timeout of over ten minutes is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					TerminationLogUpload: &TerminationLogUpload{Bucket: "node-logs", Timeout: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet tags are valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SubnetTags: Tags{"tier": "app"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "subnet tags with an explicit subnet are invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Subnet:     &AWSResourceReference{ID: aws.String("subnet-1")},
+					SubnetTags: Tags{"tier": "app"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "placement group with the host tenancy is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroupName: "critical",
+					Tenancy:            "host",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "monitoring target group with a load balancer ARN is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					MonitoringTargetGroup: &MonitoringTargetGroup{
+						ARN:  "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/metrics/0123456789abcdef",
+						Port: 9100,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "capacity fallback is valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityFallback: &CapacityFallbackOptions{AvailabilityZones: []string{"us-east-1a", "us-east-1b"}, MaxAttempts: pointer.Int32Ptr(2)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "capacity fallback with a duplicate availability zone is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityFallback: &CapacityFallbackOptions{AvailabilityZones: []string{"us-east-1a", "us-east-1a"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "node labels and taints are valid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NodeLabels: map[string]string{"pool": "gpu", "node.kubernetes.io/role": "worker"},
+					NodeTaints: []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "node label in the kubernetes.io namespace is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NodeLabels: map[string]string{"node-role.kubernetes.io/worker": ""},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "node taint without an effect is invalid",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NodeTaints: []corev1.Taint{{Key: "dedicated", Value: "gpu"}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -198,6 +706,7 @@ func TestAWSMachine_Create(t *testing.T) {
 			machine.ObjectMeta = metav1.ObjectMeta{
 				GenerateName: "machine-",
 				Namespace:    "default",
+				Labels:       tt.machine.Labels,
 			}
 			ctx := context.TODO()
 			if err := testEnv.Create(ctx, machine); (err != nil) != tt.wantErr {
@@ -269,6 +778,62 @@ func TestAWSMachine_Update(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "change in volume tags",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					VolumeTags: Tags{"backup": "daily"},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					VolumeTags: Tags{"backup": "weekly"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "convert the root volume from gp2 to gp3",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 50, Type: VolumeTypeGP2},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 50, Type: VolumeTypeGP3},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "convert the root volume from gp3 to gp2",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 50, Type: VolumeTypeGP3},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 50, Type: VolumeTypeGP2},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "change in root volume other than its type",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 50, Type: VolumeTypeGP2},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 100, Type: VolumeTypeGP3},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		ctx := context.TODO()
@@ -55,6 +55,26 @@ func (r *AWSMachineTemplate) ValidateCreate() error {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "providerID"), "cannot be set in templates"))
 	}
 
+	allErrs = append(allErrs, ValidateIAMInstanceProfile(spec.IAMInstanceProfile, field.NewPath("spec", "template", "spec", "iamInstanceProfile"))...)
+	allErrs = append(allErrs, isValidSysctls(spec.Sysctls, field.NewPath("spec", "template", "spec", "sysctls"))...)
+	allErrs = append(allErrs, isValidImageGC(spec.ImageGC, field.NewPath("spec", "template", "spec", "imageGC"))...)
+	allErrs = append(allErrs, isValidContainerLogRotation(spec.ContainerLogRotation, field.NewPath("spec", "template", "spec", "containerLogRotation"))...)
+	allErrs = append(allErrs, isValidCapacityFallback(spec.CapacityFallback, field.NewPath("spec", "template", "spec", "capacityFallback"))...)
+	allErrs = append(allErrs, isValidNodeLabels(spec.NodeLabels, field.NewPath("spec", "template", "spec", "nodeLabels"))...)
+	allErrs = append(allErrs, isValidNodeTaints(spec.NodeTaints, field.NewPath("spec", "template", "spec", "nodeTaints"))...)
+	allErrs = append(allErrs, isValidEvictionThresholds(spec.EvictionThresholds, field.NewPath("spec", "template", "spec", "evictionThresholds"))...)
+	allErrs = append(allErrs, isValidMonitoringTargetGroup(spec.MonitoringTargetGroup, field.NewPath("spec", "template", "spec", "monitoringTargetGroup"))...)
+	allErrs = append(allErrs, isValidInstanceMetadataOptions(spec.InstanceMetadataOptions, false, field.NewPath("spec", "template", "spec", "instanceMetadataOptions"))...)
+	allErrs = append(allErrs, isValidInstanceRequirements(spec.InstanceRequirements, spec.InstanceType, field.NewPath("spec", "template", "spec", "instanceRequirements"))...)
+	allErrs = append(allErrs, isValidPlacementGroupName(spec.PlacementGroupName, spec.Tenancy, field.NewPath("spec", "template", "spec", "placementGroupName"))...)
+	allErrs = append(allErrs, isValidSpotMarketOptions(spec.SpotMarketOptions, false, field.NewPath("spec", "template", "spec", "spotMarketOptions"))...)
+	allErrs = append(allErrs, isValidCapacityReservation(spec.CapacityReservationPreference, spec.CapacityReservationID, spec.SpotMarketOptions, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, isValidHostPlacement(spec.HostPlacement, spec.Tenancy, field.NewPath("spec", "template", "spec", "hostPlacement"))...)
+	allErrs = append(allErrs, isValidContainerRuntimeVolume(spec.ContainerRuntimeVolume, spec.NonRootVolumes, field.NewPath("spec", "template", "spec", "containerRuntimeVolume"))...)
+	allErrs = append(allErrs, isValidAdditionalBootConfig(spec.AdditionalBootConfig, field.NewPath("spec", "template", "spec", "additionalBootConfig"))...)
+	allErrs = append(allErrs, isValidTerminationLogUpload(spec.TerminationLogUpload, field.NewPath("spec", "template", "spec", "terminationLogUpload"))...)
+	allErrs = append(allErrs, isValidSubnetTags(spec.SubnetTags, spec.Subnet, field.NewPath("spec", "template", "spec", "subnetTags"))...)
+
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
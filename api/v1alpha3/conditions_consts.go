@@ -26,6 +26,9 @@ const (
 	VpcCreationStartedReason = "VpcCreationStarted"
 	// VpcReconciliationFailedReason used when errors occur during VPC reconciliation
 	VpcReconciliationFailedReason = "VpcReconciliationFailed"
+	// DHCPOptionsSetReconciliationFailedReason used when the DHCP options set referenced by the network
+	// spec could not be associated with the VPC.
+	DHCPOptionsSetReconciliationFailedReason = "DHCPOptionsSetReconciliationFailed"
 )
 
 const (
@@ -33,6 +36,9 @@ const (
 	SubnetsReadyCondition clusterv1.ConditionType = "SubnetsReady"
 	// SubnetsReconciliationFailedReason used to report failures while reconciling subnets
 	SubnetsReconciliationFailedReason = "SubnetsReconciliationFailed"
+	// SubnetsNotSharedReason used when the subnets of a shared VPC are not shared with the cluster's account
+	// by the owner of the VPC.
+	SubnetsNotSharedReason = "SubnetsNotShared"
 )
 
 const (
@@ -53,6 +59,16 @@ const (
 	NatGatewaysReconciliationFailedReason = "NatGatewaysReconciliationFailed"
 )
 
+const (
+	// NatGatewaysHealthyCondition reports whether the CloudWatch alarms on the managed NAT gateways are quiet.
+	// Only applicable to managed clusters with NAT gateway monitoring.
+	NatGatewaysHealthyCondition clusterv1.ConditionType = "NatGatewaysHealthy"
+	// NatGatewayAlarmReason used when an alarm on a NAT gateway went off.
+	NatGatewayAlarmReason = "NatGatewayAlarm"
+	// NatGatewayMonitoringFailedReason used when any errors occur during reconciliation of the NAT gateway alarms.
+	NatGatewayMonitoringFailedReason = "NatGatewayMonitoringFailed"
+)
+
 const (
 	// RouteTablesReady condition reports successful reconciliation of route tables.
 	// Only applicable to managed clusters.
@@ -95,6 +111,9 @@ const (
 	WaitForDNSNameResolveReason = "WaitForDNSNameResolve"
 	// LoadBalancerFailedReason used when an error occurs during load balancer reconciliation
 	LoadBalancerFailedReason = "LoadBalancerFailed"
+	// ControlPlaneEndpointChangedReason used when the load balancer no longer serves the control plane endpoint,
+	// e.g. because it was recreated with a new DNS name.
+	ControlPlaneEndpointChangedReason = "ControlPlaneEndpointChanged"
 )
 
 const (
@@ -117,6 +136,11 @@ const (
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// WaitingForQuorumReason used when the termination of a control plane instance is held back because
+	// it would leave the control plane without etcd quorum.
+	WaitingForQuorumReason = "WaitingForQuorum"
+	// InstanceStatusChecksImpairedReason used when the EC2 status checks report the instance as impaired.
+	InstanceStatusChecksImpairedReason = "InstanceStatusChecksImpaired"
 )
 
 const (
@@ -137,3 +161,64 @@ const (
 	// ELBDetachFailedReason used when a control plane node fails to detach from an ELB
 	ELBDetachFailedReason = "ELBDetachFailed"
 )
+
+const (
+	// MonitoringTargetGroupRegisteredCondition reports whether the instance is registered with the monitoring
+	// target group of the AWSMachine. Only applicable to machines with a monitoring target group.
+	MonitoringTargetGroupRegisteredCondition clusterv1.ConditionType = "MonitoringTargetGroupRegistered"
+
+	// MonitoringTargetGroupRegistrationFailedReason used when the instance fails to register with the monitoring target group.
+	MonitoringTargetGroupRegistrationFailedReason = "MonitoringTargetGroupRegistrationFailed"
+	// MonitoringTargetGroupDeregistrationFailedReason used when the instance fails to deregister from the monitoring target group.
+	MonitoringTargetGroupDeregistrationFailedReason = "MonitoringTargetGroupDeregistrationFailed"
+)
+
+const (
+	// CostAllocationTagsValidCondition reports whether the tags of the AWSMachine's instance include the cost
+	// allocation tags required by the controller's cost allocation tag policy, with allowed values.
+	// It does not block reconciliation and is not part of the AWSMachine's Ready condition.
+	CostAllocationTagsValidCondition clusterv1.ConditionType = "CostAllocationTagsValid"
+
+	// CostAllocationTagsMissingReason used when required cost allocation tags are missing.
+	CostAllocationTagsMissingReason = "CostAllocationTagsMissing"
+	// CostAllocationTagsInvalidReason used when cost allocation tags have values that are not allowed.
+	CostAllocationTagsInvalidReason = "CostAllocationTagsInvalid"
+)
+
+const (
+	// BootstrapDataUpToDateCondition reports whether the user data the AWSMachine's instance was launched with
+	// matches the bootstrap data the instance would be launched with now. Instances that are not up to date
+	// need to be replaced to pick up the changes. It is not part of the AWSMachine's Ready condition.
+	BootstrapDataUpToDateCondition clusterv1.ConditionType = "BootstrapDataUpToDate"
+
+	// OutdatedBootstrapReason used when the bootstrap data changed after the instance was launched.
+	OutdatedBootstrapReason = "OutdatedBootstrap"
+	// BootstrapDataUnavailableReason used when the bootstrap data could not be rendered for comparison.
+	BootstrapDataUnavailableReason = "BootstrapDataUnavailable"
+)
+
+const (
+	// CapacityReservationActiveCondition reports whether the time-bounded capacity reservation, e.g. a
+	// Capacity Block, the AWSMachine's instance runs in stays active for longer than the warning threshold.
+	// It is only set for instances in capacity reservations with an end date and is not part of the
+	// AWSMachine's Ready condition.
+	CapacityReservationActiveCondition clusterv1.ConditionType = "CapacityReservationActive"
+
+	// CapacityReservationExpiringReason used when the capacity reservation ends within the warning threshold.
+	CapacityReservationExpiringReason = "CapacityReservationExpiring"
+	// CapacityReservationReplacementReason used when the machine was marked as failed to be replaced before
+	// its capacity reservation ends.
+	CapacityReservationReplacementReason = "CapacityReservationReplacement"
+)
+
+const (
+	// RootVolumeTypeUpToDateCondition reports whether the root volume of the AWSMachine's instance is of the
+	// volume type of the spec. It is only set for root volumes converted from gp2 to gp3 in place and is not
+	// part of the AWSMachine's Ready condition.
+	RootVolumeTypeUpToDateCondition clusterv1.ConditionType = "RootVolumeTypeUpToDate"
+
+	// RootVolumeTypeConvertingReason used while the root volume is being converted to the volume type of the spec.
+	RootVolumeTypeConvertingReason = "RootVolumeTypeConverting"
+	// RootVolumeTypeConversionFailedReason used when the root volume could not be converted.
+	RootVolumeTypeConversionFailedReason = "RootVolumeTypeConversionFailed"
+)
@@ -64,6 +64,16 @@ func (t Tags) Difference(other Tags) Tags {
 	return res
 }
 
+// HasAll returns true if the tags contain all of the tags in other with the same values.
+func (t Tags) HasAll(other Tags) bool {
+	for k, v := range other {
+		if value, ok := t[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
 // Merge merges in tags from other. If a tag already exists, it is replaced by the tag in other.
 func (t Tags) Merge(other Tags) {
 	for k, v := range other {
@@ -126,6 +136,9 @@ const (
 	// PrivateRoleTagValue describes the value for the private role
 	PrivateRoleTagValue = "private"
 
+	// BlackholeRoleTagValue describes the value for the blackhole role
+	BlackholeRoleTagValue = "blackhole"
+
 	// MachineNameTagKey is the key for machine name
 	MachineNameTagKey = "MachineName"
 )
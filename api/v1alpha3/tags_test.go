@@ -161,3 +161,26 @@ func TestTags_Difference(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_HasAll(t *testing.T) {
+	tags := Tags{"tier": "app", "env": "prod"}
+
+	tests := []struct {
+		name     string
+		other    Tags
+		expected bool
+	}{
+		{name: "nil other", other: nil, expected: true},
+		{name: "subset", other: Tags{"tier": "app"}, expected: true},
+		{name: "different value", other: Tags{"tier": "data"}, expected: false},
+		{name: "missing key", other: Tags{"tier": "app", "team": "ml"}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tags.HasAll(tc.other); got != tc.expected {
+				t.Fatalf("expected HasAll(%v) to be %v, got %v", tc.other, tc.expected, got)
+			}
+		})
+	}
+}
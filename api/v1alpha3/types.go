@@ -21,6 +21,8 @@ import (
 	"sort"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
@@ -97,6 +99,54 @@ type Network struct {
 
 	// APIServerELB is the Kubernetes api server classic load balancer.
 	APIServerELB ClassicELB `json:"apiServerElb,omitempty"`
+
+	// SubnetGroups reports the subnets and route tables of every subnet group.
+	// +optional
+	SubnetGroups []SubnetGroupStatus `json:"subnetGroups,omitempty"`
+
+	// VPCInstanceTenancy is the instance tenancy of the VPC as reported by AWS.
+	// +optional
+	VPCInstanceTenancy string `json:"vpcInstanceTenancy,omitempty"`
+
+	// BlackholeNetworkInterfaceID is the ID of the network interface the blackhole routes target.
+	// The interface is never attached to an instance, so AWS drops the traffic routed to it.
+	// +optional
+	BlackholeNetworkInterfaceID string `json:"blackholeNetworkInterfaceId,omitempty"`
+
+	// InternetGatewayNotRequired is true when no internet gateway was created for the managed VPC
+	// because the cluster has no public subnets.
+	// +optional
+	InternetGatewayNotRequired bool `json:"internetGatewayNotRequired,omitempty"`
+
+	// DHCPOptionsSet is the DHCP options set associated with the managed VPC, if the network spec
+	// references one.
+	// +optional
+	DHCPOptionsSet *DHCPOptionsSetStatus `json:"dhcpOptionsSet,omitempty"`
+
+	// SharedVPC describes the networking owned by another account that the cluster uses, if the VPC
+	// is shared with the cluster's account.
+	// +optional
+	SharedVPC *SharedVPCStatus `json:"sharedVPC,omitempty"`
+}
+
+// SharedVPCStatus describes a VPC shared with the cluster's account through AWS RAM.
+type SharedVPCStatus struct {
+	// OwnerAccountID is the ID of the AWS account owning the VPC and its subnets.
+	OwnerAccountID string `json:"ownerAccountId"`
+
+	// SubnetIDs are the IDs of the subnets shared with the cluster's account that the cluster uses.
+	// +optional
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+}
+
+// DHCPOptionsSetStatus describes the DHCP options set associated with a VPC.
+type DHCPOptionsSetStatus struct {
+	// ID of the DHCP options set.
+	ID string `json:"id"`
+
+	// ExternallyOwned is true when the DHCP options set is owned outside of the cluster, so it is
+	// not deleted with the cluster.
+	ExternallyOwned bool `json:"externallyOwned"`
 }
 
 // ClassicELBScheme defines the scheme of a classic load balancer.
@@ -138,6 +188,10 @@ type ClassicELB struct {
 	// DNSName is the dns name of the load balancer.
 	DNSName string `json:"dnsName,omitempty"`
 
+	// CanonicalHostedZoneID is the ID of the Route53 hosted zone of the load balancer's DNS name.
+	// +optional
+	CanonicalHostedZoneID string `json:"canonicalHostedZoneId,omitempty"`
+
 	// Scheme is the load balancer scheme, either internet-facing or private.
 	Scheme ClassicELBScheme `json:"scheme,omitempty"`
 
@@ -220,6 +274,108 @@ type NetworkSpec struct {
 	// This is optional - if not provided new security groups will be created for the cluster
 	// +optional
 	SecurityGroupOverrides map[SecurityGroupRole]string `json:"securityGroupOverrides,omitempty"`
+
+	// SubnetGroups defines named groups of private subnets whose traffic is isolated from the rest
+	// of the cluster by routing it through a dedicated default route, e.g. to make shared services
+	// egress through a different gateway. Subnets join a group by setting their subnetGroup and
+	// machines are launched into a group by setting spec.subnetGroup on the AWSMachine.
+	// +optional
+	SubnetGroups []SubnetGroupSpec `json:"subnetGroups,omitempty"`
+
+	// BlackholeRoutes are routes added to every route table of the cluster that drop the traffic
+	// to their destination, making it unreachable from the cluster regardless of security groups.
+	// They are only reconciled when the provider manages the VPC, and must not overlap with the
+	// VPC or any of its subnets.
+	// +optional
+	BlackholeRoutes []BlackholeRoute `json:"blackholeRoutes,omitempty"`
+
+	// NatGatewayMonitoring, when set, creates CloudWatch alarms on the port allocation errors and
+	// dropped packets of the managed NAT gateways, and reports their state in the NatGatewaysHealthy
+	// condition. It is only reconciled when the provider manages the VPC.
+	// +optional
+	NatGatewayMonitoring *NatGatewayMonitoringSpec `json:"natGatewayMonitoring,omitempty"`
+}
+
+// NatGatewayMonitoringSpec defines the CloudWatch alarms created on the managed NAT gateways.
+type NatGatewayMonitoringSpec struct {
+	// AlarmActions are the ARNs of the actions, e.g. SNS topics, run when an alarm goes off.
+	// +optional
+	AlarmActions []string `json:"alarmActions,omitempty"`
+
+	// PacketsDropThreshold is the number of packets a NAT gateway may drop per minute before its
+	// packet drop alarm goes off. Defaults to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PacketsDropThreshold *int64 `json:"packetsDropThreshold,omitempty"`
+
+	// EvaluationPeriods is the number of consecutive minutes a metric has to breach its threshold
+	// before the alarm goes off. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EvaluationPeriods *int64 `json:"evaluationPeriods,omitempty"`
+}
+
+// BlackholeRoute defines a destination the traffic to which is dropped.
+type BlackholeRoute struct {
+	// DestinationCIDRBlock is the IPv4 CIDR block of the destination.
+	DestinationCIDRBlock string `json:"destinationCidrBlock"`
+}
+
+// SubnetGroupSpec defines a named group of subnets sharing a dedicated default route.
+type SubnetGroupSpec struct {
+	// Name identifies the group.
+	Name string `json:"name"`
+
+	// DefaultRoute is the target of the default route of the group's subnets. It is only
+	// reconciled when the provider manages the VPC.
+	DefaultRoute RouteTarget `json:"defaultRoute"`
+}
+
+// RouteTarget defines the target of a route. Exactly one target must be set.
+type RouteTarget struct {
+	// GatewayID is the ID of an internet or virtual private gateway.
+	// +optional
+	GatewayID *string `json:"gatewayId,omitempty"`
+
+	// NatGatewayID is the ID of a NAT gateway.
+	// +optional
+	NatGatewayID *string `json:"natGatewayId,omitempty"`
+
+	// TransitGatewayID is the ID of a transit gateway.
+	// +optional
+	TransitGatewayID *string `json:"transitGatewayId,omitempty"`
+
+	// VPCPeeringConnectionID is the ID of a VPC peering connection.
+	// +optional
+	VPCPeeringConnectionID *string `json:"vpcPeeringConnectionId,omitempty"`
+
+	// NetworkInterfaceID is the ID of a network interface, e.g. of a network appliance.
+	// +optional
+	NetworkInterfaceID *string `json:"networkInterfaceId,omitempty"`
+}
+
+// FindSubnetGroup returns the subnet group with the given name or nil.
+func (n *NetworkSpec) FindSubnetGroup(name string) *SubnetGroupSpec {
+	for i := range n.SubnetGroups {
+		if n.SubnetGroups[i].Name == name {
+			return &n.SubnetGroups[i]
+		}
+	}
+	return nil
+}
+
+// SubnetGroupStatus reports the subnets and route tables of a subnet group.
+type SubnetGroupStatus struct {
+	// Name of the group.
+	Name string `json:"name"`
+
+	// SubnetIDs are the IDs of the subnets in the group.
+	// +optional
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// RouteTableIDs are the IDs of the route tables associated with the subnets in the group.
+	// +optional
+	RouteTableIDs []string `json:"routeTableIds,omitempty"`
 }
 
 // VPCSpec configures an AWS VPC.
@@ -254,6 +410,26 @@ type VPCSpec struct {
 	// +kubebuilder:default=Ordered
 	// +kubebuilder:validation:Enum=Ordered;Random
 	AvailabilityZoneSelection *AZSelectionScheme `json:"availabilityZoneSelection,omitempty"`
+
+	// InstanceTenancy is the tenancy instances launched into a managed VPC default to. With dedicated
+	// tenancy, every instance in the VPC runs on single-tenant hardware. It can only be set for VPCs
+	// created by the provider and cannot be changed afterwards.
+	// +kubebuilder:validation:Enum=default;dedicated
+	// +optional
+	InstanceTenancy string `json:"instanceTenancy,omitempty"`
+
+	// DHCPOptionsSetID is the ID of an existing DHCP options set, e.g. one managed centrally and shared
+	// with the account, that is associated with a VPC created by the provider instead of the default
+	// DHCP options. The DHCP options set must set domain name servers and at most one domain name. It is
+	// owned outside of the cluster and never deleted with it.
+	// +optional
+	DHCPOptionsSetID *string `json:"dhcpOptionsSetId,omitempty"`
+
+	// OwnerAccountID is the ID of the AWS account owning an existing VPC that is shared with the
+	// cluster's account through AWS RAM. The subnets used by the cluster must be shared with the
+	// cluster's account by that account, and are never tagged or modified. Requires the VPC ID.
+	// +optional
+	OwnerAccountID string `json:"ownerAccountId,omitempty"`
 }
 
 // String returns a string representation of the VPC.
@@ -297,6 +473,50 @@ type SubnetSpec struct {
 
 	// Tags is a collection of tags describing the resource.
 	Tags Tags `json:"tags,omitempty"`
+
+	// OutpostARN is the ARN of the AWS Outpost the subnet resides on, if any.
+	// When the provider manages the VPC, the subnet is created on this Outpost.
+	// +optional
+	OutpostARN string `json:"outpostArn,omitempty"`
+
+	// IPv6CidrBlock is the IPv6 CIDR block associated with the subnet, if any.
+	// Subnets with an IPv6 CIDR block are configured to auto-assign IPv6 addresses to instances launched into them.
+	// +optional
+	IPv6CidrBlock string `json:"ipv6CidrBlock,omitempty"`
+
+	// AssignIPv6AddressOnCreation reports whether instances launched into the subnet are assigned an IPv6 address.
+	// +optional
+	AssignIPv6AddressOnCreation bool `json:"assignIPv6AddressOnCreation,omitempty"`
+
+	// PrivateIPPool is a range of addresses in the subnet from which the primary private IP of
+	// machines launched into the subnet is assigned, taking the next free address in the range.
+	// +optional
+	PrivateIPPool *IPAddressRange `json:"privateIPPool,omitempty"`
+
+	// SubnetGroup is the name of the subnet group, defined in the network spec, the subnet belongs
+	// to. Subnets in a group must be private and are only used by machines that select the group.
+	// +optional
+	SubnetGroup string `json:"subnetGroup,omitempty"`
+
+	// NetworkBorderGroup is the network border group the Elastic IP of the NAT gateway in this public
+	// subnet is allocated from, e.g. the border group of a Local Zone or of a BYOIP address range. It
+	// must be the network border group of the subnet's availability zone. Defaults to the region.
+	// +optional
+	NetworkBorderGroup *string `json:"networkBorderGroup,omitempty"`
+
+	// NatGatewayNetworkBorderGroup is the network border group of the Elastic IP of the NAT gateway
+	// in this public subnet.
+	// +optional
+	NatGatewayNetworkBorderGroup *string `json:"natGatewayNetworkBorderGroup,omitempty"`
+}
+
+// IPAddressRange defines an inclusive range of IPv4 addresses.
+type IPAddressRange struct {
+	// Start is the first address of the range.
+	Start string `json:"start"`
+
+	// End is the last address of the range.
+	End string `json:"end"`
 }
 
 // String returns a string representation of the subnet.
@@ -369,6 +589,38 @@ func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	return
 }
 
+// FilterByOutpost returns a slice containing all subnets that reside on the Outpost specified.
+// An empty ARN selects the subnets in the region, which do not reside on any Outpost.
+func (s Subnets) FilterByOutpost(outpostARN string) (res Subnets) {
+	for _, x := range s {
+		if x.OutpostARN == outpostARN {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
+// FilterBySubnetGroup returns a slice containing all subnets that belong to the subnet group specified.
+// An empty name selects the subnets that do not belong to any group.
+func (s Subnets) FilterBySubnetGroup(name string) (res Subnets) {
+	for _, x := range s {
+		if x.SubnetGroup == name {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
+// FilterByTags returns a slice containing all subnets that carry all of the tags specified.
+func (s Subnets) FilterByTags(tags Tags) (res Subnets) {
+	for _, x := range s {
+		if x.Tags.HasAll(tags) {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // GetUniqueZones returns a slice containing the unique zones of the subnets
 func (s Subnets) GetUniqueZones() []string {
 	keys := make(map[string]bool)
@@ -670,6 +922,14 @@ type Instance struct {
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
 
+	// VolumeTags are the tags the EBS volumes of the instance are created with.
+	// +optional
+	VolumeTags map[string]string `json:"volumeTags,omitempty"`
+
+	// VolumeIDs are the IDs of the EBS volumes attached to the instance.
+	// +optional
+	VolumeIDs []string `json:"volumeIDs,omitempty"`
+
 	// Availability zone of instance
 	AvailabilityZone string `json:"availabilityZone,omitempty"`
 
@@ -679,6 +939,30 @@ type Instance struct {
 	// Tenancy indicates if instance should run on shared or single-tenant hardware.
 	// +optional
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// PlacementGroupName is the name of the spread placement group the instance runs in.
+	// +optional
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// CapacityReservationPreference describes whether the instance consumes reserved capacity.
+	// +optional
+	CapacityReservationPreference CapacityReservationPreference `json:"capacityReservationPreference,omitempty"`
+
+	// CapacityReservationID is the ID of the capacity reservation the instance runs in.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// HostID is the ID of the Dedicated Host the instance runs on.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostAffinity describes whether the instance restarts on the Dedicated Host it was launched on.
+	// +optional
+	HostAffinity string `json:"hostAffinity,omitempty"`
+
+	// InstanceMetadataOptions are the options of the instance metadata service of the instance.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
 }
 
 // Volume encapsulates the configuration options for the storage device
@@ -711,6 +995,52 @@ type Volume struct {
 	EncryptionKey string `json:"encryptionKey,omitempty"`
 }
 
+const (
+	// VolumeTypeGP2 is the gp2 general purpose SSD volume type.
+	VolumeTypeGP2 = "gp2"
+	// VolumeTypeGP3 is the gp3 general purpose SSD volume type.
+	VolumeTypeGP3 = "gp3"
+)
+
+const (
+	// VolumeModificationStateModifying is the state of volumes at the start of a modification.
+	VolumeModificationStateModifying = "modifying"
+	// VolumeModificationStateOptimizing is the state of volumes that already have their new settings
+	// while their performance is being optimized.
+	VolumeModificationStateOptimizing = "optimizing"
+	// VolumeModificationStateCompleted is the state of volumes whose modification is done.
+	VolumeModificationStateCompleted = "completed"
+)
+
+// RootVolumeStatus describes the root volume of an instance.
+type RootVolumeStatus struct {
+	// ID of the volume.
+	ID string `json:"id"`
+
+	// Type of the volume.
+	Type string `json:"type"`
+
+	// ModificationState is the state of the latest modification of the volume, if any.
+	// +optional
+	ModificationState string `json:"modificationState,omitempty"`
+}
+
+// DefaultContainerRuntimeDataRoot is the data root of containerd, where the container runtime
+// volume is mounted by default.
+const DefaultContainerRuntimeDataRoot = "/var/lib/containerd"
+
+// ContainerRuntimeVolume is a volume dedicated to the storage of the container runtime.
+type ContainerRuntimeVolume struct {
+	// Volume configures the volume. Its device name must be set.
+	Volume `json:",inline"`
+
+	// MountPath is the data root of the container runtime the volume is mounted at, e.g.
+	// /var/lib/docker for Docker. Its existing content is copied onto the volume.
+	// Defaults to /var/lib/containerd.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
 // SpotMarketOptions defines the options available to a user when configuring
 // Machines to run on Spot instances.
 // Most users should provide an empty struct.
@@ -720,3 +1050,401 @@ type SpotMarketOptions struct {
 	// +kubebuilder:validation:pattern="^[0-9]+(\.[0-9]+)?$"
 	MaxPrice *string `json:"maxPrice,omitempty"`
 }
+
+// CapacityReservationPreference describes whether an instance consumes reserved capacity.
+type CapacityReservationPreference string
+
+var (
+	// CapacityReservationPreferenceOpen launches the instance into any open capacity reservation with
+	// matching attributes, or on demand capacity if there is none.
+	CapacityReservationPreferenceOpen = CapacityReservationPreference("open")
+
+	// CapacityReservationPreferenceNone launches the instance on demand capacity only.
+	CapacityReservationPreferenceNone = CapacityReservationPreference("none")
+
+	// CapacityReservationPreferenceTargeted launches the instance into a specific capacity reservation.
+	CapacityReservationPreferenceTargeted = CapacityReservationPreference("targeted")
+)
+
+// HostPlacement defines how an instance with the host tenancy is placed on a Dedicated Host.
+type HostPlacement struct {
+	// HostID is the ID of the Dedicated Host to launch the instance on. The host must support the
+	// instance family of the instance type and be in the availability zone of the machine's subnet.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// Strategy selects the Dedicated Host when no HostID is set: Auto leaves the choice to EC2, which
+	// launches the instance on any available host with auto-placement turned on, and Pack launches it
+	// on the available host with auto-placement turned on and the fewest free slots left for the
+	// instance type in the machine's availability zone, so that hosts in use are filled before empty
+	// ones. With Pack, EC2 chooses the host when none has a free slot. Defaults to Auto.
+	// +optional
+	// +kubebuilder:validation:Enum=Auto;Pack
+	Strategy HostPlacementStrategy `json:"strategy,omitempty"`
+
+	// Affinity controls where the instance restarts after it is stopped: host restarts it on the host
+	// it was launched on, as per-host licenses usually require, and default restarts it on any
+	// available host. Defaults to the EC2 behavior, which is default.
+	// +optional
+	// +kubebuilder:validation:Enum=default;host
+	Affinity string `json:"affinity,omitempty"`
+}
+
+// HostPlacementStrategy describes how a Dedicated Host is selected for an instance.
+type HostPlacementStrategy string
+
+var (
+	// HostPlacementStrategyAuto lets EC2 select the Dedicated Host.
+	HostPlacementStrategyAuto = HostPlacementStrategy("Auto")
+
+	// HostPlacementStrategyPack selects the Dedicated Host with the fewest free slots left.
+	HostPlacementStrategyPack = HostPlacementStrategy("Pack")
+)
+
+// InstanceMetadataState describes the state of the instance metadata service of an instance.
+type InstanceMetadataState string
+
+var (
+	// InstanceMetadataEndpointStateEnabled makes the instance metadata service reachable from the instance.
+	InstanceMetadataEndpointStateEnabled = InstanceMetadataState("enabled")
+
+	// InstanceMetadataEndpointStateDisabled turns the instance metadata service off for the instance.
+	InstanceMetadataEndpointStateDisabled = InstanceMetadataState("disabled")
+)
+
+// HTTPTokensState describes whether the instance metadata service requires session tokens.
+type HTTPTokensState string
+
+var (
+	// HTTPTokensStateOptional allows both IMDSv1 and IMDSv2 requests.
+	HTTPTokensStateOptional = HTTPTokensState("optional")
+
+	// HTTPTokensStateRequired only allows IMDSv2 requests, which must carry a session token.
+	HTTPTokensStateRequired = HTTPTokensState("required")
+)
+
+// InstanceMetadataOptions describes the options for the instance metadata service of an instance.
+type InstanceMetadataOptions struct {
+	// HTTPEndpoint enables or disables the HTTP endpoint of the instance metadata service. With the
+	// endpoint disabled, nothing on the node can read instance metadata or role credentials, which
+	// rules out the in-tree AWS cloud provider and any agent relying on the instance profile.
+	// Defaults to enabled.
+	// +optional
+	// +kubebuilder:validation:Enum:=enabled;disabled
+	HTTPEndpoint InstanceMetadataState `json:"httpEndpoint,omitempty"`
+
+	// HTTPTokens controls whether requests to the instance metadata service must carry a session
+	// token (IMDSv2). Defaults to optional.
+	// +optional
+	// +kubebuilder:validation:Enum:=optional;required
+	HTTPTokens HTTPTokensState `json:"httpTokens,omitempty"`
+
+	// HTTPPutResponseHopLimit is the number of network hops the PUT response carrying a session
+	// token can travel. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=64
+	HTTPPutResponseHopLimit int64 `json:"httpPutResponseHopLimit,omitempty"`
+}
+
+// InstanceRequirements describes the attributes an instance type must have for an instance to be
+// launched with it, instead of naming the instance type.
+type InstanceRequirements struct {
+	// VCPUCount is the range of the number of vCPUs of the instance type.
+	VCPUCount IntegerRange `json:"vCPUCount"`
+
+	// MemoryMiB is the range of the memory of the instance type, in MiB.
+	MemoryMiB IntegerRange `json:"memoryMiB"`
+
+	// GPUCount is the range of the number of GPUs of the instance type. Instance types with GPUs
+	// are only picked when it is set.
+	// +optional
+	GPUCount *IntegerRange `json:"gpuCount,omitempty"`
+
+	// NetworkBandwidthGbps is the range of the network bandwidth of the instance type, in Gbps.
+	// Instance types with burstable bandwidth are matched on their burst bandwidth.
+	// +optional
+	NetworkBandwidthGbps *IntegerRange `json:"networkBandwidthGbps,omitempty"`
+
+	// Architecture is the processor architecture of the instance type, which must match the AMI.
+	// Defaults to x86_64.
+	// +optional
+	// +kubebuilder:validation:Enum:=x86_64;arm64
+	Architecture string `json:"architecture,omitempty"`
+}
+
+// IntegerRange is an inclusive range of integers.
+type IntegerRange struct {
+	// Min is the lower bound of the range.
+	// +kubebuilder:validation:Minimum:=0
+	Min int64 `json:"min"`
+
+	// Max is the upper bound of the range. The range is unbounded when it is unset.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	Max *int64 `json:"max,omitempty"`
+}
+
+// Contains returns true if the value is within the range.
+func (r *IntegerRange) Contains(value int64) bool {
+	return value >= r.Min && (r.Max == nil || value <= *r.Max)
+}
+
+// GPUDriverInstallMode controls when GPU drivers are installed on an instance.
+type GPUDriverInstallMode string
+
+var (
+	// GPUDriverInstallAuto installs the drivers only when the instance type reports NVIDIA GPUs.
+	GPUDriverInstallAuto = GPUDriverInstallMode("Auto")
+
+	// GPUDriverInstallAlways installs the drivers without checking the instance type.
+	GPUDriverInstallAlways = GPUDriverInstallMode("Always")
+
+	// GPUDriverInstallNever disables the installation of the drivers.
+	GPUDriverInstallNever = GPUDriverInstallMode("Never")
+)
+
+// NVIDIADriverOptions defines the NVIDIA driver and container toolkit installation applied
+// to an instance when it boots.
+type NVIDIADriverOptions struct {
+	// Version is the NVIDIA driver branch to install, e.g. "470".
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	Version string `json:"version"`
+
+	// Install controls when the driver is installed. Auto, the default, only installs it when
+	// the instance type has NVIDIA GPUs. Always skips the instance type check and Never
+	// disables the installation.
+	// +optional
+	// +kubebuilder:validation:Enum:=Auto;Always;Never
+	Install GPUDriverInstallMode `json:"install,omitempty"`
+}
+
+// Sysctl defines a kernel parameter to set on the node before the kubelet starts.
+type Sysctl struct {
+	// Name of the kernel parameter, e.g. "net.netfilter.nf_conntrack_max".
+	Name string `json:"name"`
+
+	// Value of the kernel parameter.
+	Value string `json:"value"`
+}
+
+const (
+	// DefaultImageGCHighThresholdPercent is the kubelet's default image GC high threshold.
+	DefaultImageGCHighThresholdPercent = 85
+
+	// DefaultImageGCLowThresholdPercent is the kubelet's default image GC low threshold.
+	DefaultImageGCLowThresholdPercent = 80
+)
+
+// CapacityFallbackOptions defines where the launch of an instance is retried when an availability
+// zone is out of capacity for the instance type.
+type CapacityFallbackOptions struct {
+	// AvailabilityZones are the availability zones the launch may be retried in, in order of
+	// preference. Defaults to every availability zone of the cluster with a subnet the machine can
+	// be launched into.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// MaxAttempts is how many availability zones the launch is attempted in, including the first
+	// one. Defaults to every candidate availability zone.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+}
+
+// ImageGCOptions defines when the kubelet garbage collects unused container images. Unset
+// thresholds keep the kubelet defaults.
+type ImageGCOptions struct {
+	// HighThresholdPercent is the percentage of disk usage of the image file system above which
+	// image garbage collection always runs. Defaults to 85.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	HighThresholdPercent *int32 `json:"highThresholdPercent,omitempty"`
+
+	// LowThresholdPercent is the percentage of disk usage of the image file system that image
+	// garbage collection frees space down to. It must be lower than the high threshold. Defaults to 80.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	LowThresholdPercent *int32 `json:"lowThresholdPercent,omitempty"`
+}
+
+// ContainerLogRotationOptions defines how the kubelet rotates container logs. Unset options keep
+// the kubelet defaults.
+type ContainerLogRotationOptions struct {
+	// MaxSize is the size a container log file may grow to before it is rotated, e.g. "10Mi".
+	// +optional
+	MaxSize string `json:"maxSize,omitempty"`
+
+	// MaxFiles is the maximum number of log files kept for every container, including the one
+	// being written to. It must be at least 2.
+	// +optional
+	// +kubebuilder:validation:Minimum=2
+	MaxFiles *int32 `json:"maxFiles,omitempty"`
+}
+
+// EvictionThresholdsOptions defines when the kubelet evicts pods to reclaim memory or disk space.
+type EvictionThresholdsOptions struct {
+	// Hard thresholds evict pods as soon as they are crossed. Unset signals keep the kubelet
+	// defaults of 100Mi of memory, 10% of nodefs and 15% of imagefs available.
+	// +optional
+	Hard *EvictionSignals `json:"hard,omitempty"`
+
+	// Soft thresholds evict pods once they have been crossed for longer than the soft grace period.
+	// +optional
+	Soft *EvictionSignals `json:"soft,omitempty"`
+
+	// SoftGracePeriod is how long a soft threshold must be crossed before pods are evicted, e.g.
+	// "1m30s". It is required with soft thresholds.
+	// +optional
+	SoftGracePeriod *metav1.Duration `json:"softGracePeriod,omitempty"`
+}
+
+// EvictionSignals defines eviction thresholds for the kubelet's eviction signals. Every threshold is
+// either a quantity, e.g. "500Mi", or a percentage of the capacity, e.g. "10%".
+type EvictionSignals struct {
+	// MemoryAvailable is the threshold of memory available on the node.
+	// +optional
+	MemoryAvailable string `json:"memoryAvailable,omitempty"`
+
+	// NodeFSAvailable is the threshold of disk space available on the file system used by the
+	// kubelet for volumes and daemon logs.
+	// +optional
+	NodeFSAvailable string `json:"nodefsAvailable,omitempty"`
+
+	// ImageFSAvailable is the threshold of disk space available on the file system used by the
+	// container runtime for images and container writable layers.
+	// +optional
+	ImageFSAvailable string `json:"imagefsAvailable,omitempty"`
+}
+
+// MonitoringTargetGroup defines an ELBv2 target group an instance's metrics are served through.
+type MonitoringTargetGroup struct {
+	// ARN of the target group. It must exist, be in the cluster's VPC and have the instance target type.
+	ARN string `json:"arn"`
+
+	// Port the instance serves its metrics on, which it is registered with the target group on.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Protocol the target group is expected to reach the metrics on. When set, registration fails
+	// for a target group with another protocol.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP;TLS
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// DefaultInstanceProfiles defines the IAM instance profiles of a cluster's machines by role.
+type DefaultInstanceProfiles struct {
+	// ControlPlane is the name of the instance profile of control plane machines.
+	// +optional
+	ControlPlane string `json:"controlPlane,omitempty"`
+
+	// Node is the name of the instance profile of worker machines.
+	// +optional
+	Node string `json:"node,omitempty"`
+}
+
+// TerminationLogUpload defines the S3 bucket the journal of an instance is uploaded to before the
+// instance is terminated.
+type TerminationLogUpload struct {
+	// Bucket is the name of the S3 bucket the journal is uploaded to. The journal is sent through SSM
+	// Run Command, so the instance needs to run the SSM agent, and its instance profile needs to be
+	// allowed to put objects into the bucket.
+	Bucket string `json:"bucket"`
+
+	// KeyPrefix is prepended to the keys of the uploaded logs.
+	// +optional
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// Timeout is how long to wait for the upload to complete before terminating the instance anyway.
+	// It must be between 30s and 10m. Defaults to 2m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NTPSpec defines the time servers machines synchronize their clocks with.
+type NTPSpec struct {
+	// Servers are the hostnames or IP addresses of the NTP servers to use in addition to the Amazon
+	// Time Sync Service.
+	// +optional
+	Servers []string `json:"servers,omitempty"`
+
+	// AmazonTimeSync enables the Amazon Time Sync Service, which is reachable from every instance at
+	// 169.254.169.123 and is preferred over the other servers. Defaults to true.
+	// +optional
+	AmazonTimeSync *bool `json:"amazonTimeSync,omitempty"`
+}
+
+// HealthReportingSpec defines an external endpoint that lifecycle and health transitions of the
+// cluster's machines are reported to.
+type HealthReportingSpec struct {
+	// URL is the http or https endpoint the transitions are POSTed to as JSON.
+	URL string `json:"url"`
+
+	// AuthorizationSecretRef is a reference to a key in a Secret, in the same namespace as the
+	// AWSCluster, holding the value of the Authorization header sent with every request,
+	// e.g. "Bearer <token>".
+	// +optional
+	AuthorizationSecretRef *corev1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
+}
+
+// RegistryMirror defines a mirror containerd pulls the images of a registry through.
+type RegistryMirror struct {
+	// Registry is the host, and optionally the port, of the registry the mirror serves the images of,
+	// e.g. "docker.io".
+	Registry string `json:"registry"`
+
+	// Endpoint is the https URL of the mirror. Mirrors of the same registry are tried in order, before
+	// falling back to the registry itself.
+	Endpoint string `json:"endpoint"`
+
+	// TLS configures how containerd verifies the certificate the mirror serves.
+	// +optional
+	TLS *RegistryMirrorTLS `json:"tls,omitempty"`
+}
+
+// RegistryMirrorTLS defines how the certificate of a registry mirror is verified.
+type RegistryMirrorTLS struct {
+	// CASecretRef is a reference to a key in a Secret, in the same namespace as the AWSCluster,
+	// holding the PEM-encoded CA certificates the certificate of the mirror is verified against,
+	// for mirrors serving a certificate that is not signed by a public CA.
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the mirror. It cannot be
+	// combined with CASecretRef.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// AdditionalBootConfig defines systemd units and install scripts set up on the node at boot.
+type AdditionalBootConfig struct {
+	// SystemdUnits are written to /etc/systemd/system, then enabled and started.
+	// +optional
+	SystemdUnits []SystemdUnit `json:"systemdUnits,omitempty"`
+
+	// Scripts are run in order, after the systemd units are started.
+	// +optional
+	Scripts []BootScript `json:"scripts,omitempty"`
+}
+
+// SystemdUnit is a systemd unit installed on the node at boot.
+type SystemdUnit struct {
+	// Name of the unit file, including the unit type suffix, e.g. node-problem-detector.service.
+	Name string `json:"name"`
+
+	// Content of the unit file. It needs an [Install] section to be enabled.
+	Content string `json:"content"`
+}
+
+// BootScript is an install script run on the node at boot.
+type BootScript struct {
+	// Name of the script, a DNS-1123 label, e.g. install-log-shipper.
+	Name string `json:"name"`
+
+	// Content of the script. It has to start with an interpreter line, e.g. #!/bin/bash.
+	Content string `json:"content"`
+}
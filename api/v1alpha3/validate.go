@@ -17,9 +17,16 @@ limitations under the License.
 package v1alpha3
 
 import (
+	"bytes"
 	"fmt"
 	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -43,3 +50,475 @@ func (b *Bastion) Validate() []*field.Error {
 	}
 	return errs
 }
+
+// Validate makes sure the range is made of valid IPv4 addresses, in order, and, when the CIDR block
+// of its subnet is known, that it lies within the block outside of the addresses AWS reserves: the
+// first four and the last address of every subnet.
+func (r *IPAddressRange) Validate(cidrBlock string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	start := net.ParseIP(r.Start).To4()
+	if start == nil {
+		errs = append(errs, field.Invalid(fldPath.Child("start"), r.Start, "must be a valid IPv4 address"))
+	}
+	end := net.ParseIP(r.End).To4()
+	if end == nil {
+		errs = append(errs, field.Invalid(fldPath.Child("end"), r.End, "must be a valid IPv4 address"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if bytes.Compare(start, end) > 0 {
+		return append(errs, field.Invalid(fldPath, fmt.Sprintf("%s-%s", r.Start, r.End), "start must not be after end"))
+	}
+
+	if cidrBlock == "" {
+		return errs
+	}
+
+	_, subnet, err := net.ParseCIDR(cidrBlock)
+	if err != nil || subnet.IP.To4() == nil {
+		return append(errs, field.Invalid(fldPath, cidrBlock, "subnet CIDR block must be a valid IPv4 CIDR block"))
+	}
+
+	first := make(net.IP, net.IPv4len)
+	last := make(net.IP, net.IPv4len)
+	for i := range first {
+		first[i] = subnet.IP.To4()[i]
+		last[i] = subnet.IP.To4()[i] | ^subnet.Mask[i]
+	}
+	// The network address and the next three addresses are reserved by AWS, as is the broadcast address.
+	first[3] += 4
+	last[3]--
+
+	if bytes.Compare(start, first) < 0 || bytes.Compare(end, last) > 0 {
+		errs = append(errs, field.Invalid(fldPath, fmt.Sprintf("%s-%s", r.Start, r.End),
+			fmt.Sprintf("must be within the usable addresses %s-%s of subnet %s", first, last, cidrBlock)))
+	}
+
+	return errs
+}
+
+// Validate makes sure the endpoint is an absolute http or https URL and the authorization
+// secret reference, if any, is complete.
+func (h *HealthReportingSpec) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if h == nil {
+		return errs
+	}
+
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(fldPath.Child("url"), h.URL, "must be an absolute http or https URL"))
+	}
+
+	return append(errs, isValidSecretKeySelector(h.AuthorizationSecretRef, fldPath.Child("authorizationSecretRef"))...)
+}
+
+// Validate makes sure there is at least one time server and the servers are unique hostnames or
+// IP addresses.
+func (n *NTPSpec) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if n == nil {
+		return errs
+	}
+
+	if len(n.Servers) == 0 && n.AmazonTimeSync != nil && !*n.AmazonTimeSync {
+		errs = append(errs, field.Required(fldPath.Child("servers"), "at least one server must be set when the Amazon Time Sync Service is disabled"))
+	}
+
+	servers := map[string]bool{}
+	for i, server := range n.Servers {
+		idxPath := fldPath.Child("servers").Index(i)
+		if net.ParseIP(server) == nil {
+			if msgs := validation.IsDNS1123Subdomain(server); len(msgs) > 0 {
+				errs = append(errs, field.Invalid(idxPath, server, "must be a hostname or an IP address"))
+				continue
+			}
+		}
+		if servers[server] {
+			errs = append(errs, field.Duplicate(idxPath, server))
+		}
+		servers[server] = true
+	}
+
+	return errs
+}
+
+// instanceProfileNamePattern matches the names of IAM instance profiles.
+var instanceProfileNamePattern = regexp.MustCompile(`^[\w+=,.@-]{1,128}$`)
+
+// Validate makes sure the default instance profiles are valid instance profile names.
+func (p *DefaultInstanceProfiles) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if p == nil {
+		return errs
+	}
+
+	for _, profile := range []struct {
+		name  string
+		value string
+	}{
+		{name: "controlPlane", value: p.ControlPlane},
+		{name: "node", value: p.Node},
+	} {
+		if profile.value != "" && !instanceProfileNamePattern.MatchString(profile.value) {
+			errs = append(errs, field.Invalid(fldPath.Child(profile.name), profile.value, "must be the name of an IAM instance profile"))
+		}
+	}
+
+	return errs
+}
+
+// ValidateIAMInstanceProfile makes sure the instance profile, if set, is the name or the ARN of an IAM
+// instance profile.
+func ValidateIAMInstanceProfile(profile string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if profile == "" || instanceProfileNamePattern.MatchString(profile) {
+		return errs
+	}
+
+	if parsed, err := arn.Parse(profile); err == nil && parsed.Service == "iam" && strings.HasPrefix(parsed.Resource, "instance-profile/") {
+		path := strings.Split(strings.TrimPrefix(parsed.Resource, "instance-profile/"), "/")
+		if instanceProfileNamePattern.MatchString(path[len(path)-1]) {
+			return errs
+		}
+	}
+
+	return append(errs, field.Invalid(fldPath, profile, "must be the name or the ARN of an IAM instance profile"))
+}
+
+// Validate makes sure the DNS record names a hosted zone and is a valid domain name.
+func (r *LoadBalancerDNSRecord) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if r == nil {
+		return errs
+	}
+
+	if r.HostedZoneID == "" {
+		errs = append(errs, field.Required(fldPath.Child("hostedZoneID"), "hosted zone ID must be set"))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(r.Name) {
+		errs = append(errs, field.Invalid(fldPath.Child("name"), r.Name, msg))
+	}
+
+	return errs
+}
+
+// Validate makes sure the alarm actions are unique ARNs.
+func (m *NatGatewayMonitoringSpec) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if m == nil {
+		return errs
+	}
+
+	actions := map[string]bool{}
+	for i, action := range m.AlarmActions {
+		idxPath := fldPath.Child("alarmActions").Index(i)
+		if !arn.IsARN(action) {
+			errs = append(errs, field.Invalid(idxPath, action, "must be an ARN"))
+			continue
+		}
+		if actions[action] {
+			errs = append(errs, field.Duplicate(idxPath, action))
+		}
+		actions[action] = true
+	}
+
+	return errs
+}
+
+// ValidateSubnetGroups makes sure every subnet group has a unique name and exactly one default
+// route target, and that every subnet that joins a group joins a defined one and is private.
+func (n *NetworkSpec) ValidateSubnetGroups(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]bool{}
+	for i, group := range n.SubnetGroups {
+		idxPath := fldPath.Child("subnetGroups").Index(i)
+		switch {
+		case group.Name == "":
+			errs = append(errs, field.Required(idxPath.Child("name"), "subnet group name must be set"))
+		case names[group.Name]:
+			errs = append(errs, field.Duplicate(idxPath.Child("name"), group.Name))
+		}
+		names[group.Name] = true
+
+		if targets := group.DefaultRoute.targetCount(); targets != 1 {
+			errs = append(errs, field.Invalid(idxPath.Child("defaultRoute"), targets, "exactly one route target must be set"))
+		}
+	}
+
+	for i, subnet := range n.Subnets {
+		if subnet.SubnetGroup == "" {
+			continue
+		}
+		idxPath := fldPath.Child("subnets").Index(i)
+		if !names[subnet.SubnetGroup] {
+			errs = append(errs, field.NotFound(idxPath.Child("subnetGroup"), subnet.SubnetGroup))
+		}
+		if subnet.IsPublic {
+			errs = append(errs, field.Forbidden(idxPath.Child("subnetGroup"), "public subnets cannot join a subnet group"))
+		}
+	}
+
+	return errs
+}
+
+// ValidatePrivateEgress makes sure every private subnet of a network without public subnets, in a
+// VPC created for the cluster, joins a subnet group: no NAT gateways are created without public
+// subnets, so the default route of a subnet group is the only way out.
+func (n *NetworkSpec) ValidatePrivateEgress(fldPath *field.Path) field.ErrorList {
+	if n.VPC.ID != "" || len(n.Subnets) == 0 || len(n.Subnets.FilterPublic()) > 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for i, subnet := range n.Subnets {
+		if subnet.SubnetGroup == "" {
+			errs = append(errs, field.Required(fldPath.Child("subnets").Index(i).Child("subnetGroup"),
+				"private subnets of a cluster without public subnets must join a subnet group for egress"))
+		}
+	}
+	return errs
+}
+
+func (t RouteTarget) targetCount() int {
+	count := 0
+	for _, target := range []*string{t.GatewayID, t.NatGatewayID, t.TransitGatewayID, t.VPCPeeringConnectionID, t.NetworkInterfaceID} {
+		if target != nil && *target != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// clusterResourceDependents are the resources that have to be deleted before a resource can be.
+var clusterResourceDependents = map[ClusterResource][]ClusterResource{
+	ClusterResourceSecurityGroups: {ClusterResourceLoadBalancer, ClusterResourceBastion},
+	ClusterResourceNetwork:        {ClusterResourceLoadBalancer, ClusterResourceBastion, ClusterResourceSecurityGroups},
+}
+
+// ValidateDeletionOrder makes sure the deletion order lists every resource at most once and never
+// deletes a resource while resources depending on it are still around.
+func (s *AWSClusterSpec) ValidateDeletionOrder(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	listed := map[ClusterResource]bool{}
+	for i, resource := range s.DeletionOrder {
+		if listed[resource] {
+			errs = append(errs, field.Duplicate(fldPath.Index(i), resource))
+		}
+		listed[resource] = true
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	deleted := map[ClusterResource]bool{}
+	for _, resource := range s.ResolvedDeletionOrder() {
+		for _, dependent := range clusterResourceDependents[resource] {
+			if !deleted[dependent] {
+				errs = append(errs, field.Invalid(fldPath, s.DeletionOrder,
+					fmt.Sprintf("%s must be deleted after %s", resource, dependent)))
+			}
+		}
+		deleted[resource] = true
+	}
+
+	return errs
+}
+
+// ValidateRegistryMirrors makes sure every mirror names a registry host and has an http or https
+// endpoint that is listed once per registry, and that TLS settings are only set on https mirrors,
+// either with a complete CA reference or skipping verification.
+func (s *AWSClusterSpec) ValidateRegistryMirrors(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	endpoints := map[string]bool{}
+	for i, mirror := range s.RegistryMirrors {
+		idxPath := fldPath.Index(i)
+
+		if !isValidRegistryHost(mirror.Registry) {
+			errs = append(errs, field.Invalid(idxPath.Child("registry"), mirror.Registry, "must be a registry host, optionally with a port"))
+		}
+
+		u, err := url.Parse(mirror.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.ContainsAny(mirror.Endpoint, `"\`) {
+			errs = append(errs, field.Invalid(idxPath.Child("endpoint"), mirror.Endpoint, "must be an absolute http or https URL"))
+			continue
+		}
+		if key := mirror.Registry + " " + mirror.Endpoint; endpoints[key] {
+			errs = append(errs, field.Duplicate(idxPath.Child("endpoint"), mirror.Endpoint))
+		} else {
+			endpoints[key] = true
+		}
+
+		if mirror.TLS == nil {
+			continue
+		}
+		tlsPath := idxPath.Child("tls")
+		if u.Scheme != "https" {
+			errs = append(errs, field.Forbidden(tlsPath, "can only be set for https endpoints"))
+		}
+		if mirror.TLS.CASecretRef != nil && mirror.TLS.InsecureSkipVerify {
+			errs = append(errs, field.Invalid(tlsPath.Child("insecureSkipVerify"), mirror.TLS.InsecureSkipVerify, "cannot be set together with caSecretRef"))
+		}
+		errs = append(errs, isValidSecretKeySelector(mirror.TLS.CASecretRef, tlsPath.Child("caSecretRef"))...)
+	}
+
+	return errs
+}
+
+func isValidRegistryHost(registry string) bool {
+	host := registry
+	if h, port, err := net.SplitHostPort(registry); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || len(validation.IsValidPortNum(n)) > 0 {
+			return false
+		}
+		host = h
+	}
+	return net.ParseIP(host) != nil || len(validation.IsDNS1123Subdomain(host)) == 0
+}
+
+// ValidateNetworkBorderGroups makes sure network border groups are only set on public subnets and
+// belong to the region.
+func (n *NetworkSpec) ValidateNetworkBorderGroups(fldPath *field.Path, region string) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, sn := range n.Subnets {
+		if sn.NetworkBorderGroup == nil {
+			continue
+		}
+
+		groupPath := fldPath.Child("subnets").Index(i).Child("networkBorderGroup")
+		group := *sn.NetworkBorderGroup
+		switch {
+		case !sn.IsPublic:
+			errs = append(errs, field.Invalid(groupPath, group, "is only supported for public subnets"))
+		case region != "" && group != region && !strings.HasPrefix(group, region+"-"):
+			errs = append(errs, field.Invalid(groupPath, group, fmt.Sprintf("must be a network border group of region %q", region)))
+		}
+	}
+
+	return errs
+}
+
+// ValidateBlackholeRoutes makes sure every blackhole route has a unique IPv4 destination that
+// overlaps neither the VPC, its subnets nor any of the additional ranges the cluster relies on,
+// e.g. a secondary CIDR block for pods: traffic to these ranges must keep flowing.
+func (n *NetworkSpec) ValidateBlackholeRoutes(fldPath *field.Path, reservedCIDRBlocks ...string) field.ErrorList {
+	var errs field.ErrorList
+
+	vpcCIDRBlock := n.VPC.CidrBlock
+	if vpcCIDRBlock == "" && n.VPC.ID == "" {
+		// Managed VPCs default to 10.0.0.0/16.
+		vpcCIDRBlock = "10.0.0.0/16"
+	}
+	reserved := []string{vpcCIDRBlock}
+	for _, sn := range n.Subnets {
+		reserved = append(reserved, sn.CidrBlock)
+	}
+	reserved = append(reserved, reservedCIDRBlocks...)
+
+	destinations := map[string]bool{}
+	for i, route := range n.BlackholeRoutes {
+		idxPath := fldPath.Child("blackholeRoutes").Index(i).Child("destinationCidrBlock")
+
+		_, destination, err := net.ParseCIDR(route.DestinationCIDRBlock)
+		if err != nil || destination.IP.To4() == nil {
+			errs = append(errs, field.Invalid(idxPath, route.DestinationCIDRBlock, "must be a valid IPv4 CIDR block"))
+			continue
+		}
+		if destination.String() != route.DestinationCIDRBlock {
+			errs = append(errs, field.Invalid(idxPath, route.DestinationCIDRBlock, fmt.Sprintf("must start at the network address, e.g. %s", destination)))
+			continue
+		}
+		if ones, _ := destination.Mask.Size(); ones == 0 {
+			errs = append(errs, field.Invalid(idxPath, route.DestinationCIDRBlock, "must not replace the default route"))
+			continue
+		}
+		if destinations[destination.String()] {
+			errs = append(errs, field.Duplicate(idxPath, route.DestinationCIDRBlock))
+			continue
+		}
+		destinations[destination.String()] = true
+
+		for _, cidrBlock := range reserved {
+			if _, block, err := net.ParseCIDR(cidrBlock); err == nil && (block.Contains(destination.IP) || destination.Contains(block.IP)) {
+				errs = append(errs, field.Invalid(idxPath, route.DestinationCIDRBlock, fmt.Sprintf("must not overlap with %s, which the cluster uses", cidrBlock)))
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// dhcpOptionsSetIDPattern matches the IDs of DHCP options sets.
+var dhcpOptionsSetIDPattern = regexp.MustCompile(`^dopt-[0-9a-f]+$`)
+
+// ValidateDHCPOptionsSet makes sure the DHCP options set reference is a DHCP options set ID and, on
+// create, that it is only set for VPCs created by the provider.
+func (n *NetworkSpec) ValidateDHCPOptionsSet(old *NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if n.VPC.DHCPOptionsSetID == nil {
+		return errs
+	}
+	idPath := fldPath.Child("vpc", "dhcpOptionsSetId")
+
+	if !dhcpOptionsSetIDPattern.MatchString(*n.VPC.DHCPOptionsSetID) {
+		errs = append(errs, field.Invalid(idPath, *n.VPC.DHCPOptionsSetID, "must be a DHCP options set ID such as dopt-0123456789abcdef0"))
+	}
+	if old == nil && n.VPC.ID != "" {
+		errs = append(errs, field.Forbidden(idPath, "a DHCP options set can only be associated with VPCs created by the provider"))
+	}
+
+	return errs
+}
+
+// accountIDPattern matches the IDs of AWS accounts.
+var accountIDPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+// ValidateSharedVPC makes sure the owner of a shared VPC is an account ID and, on create, that the
+// VPC is an existing one.
+func (n *NetworkSpec) ValidateSharedVPC(old *NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if n.VPC.OwnerAccountID == "" {
+		return errs
+	}
+	ownerPath := fldPath.Child("vpc", "ownerAccountId")
+
+	if !accountIDPattern.MatchString(n.VPC.OwnerAccountID) {
+		errs = append(errs, field.Invalid(ownerPath, n.VPC.OwnerAccountID, "must be a 12-digit AWS account ID"))
+	}
+	if old == nil && n.VPC.ID == "" {
+		errs = append(errs, field.Required(fldPath.Child("vpc", "id"), "the ID of the shared VPC is required"))
+	}
+	if n.VPC.DHCPOptionsSetID != nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("vpc", "dhcpOptionsSetId"), "a DHCP options set cannot be associated with a shared VPC"))
+	}
+
+	return errs
+}
+
+// ValidateInstanceTenancy makes sure the VPC instance tenancy is only set for VPCs created by the
+// provider, and, given the previous network spec on update, that it does not change.
+func (n *NetworkSpec) ValidateInstanceTenancy(old *NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	tenancyPath := fldPath.Child("vpc", "instanceTenancy")
+
+	if old != nil {
+		if n.VPC.InstanceTenancy != old.VPC.InstanceTenancy {
+			errs = append(errs, field.Invalid(tenancyPath, n.VPC.InstanceTenancy, "field is immutable"))
+		}
+		return errs
+	}
+
+	if n.VPC.ID != "" && n.VPC.InstanceTenancy != "" {
+		errs = append(errs, field.Forbidden(tenancyPath, "instance tenancy can only be set for VPCs created by the provider"))
+	}
+
+	return errs
+}
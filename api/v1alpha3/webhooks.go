@@ -17,16 +17,47 @@ limitations under the License.
 package v1alpha3
 
 import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
-	"regexp"
 )
 
 var (
 	sshKeyValidNameRegex = regexp.MustCompile(`^[[:graph:]]+([[:print:]]*[[:graph:]]+)*$`)
 )
 
+// allowedSysctls lists the kernel parameters that can be set through the machine spec. A trailing
+// "*" allows any parameter with that prefix.
+var allowedSysctls = []string{
+	"fs.file-max",
+	"fs.inotify.*",
+	"fs.nr_open",
+	"kernel.pid_max",
+	"net.core.netdev_max_backlog",
+	"net.core.rmem_*",
+	"net.core.somaxconn",
+	"net.core.wmem_*",
+	"net.ipv4.ip_local_port_range",
+	"net.ipv4.neigh.default.gc_thresh*",
+	"net.ipv4.tcp_*",
+	"net.ipv4.vs.*",
+	"net.netfilter.nf_conntrack_*",
+	"vm.max_map_count",
+	"vm.swappiness",
+}
+
 func aggregateObjErrors(gk schema.GroupKind, name string, allErrs field.ErrorList) error {
 	if len(allErrs) == 0 {
 		return nil
@@ -49,3 +80,560 @@ func isValidSSHKey(sshKey *string) field.ErrorList {
 
 	return allErrs
 }
+
+func isValidSecretKeySelector(ref *corev1.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ref == nil {
+		return allErrs
+	}
+
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "secret name must be set"))
+	}
+	if ref.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "secret key must be set"))
+	}
+
+	return allErrs
+}
+
+func isValidSysctls(sysctls []Sysctl, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, sysctl := range sysctls {
+		idxPath := fldPath.Index(i)
+		if !isAllowedSysctl(sysctl.Name) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("name"), sysctl.Name, allowedSysctls))
+		}
+		if sysctl.Value == "" || strings.ContainsAny(sysctl.Value, "\n\r=") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), sysctl.Value, "must be a non-empty single line value"))
+		}
+	}
+
+	return allErrs
+}
+
+func isAllowedSysctl(name string) bool {
+	for _, allowed := range allowedSysctls {
+		if prefix := strings.TrimSuffix(allowed, "*"); prefix != allowed {
+			if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+				return true
+			}
+			continue
+		}
+		if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidImageGC(opts *ImageGCOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if opts == nil {
+		return allErrs
+	}
+
+	high, low := int32(DefaultImageGCHighThresholdPercent), int32(DefaultImageGCLowThresholdPercent)
+	if opts.HighThresholdPercent != nil {
+		high = *opts.HighThresholdPercent
+		if high < 0 || high > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("highThresholdPercent"), high, "must be a percentage between 0 and 100"))
+		}
+	}
+	if opts.LowThresholdPercent != nil {
+		low = *opts.LowThresholdPercent
+		if low < 0 || low > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("lowThresholdPercent"), low, "must be a percentage between 0 and 100"))
+		}
+	}
+	if len(allErrs) == 0 && low >= high {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("lowThresholdPercent"), low,
+			fmt.Sprintf("must be lower than the high threshold of %d percent", high)))
+	}
+
+	return allErrs
+}
+
+func isValidContainerLogRotation(opts *ContainerLogRotationOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if opts == nil {
+		return allErrs
+	}
+
+	if opts.MaxSize != "" {
+		if size, err := resource.ParseQuantity(opts.MaxSize); err != nil || size.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSize"), opts.MaxSize, "must be a positive quantity, e.g. 10Mi"))
+		}
+	}
+	if opts.MaxFiles != nil && *opts.MaxFiles < 2 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFiles"), *opts.MaxFiles, "must be at least 2"))
+	}
+
+	return allErrs
+}
+
+func isValidEvictionThresholds(opts *EvictionThresholdsOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if opts == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, isValidEvictionSignals(opts.Hard, fldPath.Child("hard"))...)
+	allErrs = append(allErrs, isValidEvictionSignals(opts.Soft, fldPath.Child("soft"))...)
+
+	hasSoft := opts.Soft != nil && *opts.Soft != (EvictionSignals{})
+	switch {
+	case hasSoft && opts.SoftGracePeriod == nil:
+		allErrs = append(allErrs, field.Required(fldPath.Child("softGracePeriod"), "must be set with soft thresholds"))
+	case opts.SoftGracePeriod != nil && opts.SoftGracePeriod.Duration <= 0:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("softGracePeriod"), opts.SoftGracePeriod.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
+func isValidEvictionSignals(signals *EvictionSignals, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if signals == nil {
+		return allErrs
+	}
+
+	for _, threshold := range []struct {
+		name  string
+		value string
+	}{
+		{"memoryAvailable", signals.MemoryAvailable},
+		{"nodefsAvailable", signals.NodeFSAvailable},
+		{"imagefsAvailable", signals.ImageFSAvailable},
+	} {
+		if threshold.value != "" && !isValidEvictionThreshold(threshold.value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(threshold.name), threshold.value,
+				"must be a positive quantity, e.g. 500Mi, or a percentage between 0 and 100, e.g. 10%"))
+		}
+	}
+
+	return allErrs
+}
+
+// isValidEvictionThreshold returns true if the threshold is a positive quantity or a percentage
+// the kubelet accepts.
+func isValidEvictionThreshold(threshold string) bool {
+	if strings.HasSuffix(threshold, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64)
+		return err == nil && percent > 0 && percent < 100
+	}
+
+	quantity, err := resource.ParseQuantity(threshold)
+	return err == nil && quantity.Sign() > 0
+}
+
+func isValidMonitoringTargetGroup(tg *MonitoringTargetGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if tg == nil {
+		return allErrs
+	}
+
+	if parsed, err := arn.Parse(tg.ARN); err != nil || parsed.Service != "elasticloadbalancing" || !strings.HasPrefix(parsed.Resource, "targetgroup/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("arn"), tg.ARN, "must be the ARN of an elastic load balancing target group"))
+	}
+	if tg.Port < 1 || tg.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), tg.Port, "must be between 1 and 65535"))
+	}
+
+	return allErrs
+}
+
+func isValidInstanceMetadataOptions(opts *InstanceMetadataOptions, controlPlane bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if opts == nil || opts.HTTPEndpoint != InstanceMetadataEndpointStateDisabled {
+		return allErrs
+	}
+
+	if controlPlane {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("httpEndpoint"), "the instance metadata service cannot be disabled for control plane machines"))
+	}
+	if opts.HTTPTokens != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("httpTokens"), "cannot be set when the instance metadata service is disabled"))
+	}
+	if opts.HTTPPutResponseHopLimit != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("httpPutResponseHopLimit"), "cannot be set when the instance metadata service is disabled"))
+	}
+
+	return allErrs
+}
+
+// protectedMountPaths are the directories the container runtime volume must not be mounted over.
+var protectedMountPaths = map[string]bool{
+	"/": true, "/boot": true, "/dev": true, "/etc": true, "/home": true, "/proc": true,
+	"/run": true, "/sys": true, "/tmp": true, "/usr": true, "/var": true, "/var/lib": true,
+}
+
+// ebsDeviceNamePattern matches the device names EBS volumes can be attached as on Linux.
+var ebsDeviceNamePattern = regexp.MustCompile(`^/dev/(sd|xvd)[b-z]$`)
+
+func isValidContainerRuntimeVolume(volume *ContainerRuntimeVolume, nonRootVolumes []*Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if volume == nil {
+		return allErrs
+	}
+
+	devicePath := fldPath.Child("deviceName")
+	switch {
+	case volume.DeviceName == "":
+		allErrs = append(allErrs, field.Required(devicePath, "the container runtime volume must have a device name"))
+	case !ebsDeviceNamePattern.MatchString(volume.DeviceName):
+		allErrs = append(allErrs, field.Invalid(devicePath, volume.DeviceName, "must be a device name such as /dev/sdf or /dev/xvdf"))
+	default:
+		for _, other := range nonRootVolumes {
+			if other != nil && other.DeviceName == volume.DeviceName {
+				allErrs = append(allErrs, field.Duplicate(devicePath, volume.DeviceName))
+			}
+		}
+	}
+
+	if (volume.Type == "io1" || volume.Type == "io2") && volume.IOPS == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("iops"), "iops required if type is 'io1' or 'io2'"))
+	}
+
+	if volume.MountPath != "" {
+		mountPath := fldPath.Child("mountPath")
+		switch {
+		case !path.IsAbs(volume.MountPath) || path.Clean(volume.MountPath) != volume.MountPath:
+			allErrs = append(allErrs, field.Invalid(mountPath, volume.MountPath, "must be a clean absolute path"))
+		case protectedMountPaths[volume.MountPath]:
+			allErrs = append(allErrs, field.Forbidden(mountPath, fmt.Sprintf("the container runtime volume cannot be mounted at %s", volume.MountPath)))
+		case strings.ContainsAny(volume.MountPath, " '\"\\"):
+			allErrs = append(allErrs, field.Invalid(mountPath, volume.MountPath, "must not contain spaces or quotes"))
+		}
+	}
+
+	return allErrs
+}
+
+// maxBootConfigSize is the size limit of the user data EC2 accepts. The boot config can never fit
+// into a larger user data, the size of the complete user data is checked when the instance is launched.
+const maxBootConfigSize = 16384
+
+// systemdUnitNamePattern matches the names of the systemd unit files that can be installed at boot.
+var systemdUnitNamePattern = regexp.MustCompile(`^[a-zA-Z0-9:_.@-]+\.(service|socket|timer|path|mount|target)$`)
+
+func isValidAdditionalBootConfig(bootConfig *AdditionalBootConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if bootConfig == nil {
+		return allErrs
+	}
+
+	size := 0
+	units := sets.NewString()
+	for i, unit := range bootConfig.SystemdUnits {
+		idxPath := fldPath.Child("systemdUnits").Index(i)
+		switch {
+		case !systemdUnitNamePattern.MatchString(unit.Name):
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), unit.Name, "must be a systemd unit file name such as node-problem-detector.service"))
+		case units.Has(unit.Name):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), unit.Name))
+		}
+		units.Insert(unit.Name)
+		if unit.Content == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("content"), "the unit file content must be set"))
+		}
+		size += len(unit.Content)
+	}
+
+	scripts := sets.NewString()
+	for i, script := range bootConfig.Scripts {
+		idxPath := fldPath.Child("scripts").Index(i)
+		for _, msg := range validation.IsDNS1123Label(script.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), script.Name, msg))
+		}
+		if scripts.Has(script.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), script.Name))
+		}
+		scripts.Insert(script.Name)
+		if !strings.HasPrefix(script.Content, "#!") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("content"), "", "the script must start with an interpreter line such as #!/bin/bash"))
+		}
+		size += len(script.Content)
+	}
+
+	if size > maxBootConfigSize {
+		allErrs = append(allErrs, field.Invalid(fldPath, size, fmt.Sprintf("the systemd units and scripts must not exceed %d bytes in total", maxBootConfigSize)))
+	}
+
+	return allErrs
+}
+
+// capacityReservationIDPattern matches the IDs of EC2 capacity reservations.
+var capacityReservationIDPattern = regexp.MustCompile(`^cr-[0-9a-f]+$`)
+
+func isValidCapacityReservation(preference CapacityReservationPreference, reservationID *string, spot *SpotMarketOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	idPath := fldPath.Child("capacityReservationID")
+	if preference == CapacityReservationPreferenceTargeted {
+		if reservationID == nil || *reservationID == "" {
+			allErrs = append(allErrs, field.Required(idPath, "a capacity reservation ID is required with the targeted preference"))
+		}
+		if spot != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("capacityReservationPreference"), "spot instances cannot be launched into a capacity reservation"))
+		}
+	}
+
+	if reservationID != nil {
+		switch {
+		case preference != CapacityReservationPreferenceTargeted:
+			allErrs = append(allErrs, field.Forbidden(idPath, "a capacity reservation ID is only allowed with the targeted preference"))
+		case *reservationID != "" && !capacityReservationIDPattern.MatchString(*reservationID):
+			allErrs = append(allErrs, field.Invalid(idPath, *reservationID, "must be a capacity reservation ID such as cr-0123456789abcdef0"))
+		}
+	}
+
+	return allErrs
+}
+
+// spotMaxPricePattern matches the decimal prices the spot market accepts as a maximum price.
+var spotMaxPricePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+func isValidSpotMarketOptions(spot *SpotMarketOptions, controlPlane bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spot == nil {
+		return allErrs
+	}
+
+	if controlPlane {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "control plane machines cannot be run as spot instances"))
+	}
+	if spot.MaxPrice != nil && *spot.MaxPrice != "" {
+		price, err := strconv.ParseFloat(*spot.MaxPrice, 64)
+		if !spotMaxPricePattern.MatchString(*spot.MaxPrice) || err != nil || price <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPrice"), *spot.MaxPrice, "must be a positive decimal price such as 0.05"))
+		}
+	}
+
+	return allErrs
+}
+
+func isValidSubnetTags(tags Tags, subnet *AWSResourceReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(tags) == 0 {
+		return allErrs
+	}
+
+	if subnet != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "subnet tags cannot be used with an explicit subnet"))
+	}
+	for key := range tags {
+		if key == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, key, "tag keys must not be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+// hostIDPattern matches the IDs of EC2 Dedicated Hosts.
+var hostIDPattern = regexp.MustCompile(`^h-[0-9a-f]+$`)
+
+func isValidHostPlacement(placement *HostPlacement, tenancy string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if placement == nil {
+		return allErrs
+	}
+
+	if tenancy != "host" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "host placement requires the host tenancy"))
+	}
+	if placement.HostID != nil {
+		if !hostIDPattern.MatchString(*placement.HostID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostID"), *placement.HostID, "must be a dedicated host ID such as h-0123456789abcdef0"))
+		}
+		if placement.Strategy == HostPlacementStrategyPack {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("strategy"), "the Pack strategy cannot be used with a host ID"))
+		}
+	}
+
+	return allErrs
+}
+
+// s3BucketNamePattern matches the names of S3 buckets, apart from the rules about periods.
+var s3BucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+const (
+	// minTerminationLogUploadTimeout is the shortest timeout SSM Run Command accepts.
+	minTerminationLogUploadTimeout = 30 * time.Second
+	maxTerminationLogUploadTimeout = 10 * time.Minute
+)
+
+func isValidTerminationLogUpload(upload *TerminationLogUpload, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if upload == nil {
+		return allErrs
+	}
+
+	if !s3BucketNamePattern.MatchString(upload.Bucket) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bucket"), upload.Bucket, "must be the name of an S3 bucket"))
+	}
+	if strings.HasPrefix(upload.KeyPrefix, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyPrefix"), upload.KeyPrefix, "must not start with a slash"))
+	}
+	if upload.Timeout != nil && (upload.Timeout.Duration < minTerminationLogUploadTimeout || upload.Timeout.Duration > maxTerminationLogUploadTimeout) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), upload.Timeout.Duration.String(),
+			fmt.Sprintf("must be between %s and %s", minTerminationLogUploadTimeout, maxTerminationLogUploadTimeout)))
+	}
+
+	return allErrs
+}
+
+func isValidPlacementGroupName(name, tenancy string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if name == "" {
+		return allErrs
+	}
+
+	if len(name) > 255 {
+		allErrs = append(allErrs, field.TooLong(fldPath, name, 255))
+	}
+	if tenancy == "host" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "spread placement groups cannot be used with the host tenancy"))
+	}
+
+	return allErrs
+}
+
+func isValidInstanceRequirements(requirements *InstanceRequirements, instanceType string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if requirements == nil {
+		return allErrs
+	}
+
+	if instanceType != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be set together with instanceType"))
+	}
+	if requirements.VCPUCount.Min < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vCPUCount", "min"), requirements.VCPUCount.Min, "must be at least 1"))
+	}
+	if requirements.MemoryMiB.Min < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB", "min"), requirements.MemoryMiB.Min, "must be at least 1"))
+	}
+	allErrs = append(allErrs, isValidIntegerRange(&requirements.VCPUCount, fldPath.Child("vCPUCount"))...)
+	allErrs = append(allErrs, isValidIntegerRange(&requirements.MemoryMiB, fldPath.Child("memoryMiB"))...)
+	allErrs = append(allErrs, isValidIntegerRange(requirements.GPUCount, fldPath.Child("gpuCount"))...)
+	allErrs = append(allErrs, isValidIntegerRange(requirements.NetworkBandwidthGbps, fldPath.Child("networkBandwidthGbps"))...)
+
+	return allErrs
+}
+
+func isValidIntegerRange(r *IntegerRange, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if r == nil {
+		return allErrs
+	}
+
+	if r.Min < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("min"), r.Min, "must not be negative"))
+	}
+	if r.Max != nil && *r.Max < r.Min {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("max"), *r.Max, "must not be less than min"))
+	}
+
+	return allErrs
+}
+
+func isValidCapacityFallback(opts *CapacityFallbackOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if opts == nil {
+		return allErrs
+	}
+
+	zones := map[string]bool{}
+	for i, zone := range opts.AvailabilityZones {
+		idxPath := fldPath.Child("availabilityZones").Index(i)
+		switch {
+		case zone == "":
+			allErrs = append(allErrs, field.Required(idxPath, "availability zone must not be empty"))
+		case zones[zone]:
+			allErrs = append(allErrs, field.Duplicate(idxPath, zone))
+		}
+		zones[zone] = true
+	}
+	if opts.MaxAttempts != nil && *opts.MaxAttempts < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxAttempts"), *opts.MaxAttempts, "must be at least 1"))
+	}
+
+	return allErrs
+}
+
+// allowedKubeletLabelPrefixes are the prefixes of the labels in the kubernetes.io and k8s.io
+// namespaces that the kubelet may set on its node.
+var allowedKubeletLabelPrefixes = []string{"kubelet.kubernetes.io/", "node.kubernetes.io/"}
+
+var supportedTaintEffects = sets.NewString(
+	string(corev1.TaintEffectNoSchedule),
+	string(corev1.TaintEffectPreferNoSchedule),
+	string(corev1.TaintEffectNoExecute),
+)
+
+func isValidNodeLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for key, value := range labels {
+		keyPath := fldPath.Key(key)
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			allErrs = append(allErrs, field.Invalid(keyPath, value, msg))
+		}
+		if isRestrictedKubeletLabel(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key,
+				fmt.Sprintf("labels in the kubernetes.io and k8s.io namespaces must have one of the prefixes %v", allowedKubeletLabelPrefixes)))
+		}
+	}
+
+	return allErrs
+}
+
+func isRestrictedKubeletLabel(key string) bool {
+	namespace := ""
+	if i := strings.Index(key, "/"); i >= 0 {
+		namespace = key[:i]
+	}
+	if namespace != "kubernetes.io" && namespace != "k8s.io" &&
+		!strings.HasSuffix(namespace, ".kubernetes.io") && !strings.HasSuffix(namespace, ".k8s.io") {
+		return false
+	}
+
+	for _, prefix := range allowedKubeletLabelPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidNodeTaints(taints []corev1.Taint, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := sets.NewString()
+	for i, taint := range taints {
+		idxPath := fldPath.Index(i)
+		for _, msg := range validation.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("key"), taint.Key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(taint.Value) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), taint.Value, msg))
+		}
+		if !supportedTaintEffects.Has(string(taint.Effect)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("effect"), taint.Effect, supportedTaintEffects.List()))
+		}
+
+		id := taint.Key + ":" + string(taint.Effect)
+		if seen.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, taint))
+		}
+		seen.Insert(id)
+	}
+
+	return allErrs
+}
@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha3
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/errors"
@@ -108,6 +110,43 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Bastion.DeepCopyInto(&out.Bastion)
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryCredentials != nil {
+		in, out := &in.RegistryCredentials, &out.RegistryCredentials
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryMirrors != nil {
+		in, out := &in.RegistryMirrors, &out.RegistryMirrors
+		*out = make([]RegistryMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthReporting != nil {
+		in, out := &in.HealthReporting, &out.HealthReporting
+		*out = new(HealthReportingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NTP != nil {
+		in, out := &in.NTP, &out.NTP
+		*out = new(NTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionOrder != nil {
+		in, out := &in.DeletionOrder, &out.DeletionOrder
+		*out = make([]ClusterResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultInstanceProfiles != nil {
+		in, out := &in.DefaultInstanceProfiles, &out.DefaultInstanceProfiles
+		*out = new(DefaultInstanceProfiles)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterSpec.
@@ -143,6 +182,11 @@ func (in *AWSClusterStatus) DeepCopyInto(out *AWSClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DeletedResources != nil {
+		in, out := &in.DeletedResources, &out.DeletedResources
+		*out = make([]ClusterResource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterStatus.
@@ -173,6 +217,11 @@ func (in *AWSLoadBalancerSpec) DeepCopyInto(out *AWSLoadBalancerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSRecord != nil {
+		in, out := &in.DNSRecord, &out.DNSRecord
+		*out = new(LoadBalancerDNSRecord)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerSpec.
@@ -258,6 +307,11 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		**out = **in
 	}
 	in.AMI.DeepCopyInto(&out.AMI)
+	if in.InstanceRequirements != nil {
+		in, out := &in.InstanceRequirements, &out.InstanceRequirements
+		*out = new(InstanceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
 		*out = make(Tags, len(*in))
@@ -265,6 +319,18 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExcludeClusterTags != nil {
+		in, out := &in.ExcludeClusterTags, &out.ExcludeClusterTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeTags != nil {
+		in, out := &in.VolumeTags, &out.VolumeTags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.PublicIP != nil {
 		in, out := &in.PublicIP, &out.PublicIP
 		*out = new(bool)
@@ -308,6 +374,11 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 			}
 		}
 	}
+	if in.ContainerRuntimeVolume != nil {
+		in, out := &in.ContainerRuntimeVolume, &out.ContainerRuntimeVolume
+		*out = new(ContainerRuntimeVolume)
+		**out = **in
+	}
 	if in.NetworkInterfaces != nil {
 		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
 		*out = make([]string, len(*in))
@@ -324,6 +395,94 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = new(SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostPlacement != nil {
+		in, out := &in.HostPlacement, &out.HostPlacement
+		*out = new(HostPlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityReservationID != nil {
+		in, out := &in.CapacityReservationID, &out.CapacityReservationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceMetadataOptions != nil {
+		in, out := &in.InstanceMetadataOptions, &out.InstanceMetadataOptions
+		*out = new(InstanceMetadataOptions)
+		**out = **in
+	}
+	if in.NodeLabelTags != nil {
+		in, out := &in.NodeLabelTags, &out.NodeLabelTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NVIDIADriver != nil {
+		in, out := &in.NVIDIADriver, &out.NVIDIADriver
+		*out = new(NVIDIADriverOptions)
+		**out = **in
+	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make([]Sysctl, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageGC != nil {
+		in, out := &in.ImageGC, &out.ImageGC
+		*out = new(ImageGCOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerLogRotation != nil {
+		in, out := &in.ContainerLogRotation, &out.ContainerLogRotation
+		*out = new(ContainerLogRotationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubnetTags != nil {
+		in, out := &in.SubnetTags, &out.SubnetTags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CapacityFallback != nil {
+		in, out := &in.CapacityFallback, &out.CapacityFallback
+		*out = new(CapacityFallbackOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EvictionThresholds != nil {
+		in, out := &in.EvictionThresholds, &out.EvictionThresholds
+		*out = new(EvictionThresholdsOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitoringTargetGroup != nil {
+		in, out := &in.MonitoringTargetGroup, &out.MonitoringTargetGroup
+		*out = new(MonitoringTargetGroup)
+		**out = **in
+	}
+	if in.AdditionalBootConfig != nil {
+		in, out := &in.AdditionalBootConfig, &out.AdditionalBootConfig
+		*out = new(AdditionalBootConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationLogUpload != nil {
+		in, out := &in.TerminationLogUpload, &out.TerminationLogUpload
+		*out = new(TerminationLogUpload)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineSpec.
@@ -366,6 +525,25 @@ func (in *AWSMachineStatus) DeepCopyInto(out *AWSMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BootstrapDataHash != nil {
+		in, out := &in.BootstrapDataHash, &out.BootstrapDataHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceMetadataOptions != nil {
+		in, out := &in.InstanceMetadataOptions, &out.InstanceMetadataOptions
+		*out = new(InstanceMetadataOptions)
+		**out = **in
+	}
+	if in.CapacityReservationEndTime != nil {
+		in, out := &in.CapacityReservationEndTime, &out.CapacityReservationEndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(RootVolumeStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineStatus.
@@ -505,6 +683,31 @@ func (in *AWSResourceReference) DeepCopy() *AWSResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalBootConfig) DeepCopyInto(out *AdditionalBootConfig) {
+	*out = *in
+	if in.SystemdUnits != nil {
+		in, out := &in.SystemdUnits, &out.SystemdUnits
+		*out = make([]SystemdUnit, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scripts != nil {
+		in, out := &in.Scripts, &out.Scripts
+		*out = make([]BootScript, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalBootConfig.
+func (in *AdditionalBootConfig) DeepCopy() *AdditionalBootConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalBootConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bastion) DeepCopyInto(out *Bastion) {
 	*out = *in
@@ -525,6 +728,36 @@ func (in *Bastion) DeepCopy() *Bastion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackholeRoute) DeepCopyInto(out *BlackholeRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackholeRoute.
+func (in *BlackholeRoute) DeepCopy() *BlackholeRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackholeRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootScript) DeepCopyInto(out *BootScript) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootScript.
+func (in *BootScript) DeepCopy() *BootScript {
+	if in == nil {
+		return nil
+	}
+	out := new(BootScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -623,6 +856,31 @@ func (in *CNISpec) DeepCopy() *CNISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityFallbackOptions) DeepCopyInto(out *CapacityFallbackOptions) {
+	*out = *in
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityFallbackOptions.
+func (in *CapacityFallbackOptions) DeepCopy() *CapacityFallbackOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityFallbackOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassicELB) DeepCopyInto(out *ClassicELB) {
 	*out = *in
@@ -738,121 +996,312 @@ func (in *CloudInit) DeepCopy() *CloudInit {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Filter) DeepCopyInto(out *Filter) {
+func (in *ContainerLogRotationOptions) DeepCopyInto(out *ContainerLogRotationOptions) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxFiles != nil {
+		in, out := &in.MaxFiles, &out.MaxFiles
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
-func (in *Filter) DeepCopy() *Filter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerLogRotationOptions.
+func (in *ContainerLogRotationOptions) DeepCopy() *ContainerLogRotationOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(Filter)
+	out := new(ContainerLogRotationOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressRule) DeepCopyInto(out *IngressRule) {
+func (in *ContainerRuntimeVolume) DeepCopyInto(out *ContainerRuntimeVolume) {
 	*out = *in
-	if in.CidrBlocks != nil {
-		in, out := &in.CidrBlocks, &out.CidrBlocks
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SourceSecurityGroupIDs != nil {
-		in, out := &in.SourceSecurityGroupIDs, &out.SourceSecurityGroupIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.Volume = in.Volume
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeVolume.
+func (in *ContainerRuntimeVolume) DeepCopy() *ContainerRuntimeVolume {
+	if in == nil {
+		return nil
 	}
+	out := new(ContainerRuntimeVolume)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRule.
-func (in *IngressRule) DeepCopy() *IngressRule {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsSetStatus) DeepCopyInto(out *DHCPOptionsSetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsSetStatus.
+func (in *DHCPOptionsSetStatus) DeepCopy() *DHCPOptionsSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressRule)
+	out := new(DHCPOptionsSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in IngressRules) DeepCopyInto(out *IngressRules) {
-	{
-		in := &in
-		*out = make(IngressRules, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(IngressRule)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+func (in *DefaultInstanceProfiles) DeepCopyInto(out *DefaultInstanceProfiles) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultInstanceProfiles.
+func (in *DefaultInstanceProfiles) DeepCopy() *DefaultInstanceProfiles {
+	if in == nil {
+		return nil
 	}
+	out := new(DefaultInstanceProfiles)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRules.
-func (in IngressRules) DeepCopy() IngressRules {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionSignals) DeepCopyInto(out *EvictionSignals) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionSignals.
+func (in *EvictionSignals) DeepCopy() *EvictionSignals {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressRules)
+	out := new(EvictionSignals)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Instance) DeepCopyInto(out *Instance) {
+func (in *EvictionThresholdsOptions) DeepCopyInto(out *EvictionThresholdsOptions) {
 	*out = *in
-	if in.SSHKeyName != nil {
-		in, out := &in.SSHKeyName, &out.SSHKeyName
-		*out = new(string)
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = new(EvictionSignals)
 		**out = **in
 	}
-	if in.SecurityGroupIDs != nil {
-		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.UserData != nil {
-		in, out := &in.UserData, &out.UserData
-		*out = new(string)
+	if in.Soft != nil {
+		in, out := &in.Soft, &out.Soft
+		*out = new(EvictionSignals)
 		**out = **in
 	}
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]apiv1alpha3.MachineAddress, len(*in))
-		copy(*out, *in)
-	}
-	if in.PrivateIP != nil {
-		in, out := &in.PrivateIP, &out.PrivateIP
-		*out = new(string)
+	if in.SoftGracePeriod != nil {
+		in, out := &in.SoftGracePeriod, &out.SoftGracePeriod
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.PublicIP != nil {
-		in, out := &in.PublicIP, &out.PublicIP
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionThresholdsOptions.
+func (in *EvictionThresholdsOptions) DeepCopy() *EvictionThresholdsOptions {
+	if in == nil {
+		return nil
 	}
-	if in.ENASupport != nil {
-		in, out := &in.ENASupport, &out.ENASupport
-		*out = new(bool)
-		**out = **in
+	out := new(EvictionThresholdsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Filter) DeepCopyInto(out *Filter) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.EBSOptimized != nil {
-		in, out := &in.EBSOptimized, &out.EBSOptimized
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
+func (in *Filter) DeepCopy() *Filter {
+	if in == nil {
+		return nil
 	}
-	if in.RootVolume != nil {
-		in, out := &in.RootVolume, &out.RootVolume
-		*out = new(Volume)
+	out := new(Filter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthReportingSpec) DeepCopyInto(out *HealthReportingSpec) {
+	*out = *in
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthReportingSpec.
+func (in *HealthReportingSpec) DeepCopy() *HealthReportingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthReportingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPlacement) DeepCopyInto(out *HostPlacement) {
+	*out = *in
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPlacement.
+func (in *HostPlacement) DeepCopy() *HostPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAddressRange) DeepCopyInto(out *IPAddressRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAddressRange.
+func (in *IPAddressRange) DeepCopy() *IPAddressRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAddressRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageGCOptions) DeepCopyInto(out *ImageGCOptions) {
+	*out = *in
+	if in.HighThresholdPercent != nil {
+		in, out := &in.HighThresholdPercent, &out.HighThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LowThresholdPercent != nil {
+		in, out := &in.LowThresholdPercent, &out.LowThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGCOptions.
+func (in *ImageGCOptions) DeepCopy() *ImageGCOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageGCOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressRule) DeepCopyInto(out *IngressRule) {
+	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceSecurityGroupIDs != nil {
+		in, out := &in.SourceSecurityGroupIDs, &out.SourceSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRule.
+func (in *IngressRule) DeepCopy() *IngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in IngressRules) DeepCopyInto(out *IngressRules) {
+	{
+		in := &in
+		*out = make(IngressRules, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(IngressRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRules.
+func (in IngressRules) DeepCopy() IngressRules {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRules)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Instance) DeepCopyInto(out *Instance) {
+	*out = *in
+	if in.SSHKeyName != nil {
+		in, out := &in.SSHKeyName, &out.SSHKeyName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(string)
+		**out = **in
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]apiv1alpha3.MachineAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateIP != nil {
+		in, out := &in.PrivateIP, &out.PrivateIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.ENASupport != nil {
+		in, out := &in.ENASupport, &out.ENASupport
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EBSOptimized != nil {
+		in, out := &in.EBSOptimized, &out.EBSOptimized
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(Volume)
 		**out = **in
 	}
 	if in.NonRootVolumes != nil {
@@ -878,11 +1327,38 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 			(*out)[key] = val
 		}
 	}
+	if in.VolumeTags != nil {
+		in, out := &in.VolumeTags, &out.VolumeTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VolumeIDs != nil {
+		in, out := &in.VolumeIDs, &out.VolumeIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SpotMarketOptions != nil {
 		in, out := &in.SpotMarketOptions, &out.SpotMarketOptions
 		*out = new(SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CapacityReservationID != nil {
+		in, out := &in.CapacityReservationID, &out.CapacityReservationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceMetadataOptions != nil {
+		in, out := &in.InstanceMetadataOptions, &out.InstanceMetadataOptions
+		*out = new(InstanceMetadataOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instance.
@@ -895,6 +1371,168 @@ func (in *Instance) DeepCopy() *Instance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceMetadataOptions) DeepCopyInto(out *InstanceMetadataOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceMetadataOptions.
+func (in *InstanceMetadataOptions) DeepCopy() *InstanceMetadataOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceMetadataOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRequirements) DeepCopyInto(out *InstanceRequirements) {
+	*out = *in
+	in.VCPUCount.DeepCopyInto(&out.VCPUCount)
+	in.MemoryMiB.DeepCopyInto(&out.MemoryMiB)
+	if in.GPUCount != nil {
+		in, out := &in.GPUCount, &out.GPUCount
+		*out = new(IntegerRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkBandwidthGbps != nil {
+		in, out := &in.NetworkBandwidthGbps, &out.NetworkBandwidthGbps
+		*out = new(IntegerRange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRequirements.
+func (in *InstanceRequirements) DeepCopy() *InstanceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegerRange) DeepCopyInto(out *IntegerRange) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegerRange.
+func (in *IntegerRange) DeepCopy() *IntegerRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegerRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerDNSRecord) DeepCopyInto(out *LoadBalancerDNSRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerDNSRecord.
+func (in *LoadBalancerDNSRecord) DeepCopy() *LoadBalancerDNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerDNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringTargetGroup) DeepCopyInto(out *MonitoringTargetGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringTargetGroup.
+func (in *MonitoringTargetGroup) DeepCopy() *MonitoringTargetGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringTargetGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NTPSpec) DeepCopyInto(out *NTPSpec) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AmazonTimeSync != nil {
+		in, out := &in.AmazonTimeSync, &out.AmazonTimeSync
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NTPSpec.
+func (in *NTPSpec) DeepCopy() *NTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NVIDIADriverOptions) DeepCopyInto(out *NVIDIADriverOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriverOptions.
+func (in *NVIDIADriverOptions) DeepCopy() *NVIDIADriverOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NVIDIADriverOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatGatewayMonitoringSpec) DeepCopyInto(out *NatGatewayMonitoringSpec) {
+	*out = *in
+	if in.AlarmActions != nil {
+		in, out := &in.AlarmActions, &out.AlarmActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PacketsDropThreshold != nil {
+		in, out := &in.PacketsDropThreshold, &out.PacketsDropThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EvaluationPeriods != nil {
+		in, out := &in.EvaluationPeriods, &out.EvaluationPeriods
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGatewayMonitoringSpec.
+func (in *NatGatewayMonitoringSpec) DeepCopy() *NatGatewayMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NatGatewayMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -906,6 +1544,23 @@ func (in *Network) DeepCopyInto(out *Network) {
 		}
 	}
 	in.APIServerELB.DeepCopyInto(&out.APIServerELB)
+	if in.SubnetGroups != nil {
+		in, out := &in.SubnetGroups, &out.SubnetGroups
+		*out = make([]SubnetGroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DHCPOptionsSet != nil {
+		in, out := &in.DHCPOptionsSet, &out.DHCPOptionsSet
+		*out = new(DHCPOptionsSetStatus)
+		**out = **in
+	}
+	if in.SharedVPC != nil {
+		in, out := &in.SharedVPC, &out.SharedVPC
+		*out = new(SharedVPCStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
@@ -945,6 +1600,23 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.SubnetGroups != nil {
+		in, out := &in.SubnetGroups, &out.SubnetGroups
+		*out = make([]SubnetGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlackholeRoutes != nil {
+		in, out := &in.BlackholeRoutes, &out.BlackholeRoutes
+		*out = make([]BlackholeRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.NatGatewayMonitoring != nil {
+		in, out := &in.NatGatewayMonitoring, &out.NatGatewayMonitoring
+		*out = new(NatGatewayMonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -957,6 +1629,61 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RegistryMirrorTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirrorTLS) DeepCopyInto(out *RegistryMirrorTLS) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirrorTLS.
+func (in *RegistryMirrorTLS) DeepCopy() *RegistryMirrorTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirrorTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootVolumeStatus) DeepCopyInto(out *RootVolumeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootVolumeStatus.
+func (in *RootVolumeStatus) DeepCopy() *RootVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RootVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -972,6 +1699,46 @@ func (in *RouteTable) DeepCopy() *RouteTable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTarget) DeepCopyInto(out *RouteTarget) {
+	*out = *in
+	if in.GatewayID != nil {
+		in, out := &in.GatewayID, &out.GatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.NatGatewayID != nil {
+		in, out := &in.NatGatewayID, &out.NatGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TransitGatewayID != nil {
+		in, out := &in.TransitGatewayID, &out.TransitGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.VPCPeeringConnectionID != nil {
+		in, out := &in.VPCPeeringConnectionID, &out.VPCPeeringConnectionID
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkInterfaceID != nil {
+		in, out := &in.NetworkInterfaceID, &out.NetworkInterfaceID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTarget.
+func (in *RouteTarget) DeepCopy() *RouteTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -1005,6 +1772,26 @@ func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedVPCStatus) DeepCopyInto(out *SharedVPCStatus) {
+	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVPCStatus.
+func (in *SharedVPCStatus) DeepCopy() *SharedVPCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedVPCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotMarketOptions) DeepCopyInto(out *SpotMarketOptions) {
 	*out = *in
@@ -1025,6 +1812,47 @@ func (in *SpotMarketOptions) DeepCopy() *SpotMarketOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetGroupSpec) DeepCopyInto(out *SubnetGroupSpec) {
+	*out = *in
+	in.DefaultRoute.DeepCopyInto(&out.DefaultRoute)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetGroupSpec.
+func (in *SubnetGroupSpec) DeepCopy() *SubnetGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetGroupStatus) DeepCopyInto(out *SubnetGroupStatus) {
+	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteTableIDs != nil {
+		in, out := &in.RouteTableIDs, &out.RouteTableIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetGroupStatus.
+func (in *SubnetGroupStatus) DeepCopy() *SubnetGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
@@ -1045,6 +1873,21 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PrivateIPPool != nil {
+		in, out := &in.PrivateIPPool, &out.PrivateIPPool
+		*out = new(IPAddressRange)
+		**out = **in
+	}
+	if in.NetworkBorderGroup != nil {
+		in, out := &in.NetworkBorderGroup, &out.NetworkBorderGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.NatGatewayNetworkBorderGroup != nil {
+		in, out := &in.NatGatewayNetworkBorderGroup, &out.NatGatewayNetworkBorderGroup
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -1082,6 +1925,36 @@ func (in Subnets) DeepCopy() Subnets {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sysctl) DeepCopyInto(out *Sysctl) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sysctl.
+func (in *Sysctl) DeepCopy() *Sysctl {
+	if in == nil {
+		return nil
+	}
+	out := new(Sysctl)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemdUnit) DeepCopyInto(out *SystemdUnit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemdUnit.
+func (in *SystemdUnit) DeepCopy() *SystemdUnit {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemdUnit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Tags) DeepCopyInto(out *Tags) {
 	{
@@ -1103,6 +1976,26 @@ func (in Tags) DeepCopy() Tags {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationLogUpload) DeepCopyInto(out *TerminationLogUpload) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationLogUpload.
+func (in *TerminationLogUpload) DeepCopy() *TerminationLogUpload {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationLogUpload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 	*out = *in
@@ -1128,6 +2021,11 @@ func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 		*out = new(AZSelectionScheme)
 		**out = **in
 	}
+	if in.DHCPOptionsSetID != nil {
+		in, out := &in.DHCPOptionsSetID, &out.DHCPOptionsSetID
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSpec.
@@ -66,8 +66,10 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"ec2:AssociateRouteTable",
 				"ec2:AttachInternetGateway",
 				"ec2:AuthorizeSecurityGroupIngress",
+				"ec2:CopyImage",
 				"ec2:CreateInternetGateway",
 				"ec2:CreateNatGateway",
+				"ec2:CreateNetworkInterface",
 				"ec2:CreateRoute",
 				"ec2:CreateRouteTable",
 				"ec2:CreateSecurityGroup",
@@ -77,20 +79,29 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"ec2:ModifyVpcAttribute",
 				"ec2:DeleteInternetGateway",
 				"ec2:DeleteNatGateway",
+				"ec2:DeleteNetworkInterface",
+				"ec2:DeleteRoute",
 				"ec2:DeleteRouteTable",
 				"ec2:DeleteSecurityGroup",
+				"ec2:DeleteSnapshot",
 				"ec2:DeleteSubnet",
 				"ec2:DeleteTags",
 				"ec2:DeleteVpc",
+				"ec2:DeregisterImage",
 				"ec2:DescribeAccountAttributes",
 				"ec2:DescribeAddresses",
 				"ec2:DescribeAvailabilityZones",
 				"ec2:DescribeInstances",
+				"ec2:DescribeInstanceTypes",
+				"ec2:DescribeInstanceTypeOfferings",
+				"ec2:DescribeInstanceStatus",
 				"ec2:DescribeInternetGateways",
 				"ec2:DescribeImages",
+				"ec2:DescribeHosts",
 				"ec2:DescribeNatGateways",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DescribeNetworkInterfaceAttribute",
+				"ec2:DescribePlacementGroups",
 				"ec2:DescribeRouteTables",
 				"ec2:DescribeSecurityGroups",
 				"ec2:DescribeSubnets",
@@ -119,6 +130,10 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
 				"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
 				"elasticloadbalancing:RemoveTags",
+				"elasticloadbalancing:DescribeTargetGroups",
+				"elasticloadbalancing:DescribeTargetHealth",
+				"elasticloadbalancing:RegisterTargets",
+				"elasticloadbalancing:DeregisterTargets",
 				"autoscaling:DescribeAutoScalingGroups",
 				"autoscaling:DescribeInstanceRefreshes",
 				"ec2:CreateLaunchTemplate",
@@ -127,6 +142,20 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeLaunchTemplateVersions",
 				"ec2:DeleteLaunchTemplate",
 				"ec2:DeleteLaunchTemplateVersions",
+				"outposts:GetOutpostInstanceTypes",
+				"route53:ChangeResourceRecordSets",
+				"route53:ListResourceRecordSets",
+				"cloudwatch:DescribeAlarms",
+				"cloudwatch:PutMetricAlarm",
+				"cloudwatch:DeleteAlarms",
+				"ssm:SendCommand",
+				"ssm:GetCommandInvocation",
+				"iam:GetInstanceProfile",
+				"ec2:DescribeDhcpOptions",
+				"ec2:AssociateDhcpOptions",
+				"ec2:DescribeCapacityReservations",
+				"ec2:DescribeVolumesModifications",
+				"ec2:ModifyVolume",
 			},
 		},
 		{
@@ -20,6 +20,7 @@ import (
 	"context"
 	"net"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -124,6 +125,16 @@ func (r *AWSClusterReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reter
 	return reconcileNormal(clusterScope)
 }
 
+// clusterResourceDeleted returns true if the resource has already been torn down while deleting the cluster.
+func clusterResourceDeleted(awsCluster *infrav1.AWSCluster, resource infrav1.ClusterResource) bool {
+	for _, deleted := range awsCluster.Status.DeletedResources {
+		if deleted == resource {
+			return true
+		}
+	}
+	return false
+}
+
 // TODO(ncdc): should this be a function on ClusterScope?
 func reconcileDelete(clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling AWSCluster delete")
@@ -141,24 +152,24 @@ func reconcileDelete(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 		}
 	}
 
-	if err := elbsvc.DeleteLoadbalancers(); err != nil {
-		clusterScope.Error(err, "error deleting load balancer")
-		return reconcile.Result{}, err
-	}
-
-	if err := ec2svc.DeleteBastion(); err != nil {
-		clusterScope.Error(err, "error deleting bastion")
-		return reconcile.Result{}, err
+	deleteFuncs := map[infrav1.ClusterResource]func() error{
+		infrav1.ClusterResourceLoadBalancer:   elbsvc.DeleteLoadbalancers,
+		infrav1.ClusterResourceBastion:        ec2svc.DeleteBastion,
+		infrav1.ClusterResourceSecurityGroups: sgService.DeleteSecurityGroups,
+		infrav1.ClusterResourceNetwork:        networkSvc.DeleteNetwork,
 	}
 
-	if err := sgService.DeleteSecurityGroups(); err != nil {
-		clusterScope.Error(err, "error deleting security groups")
-		return reconcile.Result{}, err
-	}
+	for _, resource := range clusterScope.AWSCluster.Spec.ResolvedDeletionOrder() {
+		if clusterResourceDeleted(clusterScope.AWSCluster, resource) {
+			continue
+		}
 
-	if err := networkSvc.DeleteNetwork(); err != nil {
-		clusterScope.Error(err, "error deleting network")
-		return reconcile.Result{}, err
+		clusterScope.V(2).Info("Deleting cluster resource", "resource", resource)
+		if err := deleteFuncs[resource](); err != nil {
+			clusterScope.Error(err, "error deleting cluster resource", "resource", resource)
+			return reconcile.Result{}, err
+		}
+		clusterScope.AWSCluster.Status.DeletedResources = append(clusterScope.AWSCluster.Status.DeletedResources, resource)
 	}
 
 	// Cluster is deleted so remove the finalizer.
@@ -232,10 +243,29 @@ func reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 		clusterScope.Info("Waiting on API server ELB DNS name to resolve")
 		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
 	}
+
+	endpointHost := awsCluster.Status.Network.APIServerELB.DNSName
+	if lb := clusterScope.ControlPlaneLoadBalancer(); lb != nil && lb.DNSRecord != nil {
+		if err := elbService.ReconcileDNSRecord(); err != nil {
+			clusterScope.Error(err, "failed to reconcile load balancer DNS record")
+			conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return reconcile.Result{}, err
+		}
+		endpointHost = lb.DNSRecord.Name
+	}
+
+	// The control plane endpoint is immutable, so a load balancer recreated with a new DNS name, e.g. after
+	// being deleted out-of-band, can only be recovered from through a managed DNS record.
+	if host := awsCluster.Spec.ControlPlaneEndpoint.Host; host != "" && !strings.EqualFold(host, endpointHost) {
+		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.ControlPlaneEndpointChangedReason, clusterv1.ConditionSeverityError,
+			"control plane endpoint %q is no longer served by the load balancer, which is now reachable at %q", host, endpointHost)
+		clusterScope.Info("Control plane endpoint no longer matches the API server load balancer", "endpoint", host, "load-balancer", endpointHost)
+		return reconcile.Result{}, nil
+	}
 	conditions.MarkTrue(awsCluster, infrav1.LoadBalancerReadyCondition)
 
 	awsCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
-		Host: awsCluster.Status.Network.APIServerELB.DNSName,
+		Host: endpointHost,
 		Port: clusterScope.APIServerPort(),
 	}
 
@@ -43,14 +43,26 @@ func (r *AWSMachineReconciler) updateMachineAnnotation(machine *infrav1.AWSMachi
 	annotations := machine.GetAnnotations()
 
 	// Set our annotation to the given content.
-	if annotations != nil {
-		annotations[annotation] = content
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
+	annotations[annotation] = content
 
 	// Update the machine object with these annotations
 	machine.SetAnnotations(annotations)
 }
 
+// removeMachineAnnotation removes the `annotation` from the given `machine`, if present.
+func (r *AWSMachineReconciler) removeMachineAnnotation(machine *infrav1.AWSMachine, annotation string) {
+	annotations := machine.GetAnnotations()
+	if _, ok := annotations[annotation]; !ok {
+		return
+	}
+
+	delete(annotations, annotation)
+	machine.SetAnnotations(annotations)
+}
+
 // Returns a map[string]interface from a JSON annotation.
 // This method gets the given `annotation` from the `machine` and unmarshalls it
 // from a JSON string into a `map[string]interface{}`.
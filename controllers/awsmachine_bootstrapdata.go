@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
+)
+
+// reconcileBootstrapDataHash compares the hash of the user data the machine's instance was launched
+// with to the hash of the user data it would be launched with now, and reports whether they match on
+// the BootstrapDataUpToDate condition. Instances launched before their hash was recorded are not
+// compared. It never fails the reconciliation.
+func (r *AWSMachineReconciler) reconcileBootstrapDataHash(ctx context.Context, ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope) {
+	launched := machineScope.GetBootstrapDataHash()
+	if launched == nil {
+		return
+	}
+
+	bootstrapData, err := r.renderUserData(ctx, ec2svc, machineScope)
+	if err != nil {
+		machineScope.V(2).Info("Unable to render bootstrap data for comparison", "error", err.Error())
+		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.BootstrapDataUpToDateCondition, infrav1.BootstrapDataUnavailableReason, err.Error())
+		return
+	}
+
+	markBootstrapDataUpToDate(machineScope, *launched, userdata.ComputeHash(bootstrapData))
+}
+
+// markBootstrapDataUpToDate sets the BootstrapDataUpToDate condition according to whether the hash of the
+// desired user data matches the hash of the user data the instance was launched with.
+func markBootstrapDataUpToDate(machineScope *scope.MachineScope, launched, desired string) {
+	if launched == desired {
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.BootstrapDataUpToDateCondition)
+		return
+	}
+
+	if !conditions.IsFalse(machineScope.AWSMachine, infrav1.BootstrapDataUpToDateCondition) {
+		machineScope.Info("Bootstrap data changed since the instance was launched", "instance-id", machineScope.GetInstanceID())
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.BootstrapDataUpToDateCondition, infrav1.OutdatedBootstrapReason, clusterv1.ConditionSeverityWarning,
+		"bootstrap data changed since the instance was launched, the instance needs to be replaced to pick up the changes")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// CapacityReservationExpiryPolicy configures how machines whose instances run in time-bounded
+// capacity reservations, such as Capacity Blocks, are handled as the reservation nears its end,
+// when EC2 terminates the instances.
+type CapacityReservationExpiryPolicy struct {
+	// WarningThreshold is how long before the reservation ends the CapacityReservationActive
+	// condition turns false. The policy is disabled when it is zero.
+	WarningThreshold time.Duration
+
+	// ReplacementThreshold is how long before the reservation ends worker machines are marked as
+	// failed, so that they are drained and replaced before their instances are terminated. Machines
+	// are not replaced when it is zero.
+	ReplacementThreshold time.Duration
+}
+
+// Enabled returns true if the end of capacity reservations should be tracked.
+func (p CapacityReservationExpiryPolicy) Enabled() bool {
+	return p.WarningThreshold > 0 || p.ReplacementThreshold > 0
+}
+
+// reconcileCapacityReservationExpiry reports on the CapacityReservationActive condition whether the
+// time-bounded capacity reservation of the machine's instance ends within the warning threshold, and
+// marks worker machines as failed once it ends within the replacement threshold. Control plane
+// machines are only warned about, since replacing them is up to the control plane provider.
+func (r *AWSMachineReconciler) reconcileCapacityReservationExpiry(ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, instance *infrav1.Instance) (ctrl.Result, error) {
+	if instance.CapacityReservationID == nil {
+		machineScope.SetCapacityReservationEndTime(nil)
+		conditions.Delete(machineScope.AWSMachine, infrav1.CapacityReservationActiveCondition)
+		return ctrl.Result{}, nil
+	}
+
+	end, err := ec2svc.GetCapacityReservationEndDate(*instance.CapacityReservationID)
+	if err != nil {
+		machineScope.Error(err, "failed to get capacity reservation end date")
+		return ctrl.Result{}, err
+	}
+	if end == nil {
+		machineScope.SetCapacityReservationEndTime(nil)
+		conditions.Delete(machineScope.AWSMachine, infrav1.CapacityReservationActiveCondition)
+		return ctrl.Result{}, nil
+	}
+	endTime := metav1.NewTime(*end)
+	machineScope.SetCapacityReservationEndTime(&endTime)
+
+	remaining := time.Until(*end)
+	policy := r.CapacityReservationExpiryPolicy
+
+	if policy.ReplacementThreshold > 0 && remaining <= policy.ReplacementThreshold && !machineScope.IsControlPlane() {
+		if machineScope.HasFailed() {
+			return ctrl.Result{}, nil
+		}
+		err := errors.Errorf("capacity reservation %q of EC2 instance %q ends at %s", *instance.CapacityReservationID, instance.ID, end.UTC().Format(time.RFC3339))
+		machineScope.Info("Marking machine as failed to replace it before its capacity reservation ends", "instance-id", instance.ID, "capacity-reservation-id", *instance.CapacityReservationID)
+		machineScope.SetFailureReason(capierrors.UpdateMachineError)
+		machineScope.SetFailureMessage(err)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.CapacityReservationActiveCondition, infrav1.CapacityReservationReplacementReason, clusterv1.ConditionSeverityError, err.Error())
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "CapacityReservationReplacement", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	if remaining <= policy.WarningThreshold {
+		if !conditions.IsFalse(machineScope.AWSMachine, infrav1.CapacityReservationActiveCondition) {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "CapacityReservationExpiring",
+				"Capacity reservation %q ends at %s", *instance.CapacityReservationID, end.UTC().Format(time.RFC3339))
+		}
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.CapacityReservationActiveCondition, infrav1.CapacityReservationExpiringReason, clusterv1.ConditionSeverityWarning,
+			"capacity reservation %q ends at %s", *instance.CapacityReservationID, end.UTC().Format(time.RFC3339))
+	} else {
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.CapacityReservationActiveCondition)
+	}
+
+	// Come back when the next threshold is crossed.
+	var requeueAfter time.Duration
+	for _, threshold := range []time.Duration{policy.WarningThreshold, policy.ReplacementThreshold} {
+		if until := remaining - threshold; threshold > 0 && until > 0 && (requeueAfter == 0 || until < requeueAfter) {
+			requeueAfter = until
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
@@ -36,7 +36,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -54,6 +53,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/secretsmanager"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/healthreport"
 )
 
 const InstanceIDIndex = ".spec.instanceID"
@@ -66,7 +66,24 @@ type AWSMachineReconciler struct {
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2MachineInterface
 	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretInterface
 	SSMServiceFactory            func(cloud.ClusterScoper) services.SecretInterface
+	instanceLogsServiceFactory   func(cloud.ClusterScoper) services.InstanceLogsInterface
 	Endpoints                    []scope.ServiceEndpoint
+
+	// SkipQuorumCheck disables serializing the termination of control plane instances.
+	SkipQuorumCheck bool
+
+	// RecoveryPolicy configures when machines with impaired instances are marked as failed.
+	RecoveryPolicy InstanceRecoveryPolicy
+
+	// CapacityReservationExpiryPolicy configures how machines in time-bounded capacity reservations
+	// are handled as their reservations near their end.
+	CapacityReservationExpiryPolicy CapacityReservationExpiryPolicy
+
+	// CostAllocationTags lists the tags every instance is expected to carry for cost allocation.
+	CostAllocationTags CostAllocationTagPolicy
+
+	// HealthReporter delivers machine health transitions to the health reporting endpoints of clusters.
+	HealthReporter *healthreport.Reporter
 }
 
 const (
@@ -97,6 +114,13 @@ func (r *AWSMachineReconciler) getSSMService(scope cloud.ClusterScoper) services
 	return ssm.NewService(scope)
 }
 
+func (r *AWSMachineReconciler) getInstanceLogsService(scope cloud.ClusterScoper) services.InstanceLogsInterface {
+	if r.instanceLogsServiceFactory != nil {
+		return r.instanceLogsServiceFactory(scope)
+	}
+	return ssm.NewService(scope)
+}
+
 func (r *AWSMachineReconciler) getSecretService(machineScope *scope.MachineScope, scope cloud.ClusterScoper) (services.SecretInterface, error) {
 	switch machineScope.SecureSecretsBackend() {
 	case infrav1.SecretBackendSSMParameterStore:
@@ -170,6 +194,7 @@ func (r *AWSMachineReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reter
 		Machine:      machine,
 		InfraCluster: infraCluster,
 		AWSMachine:   awsMachine,
+		Recorder:     r.Recorder,
 	})
 	if err != nil {
 		logger.Error(err, "failed to create scope")
@@ -178,21 +203,21 @@ func (r *AWSMachineReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reter
 
 	// Always close the scope when exiting this function so we can persist any AWSMachine changes.
 	defer func() {
-		if err := machineScope.Close(); err != nil && reterr == nil {
+		if err := machineScope.Close(ctx); err != nil && reterr == nil {
 			reterr = err
 		}
 	}()
 
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
-		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return r.reconcileDelete(machineScope, infraScope, infraScope, nil)
+		if machineScope.IsBeingDeleted() {
+			return r.reconcileDelete(ctx, machineScope, infraScope, infraScope, nil)
 		}
 
 		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil)
 	case *scope.ClusterScope:
-		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return r.reconcileDelete(machineScope, infraScope, infraScope, infraScope)
+		if machineScope.IsBeingDeleted() {
+			return r.reconcileDelete(ctx, machineScope, infraScope, infraScope, infraScope)
 		}
 
 		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, infraScope)
@@ -308,7 +333,7 @@ func (r *AWSMachineReconciler) SetupWithManager(mgr ctrl.Manager, options contro
 	)
 }
 
-func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope) (ctrl.Result, error) {
+func (r *AWSMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope) (ctrl.Result, error) {
 	machineScope.Info("Handling deleted AWSMachine")
 
 	ec2Service := r.getEC2Service(ec2Scope)
@@ -336,12 +361,18 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		// 4. Scale controller deployment to 1
 		machineScope.V(2).Info("Unable to locate EC2 instance by ID or tags")
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "NoInstanceFound", "Unable to find matching EC2 instance")
-		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
+		machineScope.RemoveFinalizer(infrav1.MachineFinalizer)
 		return ctrl.Result{}, nil
 	}
 
 	machineScope.V(3).Info("EC2 instance found matching deleted AWSMachine", "instance-id", instance.ID)
 
+	if instance.State != infrav1.InstanceStateShuttingDown && instance.State != infrav1.InstanceStateTerminated {
+		if result, err := r.reconcileTerminationQuorum(machineScope); err != nil || !result.IsZero() {
+			return result, err
+		}
+	}
+
 	if err := r.reconcileLBAttachment(machineScope, elbScope, instance); err != nil {
 		// We are tolerating AccessDenied error, so this won't block for users with older version of IAM;
 		// all the other errors are blocking.
@@ -351,6 +382,12 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		}
 	}
 
+	if err := r.reconcileMonitoringTargetGroup(machineScope, elbScope, instance); err != nil {
+		if !elb.IsAccessDenied(err) && !elb.IsNotFound(err) {
+			return ctrl.Result{}, errors.Errorf("failed to reconcile monitoring target group registration: %+v", err)
+		}
+	}
+
 	if machineScope.IsControlPlane() {
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 	}
@@ -372,11 +409,13 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 
 		// Set the InstanceReadyCondition and patch the object before the blocking operation
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-		if err := machineScope.PatchObject(); err != nil {
+		if err := machineScope.PatchObject(ctx); err != nil {
 			machineScope.Error(err, "failed to patch object")
 			return ctrl.Result{}, err
 		}
 
+		r.uploadTerminationLogs(machineScope, clusterScope, instance)
+
 		if err := ec2Service.TerminateInstanceAndWait(instance.ID); err != nil {
 			machineScope.Error(err, "failed to terminate instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -384,6 +423,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			return ctrl.Result{}, err
 		}
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		r.reportHealth(ctx, machineScope, healthreport.TransitionTerminated, instance, "instance terminated on machine deletion")
 
 		// If the AWSMachine specifies Network Interfaces, detach the cluster's core Security Groups from them as part of deletion.
 		if len(machineScope.AWSMachine.Spec.NetworkInterfaces) > 0 {
@@ -400,7 +440,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			)
 
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-			if err := machineScope.PatchObject(); err != nil {
+			if err := machineScope.PatchObject(ctx); err != nil {
 				return ctrl.Result{}, err
 			}
 
@@ -415,11 +455,11 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		}
 
 		machineScope.Info("EC2 instance successfully terminated", "instance-id", instance.ID)
-		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulTerminate", "Terminated instance %q", instance.ID)
+		machineScope.RecordInstanceTerminated(instance.ID)
 	}
 
 	// Instance is deleted so remove the finalizer.
-	controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
+	machineScope.RemoveFinalizer(infrav1.MachineFinalizer)
 
 	return ctrl.Result{}, nil
 }
@@ -450,7 +490,7 @@ func (r *AWSMachineReconciler) findInstance(scope *scope.MachineScope, ec2svc se
 	return instance, nil
 }
 
-func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope) (ctrl.Result, error) {
+func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope) (ctrl.Result, error) {
 	machineScope.Info("Reconciling AWSMachine")
 
 	// If the AWSMachine is in an error state, return early.
@@ -469,11 +509,12 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	}
 
 	// If the AWSMachine doesn't have our finalizer, add it.
-	controllerutil.AddFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
-	// Register the finalizer immediately to avoid orphaning AWS resources on delete
-	if err := machineScope.PatchObject(); err != nil {
-		machineScope.Error(err, "unable to patch object")
-		return ctrl.Result{}, err
+	if machineScope.EnsureFinalizer(infrav1.MachineFinalizer) {
+		// Register the finalizer immediately to avoid orphaning AWS resources on delete
+		if err := machineScope.PatchObject(ctx); err != nil {
+			machineScope.Error(err, "unable to patch object")
+			return ctrl.Result{}, err
+		}
 	}
 
 	if !machineScope.Cluster.Status.InfrastructureReady {
@@ -503,17 +544,25 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		// Avoid a flickering condition between InstanceProvisionStarted and InstanceProvisionFailed if there's a persistent failure with createInstance
 		if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceProvisionFailedReason {
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionStartedReason, clusterv1.ConditionSeverityInfo, "")
-			if patchErr := machineScope.PatchObject(); err != nil {
+			if patchErr := machineScope.PatchStatus(ctx); patchErr != nil {
 				machineScope.Error(patchErr, "failed to patch conditions")
 				return ctrl.Result{}, patchErr
 			}
 		}
-		instance, err = r.createInstance(ec2svc, machineScope, clusterScope)
+		instance, err = r.createInstance(ctx, ec2svc, machineScope, clusterScope)
+		if errors.Cause(err) == scope.ErrBootstrapDataNotReady {
+			machineScope.Info("Bootstrap data secret reference is not yet available")
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
+			return ctrl.Result{}, nil
+		}
 		if err != nil {
 			machineScope.Error(err, "unable to create instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
+		r.reportHealth(ctx, machineScope, healthreport.TransitionCreated, instance, "")
+	} else {
+		r.reconcileBootstrapDataHash(ctx, ec2svc, machineScope)
 	}
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(ec2Scope)
@@ -523,7 +572,10 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	}
 
 	// Make sure Spec.ProviderID and Spec.InstanceID are always set.
-	machineScope.SetProviderID(instance.ID, instance.AvailabilityZone)
+	if err := machineScope.SetProviderID(instance.ID, instance.AvailabilityZone); err != nil {
+		machineScope.Error(err, "unable to set provider ID")
+		return ctrl.Result{}, err
+	}
 	machineScope.SetInstanceID(instance.ID)
 
 	// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-lifecycle.html
@@ -532,11 +584,19 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	machineScope.SetInterruptible()
 
 	existingInstanceState := machineScope.GetInstanceState()
-	machineScope.SetInstanceState(instance.State)
+	if err := machineScope.TransitionInstanceState(instance.State); err != nil {
+		// A stale describe of the instance, carry on with the state it was last seen in.
+		machineScope.Info("Ignoring EC2 instance state", "reason", err.Error(), "instance-id", *machineScope.GetInstanceID())
+		instance.State = *existingInstanceState
+	}
+	machineScope.SetInstanceMetadataOptions(instance.InstanceMetadataOptions)
 
 	// Proceed to reconcile the AWSMachine state.
 	if existingInstanceState == nil || *existingInstanceState != instance.State {
 		machineScope.Info("EC2 instance state changed", "state", instance.State, "instance-id", *machineScope.GetInstanceID())
+		if instance.State == infrav1.InstanceStateRunning {
+			machineScope.RecordInstanceRunning(instance.ID)
+		}
 	}
 
 	switch instance.State {
@@ -563,6 +623,8 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "", "")
 	}
 
+	r.reconcileHealthReport(ctx, ec2svc, machineScope, instance)
+
 	// reconcile the deletion of the bootstrap data secret now that we have updated instance state
 	if deleteSecretErr := r.deleteEncryptedBootstrapDataSecret(machineScope, clusterScope); err != nil {
 		r.Log.Error(deleteSecretErr, "unable to delete secrets")
@@ -576,16 +638,27 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 	// tasks that can take place during all known instance states
 	if machineScope.InstanceIsInKnownState() {
-		_, err = r.ensureTags(ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), machineScope.AdditionalTags())
+		_, err = r.ensureTags(ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), r.costAllocationTags(machineScope, r.instanceTags(machineScope)))
 		if err != nil {
 			machineScope.Error(err, "failed to ensure tags")
 			return ctrl.Result{}, err
 		}
 
+		_, err = r.ensureVolumeTags(ec2svc, machineScope.AWSMachine, instance.VolumeIDs, machineScope.VolumeTags())
+		if err != nil {
+			machineScope.Error(err, "failed to ensure volume tags")
+			return ctrl.Result{}, err
+		}
+
 		if err := r.reconcileLBAttachment(machineScope, elbScope, instance); err != nil {
 			machineScope.Error(err, "failed to reconcile LB attachment")
 			return ctrl.Result{}, err
 		}
+
+		if err := r.reconcileMonitoringTargetGroup(machineScope, elbScope, instance); err != nil {
+			machineScope.Error(err, "failed to reconcile monitoring target group registration")
+			return ctrl.Result{}, err
+		}
 	}
 
 	// tasks that can only take place during operational instance states
@@ -606,11 +679,41 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 			return ctrl.Result{}, err
 		}
 		conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
+
+		var result ctrl.Result
+		if r.CapacityReservationExpiryPolicy.Enabled() {
+			if result, err = r.reconcileCapacityReservationExpiry(ec2svc, machineScope, instance); err != nil {
+				return result, err
+			}
+		}
+
+		volumeResult, err := r.reconcileRootVolumeType(ec2svc, machineScope, instance)
+		if err != nil {
+			return volumeResult, err
+		}
+		result = soonestRequeue(result, volumeResult)
+
+		if r.RecoveryPolicy.Enabled() {
+			recoveryResult, err := r.reconcileInstanceRecovery(ec2svc, machineScope, instance)
+			if err != nil {
+				return recoveryResult, err
+			}
+			result = soonestRequeue(result, recoveryResult)
+		}
+		return result, nil
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// soonestRequeue returns whichever of the results requeues first.
+func soonestRequeue(a, b ctrl.Result) ctrl.Result {
+	if a.RequeueAfter == 0 || (b.RequeueAfter > 0 && b.RequeueAfter < a.RequeueAfter) {
+		return b
+	}
+	return a
+}
+
 func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) error {
 	if !machineScope.UseSecretsManager() {
 		return nil
@@ -631,7 +734,7 @@ func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *
 	}
 
 	// Do nothing if the AWSMachine is not in a failed state, and is operational from an EC2 perspective, but does not have a node reference
-	if !machineScope.HasFailed() && machineScope.InstanceIsOperational() && machineScope.Machine.Status.NodeRef == nil && !machineScope.AWSMachineIsDeleted() {
+	if !machineScope.HasFailed() && machineScope.InstanceIsOperational() && machineScope.Machine.Status.NodeRef == nil && !machineScope.IsBeingDeleted() {
 		return nil
 	}
 	machineScope.Info("Deleting unneeded entry from AWS Secret", "secretPrefix", machineScope.GetSecretPrefix())
@@ -648,10 +751,19 @@ func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *
 	return nil
 }
 
-func (r *AWSMachineReconciler) createInstance(ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) (*infrav1.Instance, error) {
+func (r *AWSMachineReconciler) createInstance(ctx context.Context, ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) (*infrav1.Instance, error) {
 	machineScope.Info("Creating EC2 instance")
 
-	userData, userDataErr := r.resolveUserData(machineScope, clusterScope)
+	if err := r.resolveInstanceType(ec2svc, machineScope); err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve instance type")
+	}
+
+	bootstrapData, err := r.renderUserData(ctx, ec2svc, machineScope)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve userdata")
+	}
+
+	userData, userDataErr := r.resolveUserData(ctx, machineScope, clusterScope, bootstrapData)
 	if userDataErr != nil {
 		return nil, errors.Wrapf(userDataErr, "failed to resolve userdata")
 	}
@@ -661,16 +773,65 @@ func (r *AWSMachineReconciler) createInstance(ec2svc services.EC2MachineInterfac
 		return nil, errors.Wrapf(err, "failed to create AWSMachine instance")
 	}
 
+	machineScope.SetBootstrapDataHash(userdata.ComputeHash(bootstrapData))
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.BootstrapDataUpToDateCondition)
+
 	return instance, nil
 }
 
-func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) ([]byte, error) {
-	userData, err := machineScope.GetRawBootstrapData()
+// resolveInstanceType picks the type the instance is created as out of the instance types matching the
+// machine's instance requirements, unless the machine names its instance type. It is picked again on
+// every attempt to create the instance, so that the pick follows changes to the instance type offerings.
+func (r *AWSMachineReconciler) resolveInstanceType(ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope) error {
+	if machineScope.AWSMachine.Spec.InstanceType != "" || machineScope.InstanceRequirements() == nil {
+		return nil
+	}
+
+	instanceTypes, err := ec2svc.GetInstanceTypesFromInstanceRequirements(machineScope)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedResolveInstanceType", err.Error())
+		return err
+	}
+	if len(instanceTypes) == 0 {
+		err := errors.New("no instance type offered in the machine's availability zones matches its instance requirements")
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedResolveInstanceType", err.Error())
+		return err
+	}
+
+	machineScope.V(2).Info("Picked instance type from instance requirements", "instance-type", instanceTypes[0])
+	machineScope.SetInstanceType(instanceTypes[0])
+	return nil
+}
+
+// renderUserData returns the machine's bootstrap data with the additional node configuration merged in.
+func (r *AWSMachineReconciler) renderUserData(ctx context.Context, ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope) ([]byte, error) {
+	userData, err := machineScope.GetRawBootstrapData(ctx)
+	if errors.Cause(err) == scope.ErrBootstrapDataNotReady {
+		return nil, err
+	}
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
 		return nil, err
 	}
 
+	extensions, err := r.userDataExtensions(ctx, ec2svc, machineScope)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedResolveUserDataExtensions", err.Error())
+		return nil, err
+	}
+
+	userData, err = userdata.WithExtensions(userData, extensions)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedMergeUserDataExtensions", err.Error())
+		return nil, err
+	}
+
+	return userData, nil
+}
+
+// resolveUserData returns the user data the instance is launched with, which is the rendered user data
+// itself or, with a secret backend, the user data fetching it from the secret backend.
+func (r *AWSMachineReconciler) resolveUserData(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, userData []byte) ([]byte, error) {
 	if !machineScope.UseSecretsManager() {
 		return userData, nil
 	}
@@ -692,7 +853,7 @@ func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope,
 		machineScope.SetSecretCount(chunks)
 	}
 	// Register the Secret ARN immediately to avoid orphaning whatever AWS resources have been created
-	if err := machineScope.PatchObject(); err != nil {
+	if err := machineScope.PatchObject(ctx); err != nil {
 		return nil, err
 	}
 	if serviceErr != nil {
@@ -717,7 +878,7 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 
 	// In order to prevent sending request to a "not-ready" control plane machines, it is required to remove the machine
 	// from the ELB as soon as the machine gets deleted or when the machine is in a not running state.
-	if !machineScope.AWSMachine.DeletionTimestamp.IsZero() || !machineScope.InstanceIsRunning() {
+	if machineScope.IsBeingDeleted() || !machineScope.InstanceIsRunning() {
 		registered, err := elbsvc.InstanceIsRegisteredWithAPIServerELB(i)
 		if err != nil {
 			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
@@ -802,9 +963,8 @@ func (r *AWSMachineReconciler) requeueAWSMachinesForUnpausedCluster(o handler.Ma
 }
 
 func (r *AWSMachineReconciler) requestsForCluster(log logr.Logger, namespace, name string) []ctrl.Request {
-	labels := map[string]string{clusterv1.ClusterLabelName: name}
-	machineList := &clusterv1.MachineList{}
-	if err := r.Client.List(context.TODO(), machineList, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+	machineList, err := r.listClusterMachines(namespace, name)
+	if err != nil {
 		log.Error(err, "Failed to get owned Machines, skipping mapping.")
 		return nil
 	}
@@ -827,6 +987,16 @@ func (r *AWSMachineReconciler) requestsForCluster(log logr.Logger, namespace, na
 	return result
 }
 
+// listClusterMachines returns all the Machines that belong to the given cluster.
+func (r *AWSMachineReconciler) listClusterMachines(namespace, name string) (*clusterv1.MachineList, error) {
+	labels := map[string]string{clusterv1.ClusterLabelName: name}
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(context.TODO(), machineList, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	return machineList, nil
+}
+
 func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log logr.Logger, cluster *clusterv1.Cluster, awsMachine *infrav1.AWSMachine) (scope.EC2Scope, error) {
 	var clusterScope *scope.ClusterScope
 	var managedControlPlaneScope *scope.ManagedControlPlaneScope
@@ -226,7 +226,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 		})
 
 		When("there's a provider ID", func() {
-			id := "aws:////myMachine"
+			id := "aws:///us-east-1a/i-0123456789abcdef0"
 			BeforeEach(func() {
 				_, err := noderefutil.NewProviderID(id)
 				Expect(err).To(BeNil())
@@ -236,7 +236,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 
 			It("it should look up by provider ID when one exists", func() {
 				expectedErr := errors.New("no connection available ")
-				ec2Svc.EXPECT().InstanceIfExists(PointsTo("myMachine")).Return(nil, expectedErr)
+				ec2Svc.EXPECT().InstanceIfExists(PointsTo("i-0123456789abcdef0")).Return(nil, expectedErr)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
 				Expect(errors.Cause(err)).To(MatchError(expectedErr))
@@ -258,7 +258,8 @@ var _ = Describe("AWSMachineReconciler", func() {
 			var instance *infrav1.Instance
 			BeforeEach(func() {
 				instance = &infrav1.Instance{
-					ID: "myMachine",
+					ID:               "i-0123456789abcdef0",
+					AvailabilityZone: "us-east-1a",
 				}
 				instance.State = infrav1.InstanceStatePending
 
@@ -275,7 +276,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				It("should set attributes after creating an instance", func() {
 					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
-					Expect(ms.AWSMachine.Spec.ProviderID).To(PointTo(Equal("aws:////myMachine")))
+					Expect(ms.AWSMachine.Spec.ProviderID).To(PointTo(Equal("aws:///us-east-1a/i-0123456789abcdef0")))
 				})
 
 				Context("with captured logging", func() {
@@ -359,7 +360,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 					cs.AWSCluster.Spec.AdditionalTags = infrav1.Tags{"colour": "lavender"}
 
 					ec2Svc.EXPECT().UpdateResourceTags(
-						PointsTo("myMachine"),
+						PointsTo("i-0123456789abcdef0"),
 						map[string]string{
 							"kind":   "alicorn",
 							"colour": "lavender",
@@ -446,8 +447,9 @@ var _ = Describe("AWSMachineReconciler", func() {
 		When("creating EC2 instances", func() {
 			It("should leverage AWS Secrets Manager", func() {
 				instance = &infrav1.Instance{
-					ID:    "myMachine",
-					State: infrav1.InstanceStatePending,
+					ID:               "i-0123456789abcdef0",
+					AvailabilityZone: "us-east-1a",
+					State:            infrav1.InstanceStatePending,
 				}
 				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil).AnyTimes()
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
@@ -466,7 +468,8 @@ var _ = Describe("AWSMachineReconciler", func() {
 		When("there's a node ref and a secret ARN", func() {
 			BeforeEach(func() {
 				instance = &infrav1.Instance{
-					ID: "myMachine",
+					ID:               "i-0123456789abcdef0",
+					AvailabilityZone: "us-east-1a",
 				}
 
 				ms.Machine.Status.NodeRef = &corev1.ObjectReference{
@@ -502,21 +505,22 @@ var _ = Describe("AWSMachineReconciler", func() {
 				instance.State = infrav1.InstanceStateRunning
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
 				ec2Svc.EXPECT().TerminateInstanceAndWait(gomock.Any()).Return(nil).AnyTimes()
-				_, _ = reconciler.reconcileDelete(ms, cs, cs, cs)
+				_, _ = reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			})
 
 			It("should delete the secret if the AWSMachine is in a failure condition", func() {
 				ms.AWSMachine.Status.FailureReason = capierrors.MachineStatusErrorPtr(capierrors.UpdateMachineError)
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
 				ec2Svc.EXPECT().TerminateInstanceAndWait(gomock.Any()).Return(nil).AnyTimes()
-				_, _ = reconciler.reconcileDelete(ms, cs, cs, cs)
+				_, _ = reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			})
 		})
 
 		When("there's only a secret ARN and no node ref", func() {
 			BeforeEach(func() {
 				instance = &infrav1.Instance{
-					ID: "myMachine",
+					ID:               "i-0123456789abcdef0",
+					AvailabilityZone: "us-east-1a",
 				}
 				ms.AWSMachine.Spec.CloudInit = infrav1.CloudInit{
 					SecretPrefix:         "secret",
@@ -545,14 +549,14 @@ var _ = Describe("AWSMachineReconciler", func() {
 				instance.State = infrav1.InstanceStateRunning
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
 				ec2Svc.EXPECT().TerminateInstanceAndWait(gomock.Any()).Return(nil).AnyTimes()
-				_, _ = reconciler.reconcileDelete(ms, cs, cs, cs)
+				_, _ = reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			})
 
 			It("should delete the secret if the AWSMachine is in a failure condition", func() {
 				ms.AWSMachine.Status.FailureReason = capierrors.MachineStatusErrorPtr(capierrors.UpdateMachineError)
 				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
 				ec2Svc.EXPECT().TerminateInstanceAndWait(gomock.Any()).Return(nil).AnyTimes()
-				_, _ = reconciler.reconcileDelete(ms, cs, cs, cs)
+				_, _ = reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			})
 		})
 
@@ -571,7 +575,8 @@ var _ = Describe("AWSMachineReconciler", func() {
 
 			It("should update prefix and count on successful creation", func() {
 				instance = &infrav1.Instance{
-					ID: "myMachine",
+					ID:               "i-0123456789abcdef0",
+					AvailabilityZone: "us-east-1a",
 				}
 				instance.State = infrav1.InstanceStatePending
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
@@ -601,7 +606,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 			expectedErr := errors.New("no connection available ")
 			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, expectedErr).AnyTimes()
 
-			_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+			_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			Expect(errors.Cause(err)).To(MatchError(expectedErr))
 		})
 
@@ -611,7 +616,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 			buf := new(bytes.Buffer)
 			klog.SetOutput(buf)
 
-			_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+			_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			Expect(err).To(BeNil())
 			Expect(buf.String()).To(ContainSubstring("Unable to locate EC2 instance by ID or tags"))
 			Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
@@ -626,7 +631,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 			buf := new(bytes.Buffer)
 			klog.SetOutput(buf)
 
-			_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+			_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			Expect(err).To(BeNil())
 			Expect(buf.String()).To(ContainSubstring("EC2 instance is shutting down or already terminated"))
 			Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
@@ -640,7 +645,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 			buf := new(bytes.Buffer)
 			klog.SetOutput(buf)
 
-			_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+			_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 			Expect(err).To(BeNil())
 			Expect(buf.String()).To(ContainSubstring("EC2 instance is shutting down or already terminated"))
 			Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
@@ -660,7 +665,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				buf := new(bytes.Buffer)
 				klog.SetOutput(buf)
 
-				_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+				_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 				Expect(errors.Cause(err)).To(MatchError(expected))
 				Expect(buf.String()).To(ContainSubstring("Terminating EC2 instance"))
 				Eventually(recorder.Events).Should(Receive(ContainSubstring("FailedTerminate")))
@@ -683,7 +688,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 						expected := errors.New("can't reach AWS to list security groups")
 						ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return(nil, expected)
 
-						_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+						_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 						Expect(errors.Cause(err)).To(MatchError(expected))
 					})
 
@@ -692,7 +697,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 						ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg0", "sg1"}, nil)
 						ec2Svc.EXPECT().DetachSecurityGroupsFromNetworkInterface(gomock.Any(), gomock.Any()).Return(expected)
 
-						_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+						_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 						Expect(errors.Cause(err)).To(MatchError(expected))
 					})
 
@@ -702,13 +707,13 @@ var _ = Describe("AWSMachineReconciler", func() {
 						ec2Svc.EXPECT().DetachSecurityGroupsFromNetworkInterface(groups, "eth0").Return(nil)
 						ec2Svc.EXPECT().DetachSecurityGroupsFromNetworkInterface(groups, "eth1").Return(nil)
 
-						_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+						_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 						Expect(err).To(BeNil())
 					})
 				})
 
 				It("should remove security groups", func() {
-					_, err := reconciler.reconcileDelete(ms, cs, cs, cs)
+					_, err := reconciler.reconcileDelete(context.Background(), ms, cs, cs, cs)
 					Expect(err).To(BeNil())
 					Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
 				})
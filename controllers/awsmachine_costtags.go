@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// CostAllocationTagPolicy maps the tag keys that drive cost allocation, e.g. through AWS Cost
+// Categories, to their allowed values. Every instance is expected to carry all of the keys. A key
+// without allowed values accepts any non-empty value.
+type CostAllocationTagPolicy map[string][]string
+
+// ParseCostAllocationTagPolicy parses a policy of the form key1=value1|value2,key2, where the
+// allowed values of each key are separated by "|" and may be omitted.
+func ParseCostAllocationTagPolicy(s string) (CostAllocationTagPolicy, error) {
+	policy := CostAllocationTagPolicy{}
+	if strings.TrimSpace(s) == "" {
+		return policy, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if key == "" {
+			return nil, errors.Errorf("invalid cost allocation tag policy entry %q: tag key must not be empty", entry)
+		}
+		if _, ok := policy[key]; ok {
+			return nil, errors.Errorf("invalid cost allocation tag policy: tag key %q is listed more than once", key)
+		}
+
+		values := []string{}
+		if len(parts) == 2 {
+			for _, value := range strings.Split(parts[1], "|") {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+		}
+		policy[key] = values
+	}
+
+	return policy, nil
+}
+
+// Apply checks tags against the policy and returns a copy of the tags in which values matching an
+// allowed value up to case are replaced with the allowed value, along with the sorted keys that are
+// missing and the sorted keys whose values are not allowed.
+func (p CostAllocationTagPolicy) Apply(tags infrav1.Tags) (result infrav1.Tags, missing, invalid []string) {
+	result = tags.DeepCopy()
+	if result == nil {
+		result = infrav1.Tags{}
+	}
+
+	for key, allowed := range p {
+		value, ok := result[key]
+		if !ok || value == "" {
+			missing = append(missing, key)
+			continue
+		}
+		if len(allowed) == 0 {
+			continue
+		}
+
+		canonical, ok := allowedValue(allowed, value)
+		if !ok {
+			invalid = append(invalid, key)
+			continue
+		}
+		result[key] = canonical
+	}
+
+	sort.Strings(missing)
+	sort.Strings(invalid)
+	return result, missing, invalid
+}
+
+func allowedValue(allowed []string, value string) (string, bool) {
+	for _, a := range allowed {
+		if strings.EqualFold(a, value) {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// costAllocationTags applies the cost allocation tag policy to the tags of the machine's instance,
+// reporting missing or disallowed tags on the CostAllocationTagsValid condition without blocking
+// the reconciliation, and returns the tags to apply to the instance.
+func (r *AWSMachineReconciler) costAllocationTags(machineScope *scope.MachineScope, tags infrav1.Tags) infrav1.Tags {
+	if len(r.CostAllocationTags) == 0 {
+		return tags
+	}
+
+	result, missing, invalid := r.CostAllocationTags.Apply(tags)
+	switch {
+	case len(missing) > 0:
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.CostAllocationTagsValidCondition, infrav1.CostAllocationTagsMissingReason, clusterv1.ConditionSeverityWarning,
+			"Missing cost allocation tags: %s", strings.Join(missing, ", "))
+	case len(invalid) > 0:
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.CostAllocationTagsValidCondition, infrav1.CostAllocationTagsInvalidReason, clusterv1.ConditionSeverityWarning,
+			"Cost allocation tags with values that are not allowed: %s", strings.Join(invalid, ", "))
+	default:
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.CostAllocationTagsValidCondition)
+	}
+
+	return result
+}
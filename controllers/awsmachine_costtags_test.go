@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+func TestParseCostAllocationTagPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	policy, err := ParseCostAllocationTagPolicy("cost-center=eng|ops, team")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(policy).To(Equal(CostAllocationTagPolicy{
+		"cost-center": {"eng", "ops"},
+		"team":        {},
+	}))
+
+	policy, err = ParseCostAllocationTagPolicy("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(policy).To(BeEmpty())
+
+	_, err = ParseCostAllocationTagPolicy("=eng")
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = ParseCostAllocationTagPolicy("team,team=a")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCostAllocationTagPolicyApply(t *testing.T) {
+	policy := CostAllocationTagPolicy{
+		"cost-center": {"eng", "ops"},
+		"team":        {},
+	}
+
+	tests := []struct {
+		name            string
+		tags            infrav1.Tags
+		expectedTags    infrav1.Tags
+		expectedMissing []string
+		expectedInvalid []string
+	}{
+		{
+			name:         "all tags present with allowed values",
+			tags:         infrav1.Tags{"cost-center": "eng", "team": "platform", "other": "x"},
+			expectedTags: infrav1.Tags{"cost-center": "eng", "team": "platform", "other": "x"},
+		},
+		{
+			name:         "values are corrected to the allowed spelling",
+			tags:         infrav1.Tags{"cost-center": "OPS", "team": "platform"},
+			expectedTags: infrav1.Tags{"cost-center": "ops", "team": "platform"},
+		},
+		{
+			name:            "missing and empty tags",
+			tags:            infrav1.Tags{"team": ""},
+			expectedTags:    infrav1.Tags{"team": ""},
+			expectedMissing: []string{"cost-center", "team"},
+		},
+		{
+			name:            "disallowed values are kept",
+			tags:            infrav1.Tags{"cost-center": "marketing", "team": "platform"},
+			expectedTags:    infrav1.Tags{"cost-center": "marketing", "team": "platform"},
+			expectedInvalid: []string{"cost-center"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tags, missing, invalid := policy.Apply(tt.tags)
+			g.Expect(tags).To(Equal(tt.expectedTags))
+			g.Expect(missing).To(Equal(tt.expectedMissing))
+			g.Expect(invalid).To(Equal(tt.expectedInvalid))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/healthreport"
+)
+
+// instanceHealthTransition derives the health transition to report from the state and, for running
+// instances, the EC2 status checks of the machine's instance. It returns an empty transition for
+// states that are not reported, such as pending or stopped.
+func instanceHealthTransition(ec2svc services.EC2MachineInterface, instance *infrav1.Instance) (healthreport.Transition, error) {
+	switch instance.State {
+	case infrav1.InstanceStateRunning:
+		impaired, err := ec2svc.InstanceStatusChecksImpaired(instance.ID)
+		if err != nil {
+			return "", err
+		}
+		if impaired {
+			return healthreport.TransitionUnhealthy, nil
+		}
+		return healthreport.TransitionRunning, nil
+	case infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated:
+		return healthreport.TransitionTerminated, nil
+	}
+
+	return "", nil
+}
+
+// reconcileHealthReport reports the current health of the machine's instance to the cluster's
+// health reporting endpoint, if one is configured. Failures are only logged so that reporting
+// never holds up the reconciliation of the machine.
+func (r *AWSMachineReconciler) reconcileHealthReport(ctx context.Context, ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, instance *infrav1.Instance) {
+	if r.HealthReporter == nil || machineScope.HealthReporting() == nil {
+		return
+	}
+
+	transition, err := instanceHealthTransition(ec2svc, instance)
+	if err != nil {
+		machineScope.Error(err, "failed to determine instance health for reporting")
+		return
+	}
+	if transition == "" {
+		return
+	}
+
+	r.reportHealth(ctx, machineScope, transition, instance, "")
+}
+
+// reportHealth queues the transition for delivery to the cluster's health reporting endpoint unless
+// it is the last transition reported for the machine.
+func (r *AWSMachineReconciler) reportHealth(ctx context.Context, machineScope *scope.MachineScope, transition healthreport.Transition, instance *infrav1.Instance, message string) {
+	spec := machineScope.HealthReporting()
+	if r.HealthReporter == nil || spec == nil {
+		return
+	}
+	if r.machineAnnotation(machineScope.AWSMachine, infrav1.ReportedHealthAnnotation) == string(transition) {
+		return
+	}
+
+	authorization, err := machineScope.GetHealthReportingAuthorization(ctx)
+	if err != nil {
+		machineScope.Error(err, "failed to get health reporting authorization")
+		return
+	}
+
+	event := healthreport.Event{
+		Cluster:    machineScope.Cluster.Name,
+		Namespace:  machineScope.Namespace(),
+		Machine:    machineScope.Name(),
+		Transition: transition,
+		Message:    message,
+		Time:       time.Now().UTC(),
+	}
+	if instance != nil {
+		event.InstanceID = instance.ID
+		event.InstanceState = string(instance.State)
+		event.AvailabilityZone = instance.AvailabilityZone
+	}
+
+	if r.HealthReporter.Report(healthreport.Endpoint{URL: spec.URL, Authorization: authorization}, event) {
+		r.updateMachineAnnotation(machineScope.AWSMachine, infrav1.ReportedHealthAnnotation, string(transition))
+	}
+}
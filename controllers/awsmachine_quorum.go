@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// quorumRequeueAfter is how long to wait before checking again whether a held
+// control plane instance can be terminated.
+const quorumRequeueAfter = 20 * time.Second
+
+// reconcileTerminationQuorum holds back the termination of a control plane instance while another
+// control plane instance is being terminated, or while terminating it would leave the control plane
+// without etcd quorum. A non-zero result is returned while the termination is held.
+func (r *AWSMachineReconciler) reconcileTerminationQuorum(machineScope *scope.MachineScope) (ctrl.Result, error) {
+	if !machineScope.IsControlPlane() || r.SkipQuorumCheck {
+		return ctrl.Result{}, nil
+	}
+
+	if _, ok := machineScope.AWSMachine.Annotations[infrav1.SkipQuorumCheckAnnotation]; ok {
+		machineScope.Info("Skipping control plane quorum check", "annotation", infrav1.SkipQuorumCheckAnnotation)
+		return ctrl.Result{}, nil
+	}
+
+	// The whole cluster is going away, so there is no quorum left to protect.
+	if !machineScope.Cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	machines, err := r.listClusterMachines(machineScope.Machine.Namespace, machineScope.Cluster.Name)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list control plane machines")
+	}
+
+	reason := terminationBlockedReason(machineScope.Machine, machines.Items)
+	if reason == "" {
+		return ctrl.Result{}, nil
+	}
+
+	machineScope.Info("Waiting to terminate control plane instance", "reason", reason)
+	if !conditions.IsFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition) ||
+		conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.WaitingForQuorumReason {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, infrav1.WaitingForQuorumReason, "Waiting to terminate instance: %s", reason)
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForQuorumReason, clusterv1.ConditionSeverityWarning, reason)
+
+	return ctrl.Result{RequeueAfter: quorumRequeueAfter}, nil
+}
+
+// terminationBlockedReason returns why the instance of the given control plane machine cannot be
+// terminated yet, or an empty string if it can. Only machines that have joined the cluster, i.e. that
+// have a node reference, are counted as etcd members.
+func terminationBlockedReason(machine *clusterv1.Machine, machines []clusterv1.Machine) string {
+	// A machine that never joined the cluster does not hold an etcd member.
+	if machine.Status.NodeRef == nil {
+		return ""
+	}
+
+	members, healthy := 1, 0
+	for i := range machines {
+		m := &machines[i]
+		if m.Name == machine.Name || !util.IsControlPlaneMachine(m) || m.Status.NodeRef == nil {
+			continue
+		}
+		members++
+
+		if m.DeletionTimestamp.IsZero() {
+			if m.Status.FailureReason == nil && m.Status.FailureMessage == nil {
+				healthy++
+			}
+			continue
+		}
+
+		if terminatesBefore(m, machine) {
+			return fmt.Sprintf("control plane machine %q is being deleted", m.Name)
+		}
+	}
+
+	if members == 1 {
+		return "machine is the last member of the control plane"
+	}
+
+	if quorum := (members-1)/2 + 1; healthy < quorum {
+		return fmt.Sprintf("terminating the instance would leave %d of the %d remaining control plane members healthy, %d are required for quorum", healthy, members-1, quorum)
+	}
+
+	return ""
+}
+
+// terminatesBefore orders deleting machines by deletion time and then by name, so that exactly one
+// of them is allowed to proceed at a time.
+func terminatesBefore(a, b *clusterv1.Machine) bool {
+	if !a.DeletionTimestamp.Equal(b.DeletionTimestamp) {
+		return a.DeletionTimestamp.Before(b.DeletionTimestamp)
+	}
+	return a.Name < b.Name
+}
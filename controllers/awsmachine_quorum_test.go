@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+)
+
+func newControlPlaneMember(name string, deletedAt *time.Time) clusterv1.Machine {
+	m := newMachine("my-cluster", name)
+	m.Labels[clusterv1.MachineControlPlaneLabelName] = ""
+	m.Status.NodeRef = &corev1.ObjectReference{Name: name}
+	if deletedAt != nil {
+		m.DeletionTimestamp = &metav1.Time{Time: *deletedAt}
+	}
+	return *m
+}
+
+func TestTerminationBlockedReason(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+	failure := capierrors.UpdateMachineError
+
+	tests := []struct {
+		name     string
+		machine  func() clusterv1.Machine
+		others   func() []clusterv1.Machine
+		expected bool
+	}{
+		{
+			name:    "allows termination when the remaining members keep quorum",
+			machine: func() clusterv1.Machine { return newControlPlaneMember("cp-0", &now) },
+			others: func() []clusterv1.Machine {
+				return []clusterv1.Machine{newControlPlaneMember("cp-1", nil), newControlPlaneMember("cp-2", nil)}
+			},
+		},
+		{
+			name:    "allows termination of a machine that never joined",
+			machine: func() clusterv1.Machine { m := newControlPlaneMember("cp-0", &now); m.Status.NodeRef = nil; return m },
+			others:  func() []clusterv1.Machine { return nil },
+		},
+		{
+			name:    "ignores worker machines",
+			machine: func() clusterv1.Machine { return newControlPlaneMember("cp-0", &now) },
+			others: func() []clusterv1.Machine {
+				w := *newMachine("my-cluster", "worker-0")
+				w.DeletionTimestamp = &metav1.Time{Time: earlier}
+				w.Status.NodeRef = &corev1.ObjectReference{Name: "worker-0"}
+				return []clusterv1.Machine{newControlPlaneMember("cp-1", nil), newControlPlaneMember("cp-2", nil), w}
+			},
+		},
+		{
+			name:    "holds termination while an earlier control plane machine is deleting",
+			machine: func() clusterv1.Machine { return newControlPlaneMember("cp-0", &now) },
+			others: func() []clusterv1.Machine {
+				return []clusterv1.Machine{newControlPlaneMember("cp-1", &earlier), newControlPlaneMember("cp-2", nil), newControlPlaneMember("cp-3", nil), newControlPlaneMember("cp-4", nil)}
+			},
+			expected: true,
+		},
+		{
+			name:    "allows the first of several deleting machines to proceed",
+			machine: func() clusterv1.Machine { return newControlPlaneMember("cp-0", &now) },
+			others: func() []clusterv1.Machine {
+				return []clusterv1.Machine{newControlPlaneMember("cp-1", &now), newControlPlaneMember("cp-2", nil), newControlPlaneMember("cp-3", nil), newControlPlaneMember("cp-4", nil)}
+			},
+		},
+		{
+			name:    "holds termination when the remaining members would lose quorum",
+			machine: func() clusterv1.Machine { return newControlPlaneMember("cp-0", &now) },
+			others: func() []clusterv1.Machine {
+				failed := newControlPlaneMember("cp-1", nil)
+				failed.Status.FailureReason = &failure
+				return []clusterv1.Machine{failed, newControlPlaneMember("cp-2", nil)}
+			},
+			expected: true,
+		},
+		{
+			name:     "holds termination of the last control plane member",
+			machine:  func() clusterv1.Machine { return newControlPlaneMember("cp-0", &now) },
+			others:   func() []clusterv1.Machine { return nil },
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := tc.machine()
+			machines := append(tc.others(), machine)
+			reason := terminationBlockedReason(&machine, machines)
+			if tc.expected {
+				g.Expect(reason).NotTo(BeEmpty())
+			} else {
+				g.Expect(reason).To(BeEmpty())
+			}
+		})
+	}
+}
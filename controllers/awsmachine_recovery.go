@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// InstanceRecoveryPolicy configures when a machine whose instance keeps failing its EC2 status
+// checks is marked as failed, so that it can be replaced in another availability zone.
+type InstanceRecoveryPolicy struct {
+	// Threshold is how long the status checks may stay impaired before the machine is marked as
+	// failed. The policy is disabled when it is zero.
+	Threshold time.Duration
+
+	// AvoidanceWindow is how long the availability zone of a failed machine should be avoided.
+	AvoidanceWindow time.Duration
+}
+
+// Enabled returns true if machines with impaired instances should be marked as failed.
+func (p InstanceRecoveryPolicy) Enabled() bool {
+	return p.Threshold > 0
+}
+
+// reconcileInstanceRecovery tracks how long the EC2 status checks of the machine's instance have
+// been impaired and marks the machine as failed once the recovery policy threshold is exceeded,
+// recording the impaired availability zone on the AWSMachine.
+func (r *AWSMachineReconciler) reconcileInstanceRecovery(ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, instance *infrav1.Instance) (ctrl.Result, error) {
+	impaired, err := ec2svc.InstanceStatusChecksImpaired(instance.ID)
+	if err != nil {
+		machineScope.Error(err, "failed to get instance status checks")
+		return ctrl.Result{}, err
+	}
+
+	if !impaired {
+		r.removeMachineAnnotation(machineScope.AWSMachine, infrav1.StatusChecksImpairedSinceAnnotation)
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now().UTC()
+	since, err := time.Parse(time.RFC3339, r.machineAnnotation(machineScope.AWSMachine, infrav1.StatusChecksImpairedSinceAnnotation))
+	if err != nil {
+		since = now
+		r.updateMachineAnnotation(machineScope.AWSMachine, infrav1.StatusChecksImpairedSinceAnnotation, since.Format(time.RFC3339))
+	}
+
+	if elapsed := now.Sub(since); elapsed < r.RecoveryPolicy.Threshold {
+		machineScope.Info("EC2 instance status checks are impaired", "instance-id", instance.ID, "since", since)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStatusChecksImpairedReason, clusterv1.ConditionSeverityWarning,
+			"EC2 status checks impaired since %s", since.Format(time.RFC3339))
+		return ctrl.Result{RequeueAfter: r.RecoveryPolicy.Threshold - elapsed}, nil
+	}
+
+	r.updateMachineAnnotation(machineScope.AWSMachine, infrav1.ImpairedAvailabilityZoneAnnotation, instance.AvailabilityZone)
+	r.updateMachineAnnotation(machineScope.AWSMachine, infrav1.ImpairedAvailabilityZoneUntilAnnotation, now.Add(r.RecoveryPolicy.AvoidanceWindow).Format(time.RFC3339))
+
+	err = errors.Errorf("EC2 instance %q status checks have been impaired for more than %s in availability zone %q", instance.ID, r.RecoveryPolicy.Threshold, instance.AvailabilityZone)
+	machineScope.SetFailureReason(capierrors.UpdateMachineError)
+	machineScope.SetFailureMessage(err)
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStatusChecksImpairedReason, clusterv1.ConditionSeverityError, err.Error())
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "InstanceImpaired", err.Error())
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// rootVolumeConversionRequeue is how often the conversion of a root volume is checked on.
+const rootVolumeConversionRequeue = time.Minute
+
+// reconcileRootVolumeType converts the gp2 root volume of the machine's instance to gp3 in place when
+// the spec asks for a gp3 root volume, which migrates existing machines without replacing them.
+// Volumes are never converted in any other direction.
+func (r *AWSMachineReconciler) reconcileRootVolumeType(ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, instance *infrav1.Instance) (ctrl.Result, error) {
+	rootVolume := machineScope.AWSMachine.Spec.RootVolume
+	if rootVolume == nil || rootVolume.Type != infrav1.VolumeTypeGP3 {
+		return ctrl.Result{}, nil
+	}
+
+	// Instances launched with a gp3 root volume, or done converting it, need no further calls.
+	if status := machineScope.AWSMachine.Status.RootVolume; status != nil && status.Type == infrav1.VolumeTypeGP3 &&
+		(status.ModificationState == "" || status.ModificationState == infrav1.VolumeModificationStateCompleted) {
+		return ctrl.Result{}, nil
+	}
+
+	volume, err := ec2svc.GetRootVolume(instance.ID)
+	if err != nil {
+		machineScope.Error(err, "failed to get root volume")
+		return ctrl.Result{}, err
+	}
+	if volume == nil {
+		return ctrl.Result{}, nil
+	}
+	machineScope.SetRootVolume(volume)
+
+	switch {
+	case volume.ModificationState == infrav1.VolumeModificationStateModifying || volume.ModificationState == infrav1.VolumeModificationStateOptimizing:
+		// The volume reports its new type while it is being optimized, which can take hours.
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.RootVolumeTypeUpToDateCondition, infrav1.RootVolumeTypeConvertingReason, clusterv1.ConditionSeverityInfo,
+			"root volume %q is being converted to %s", volume.ID, infrav1.VolumeTypeGP3)
+		return ctrl.Result{RequeueAfter: rootVolumeConversionRequeue}, nil
+	case volume.Type == infrav1.VolumeTypeGP3:
+		if conditions.Has(machineScope.AWSMachine, infrav1.RootVolumeTypeUpToDateCondition) {
+			conditions.MarkTrue(machineScope.AWSMachine, infrav1.RootVolumeTypeUpToDateCondition)
+		}
+		return ctrl.Result{}, nil
+	case volume.Type != infrav1.VolumeTypeGP2:
+		return ctrl.Result{}, nil
+	}
+
+	machineScope.Info("Converting root volume", "instance-id", instance.ID, "volume-id", volume.ID, "from", volume.Type, "to", infrav1.VolumeTypeGP3)
+	if err := ec2svc.ModifyVolumeType(volume.ID, infrav1.VolumeTypeGP3); err != nil {
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.RootVolumeTypeUpToDateCondition, infrav1.RootVolumeTypeConversionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedRootVolumeConversion", "Failed to convert root volume %q to %s: %v", volume.ID, infrav1.VolumeTypeGP3, err)
+		return ctrl.Result{}, err
+	}
+	volume.ModificationState = infrav1.VolumeModificationStateModifying
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.RootVolumeTypeUpToDateCondition, infrav1.RootVolumeTypeConvertingReason, clusterv1.ConditionSeverityInfo,
+		"root volume %q is being converted to %s", volume.ID, infrav1.VolumeTypeGP3)
+	return ctrl.Result{RequeueAfter: rootVolumeConversionRequeue}, nil
+}
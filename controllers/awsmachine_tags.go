@@ -17,7 +17,16 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
 )
 
@@ -29,6 +38,10 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	TagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-tags"
+
+	// VolumeTagsLastAppliedAnnotation is the key for the machine object annotation which tracks the
+	// tags applied to the EBS volumes of the machine's instance.
+	VolumeTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-volume-tags"
 )
 
 // Ensure that the tags of the machine are correct
@@ -62,6 +75,102 @@ func (r *AWSMachineReconciler) ensureTags(svc service.EC2MachineInterface, machi
 	return changed, nil
 }
 
+// ensureVolumeTags makes sure the EBS volumes of the instance carry the volume tags of the machine,
+// and no longer carry the ones removed since they were last applied. It returns true if any tags
+// were changed.
+func (r *AWSMachineReconciler) ensureVolumeTags(svc service.EC2MachineInterface, machine *infrav1.AWSMachine, volumeIDs []string, volumeTags map[string]string) (bool, error) {
+	annotation, err := r.machineAnnotationJSON(machine, VolumeTagsLastAppliedAnnotation)
+	if err != nil {
+		return false, err
+	}
+
+	changed, created, deleted, newAnnotation := r.tagsChanged(annotation, volumeTags)
+	// Tags are only recorded as applied once the instance reports volumes to apply them to.
+	if !changed || len(volumeIDs) == 0 {
+		return false, nil
+	}
+
+	for i := range volumeIDs {
+		if err := svc.UpdateResourceTags(&volumeIDs[i], created, deleted); err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.updateMachineAnnotationJSON(machine, VolumeTagsLastAppliedAnnotation, newAnnotation); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// instanceTags returns the tags that should be applied to the machine's instance: the node label
+// tags configured by NodeLabelTags, overridden by the additional tags of the machine and cluster.
+func (r *AWSMachineReconciler) instanceTags(machineScope *scope.MachineScope) infrav1.Tags {
+	tags := infrav1.Tags{}
+
+	if len(machineScope.AWSMachine.Spec.NodeLabelTags) > 0 {
+		labelTags, err := r.nodeLabelTags(machineScope)
+		if err != nil {
+			// Keep the tags applied from node labels last time rather than removing them while the
+			// workload cluster cannot be reached.
+			machineScope.Info("Failed to read node labels, keeping previously applied node label tags", "error", err.Error())
+			labelTags = r.lastAppliedNodeLabelTags(machineScope.AWSMachine)
+		}
+		tags.Merge(labelTags)
+	}
+
+	tags.Merge(machineScope.AdditionalTags())
+	return tags
+}
+
+// nodeLabelTags reads the labels of the machine's node from the workload cluster and maps the ones
+// configured in NodeLabelTags to instance tags.
+func (r *AWSMachineReconciler) nodeLabelTags(machineScope *scope.MachineScope) (infrav1.Tags, error) {
+	tags := infrav1.Tags{}
+
+	// The node has not joined the cluster yet, so there are no labels to apply.
+	if machineScope.Machine.Status.NodeRef == nil {
+		return tags, nil
+	}
+
+	ctx := context.TODO()
+	workloadClient, err := remote.NewClusterClient(ctx, r.Client, util.ObjectKey(machineScope.Cluster), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create workload cluster client")
+	}
+
+	node := &corev1.Node{}
+	if err := workloadClient.Get(ctx, client.ObjectKey{Name: machineScope.Machine.Status.NodeRef.Name}, node); err != nil {
+		return nil, errors.Wrapf(err, "failed to get node %q", machineScope.Machine.Status.NodeRef.Name)
+	}
+
+	for label, tag := range machineScope.AWSMachine.Spec.NodeLabelTags {
+		if value, ok := node.Labels[label]; ok {
+			tags[tag] = value
+		}
+	}
+
+	return tags, nil
+}
+
+// lastAppliedNodeLabelTags returns the node label tags recorded in the last applied tags annotation.
+func (r *AWSMachineReconciler) lastAppliedNodeLabelTags(machine *infrav1.AWSMachine) infrav1.Tags {
+	tags := infrav1.Tags{}
+
+	annotation, err := r.machineAnnotationJSON(machine, TagsLastAppliedAnnotation)
+	if err != nil {
+		return tags
+	}
+
+	for _, tag := range machine.Spec.NodeLabelTags {
+		if value, ok := annotation[tag].(string); ok {
+			tags[tag] = value
+		}
+	}
+
+	return tags
+}
+
 // tagsChanged determines which tags to delete and which to add.
 func (r *AWSMachineReconciler) tagsChanged(annotation map[string]interface{}, src map[string]string) (bool, map[string]string, map[string]string, map[string]interface{}) {
 	// Bool tracking if we found any changed state.
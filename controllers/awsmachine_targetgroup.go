@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb"
+)
+
+// reconcileMonitoringTargetGroup registers the instance with the machine's monitoring target group
+// while it is running, and deregisters it as soon as it stops running or the machine is deleted, so
+// that the target group only ever contains instances that can be scraped.
+func (r *AWSMachineReconciler) reconcileMonitoringTargetGroup(machineScope *scope.MachineScope, elbScope scope.ELBScope, i *infrav1.Instance) error {
+	tg := machineScope.AWSMachine.Spec.MonitoringTargetGroup
+	if tg == nil {
+		return nil
+	}
+
+	elbsvc := elb.NewService(elbScope)
+
+	registered, err := elbsvc.InstanceIsRegisteredWithTargetGroup(i, tg)
+	if err != nil {
+		return errors.Wrapf(err, "could not determine whether instance %q is registered with monitoring target group", i.ID)
+	}
+
+	if !machineScope.AWSMachine.DeletionTimestamp.IsZero() || !machineScope.InstanceIsRunning() {
+		if !registered {
+			return nil
+		}
+
+		if err := elbsvc.DeregisterInstanceFromTargetGroup(i, tg); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachMonitoringTargetGroup",
+				"Failed to deregister instance %q from monitoring target group %q: %v", i.ID, tg.ARN, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.MonitoringTargetGroupRegisteredCondition, infrav1.MonitoringTargetGroupDeregistrationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDetachMonitoringTargetGroup",
+			"Instance %q is deregistered from monitoring target group %q", i.ID, tg.ARN)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.MonitoringTargetGroupRegisteredCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	if registered {
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.MonitoringTargetGroupRegisteredCondition)
+		return nil
+	}
+
+	if err := elbsvc.RegisterInstanceWithTargetGroup(i, tg); err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachMonitoringTargetGroup",
+			"Failed to register instance %q with monitoring target group %q: %v", i.ID, tg.ARN, err)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.MonitoringTargetGroupRegisteredCondition, infrav1.MonitoringTargetGroupRegistrationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulAttachMonitoringTargetGroup",
+		"Instance %q is registered with monitoring target group %q", i.ID, tg.ARN)
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.MonitoringTargetGroupRegisteredCondition)
+	return nil
+}
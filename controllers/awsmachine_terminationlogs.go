@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+)
+
+// defaultTerminationLogUploadTimeout is how long to wait for the journal of an instance to be uploaded
+// before terminating it, unless the AWSMachine sets another timeout.
+const defaultTerminationLogUploadTimeout = 2 * time.Minute
+
+// uploadTerminationLogs uploads the journal of the machine's instance to S3 before the instance is
+// terminated, if the AWSMachine asks for it. The upload is best effort: a failed or timed out upload is
+// reported as an event and never blocks the termination.
+func (r *AWSMachineReconciler) uploadTerminationLogs(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, instance *infrav1.Instance) {
+	upload := machineScope.TerminationLogUpload()
+	// The logs were already uploaded by an earlier attempt to terminate the instance.
+	if upload == nil || machineScope.AWSMachine.Status.TerminationLogLocation != "" {
+		return
+	}
+
+	if instance.State != infrav1.InstanceStateRunning {
+		machineScope.V(2).Info("Skipping the log upload of an instance that is not running", "instance-id", instance.ID, "state", instance.State)
+		return
+	}
+
+	timeout := defaultTerminationLogUploadTimeout
+	if upload.Timeout != nil {
+		timeout = upload.Timeout.Duration
+	}
+
+	machineScope.Info("Uploading the logs of the EC2 instance before terminating it", "instance-id", instance.ID, "bucket", upload.Bucket)
+	location, err := r.getInstanceLogsService(clusterScope).UploadInstanceLogs(instance.ID, upload.Bucket, upload.KeyPrefix, timeout)
+	if err != nil {
+		machineScope.Error(err, "failed to upload the logs of the instance", "instance-id", instance.ID)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedUploadTerminationLogs",
+			"Failed to upload the logs of instance %q, terminating it anyway: %v", instance.ID, err)
+		return
+	}
+
+	machineScope.SetTerminationLogLocation(location)
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulUploadTerminationLogs", "Uploaded the logs of instance %q to %s", instance.ID, location)
+}
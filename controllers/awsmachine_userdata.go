@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
+)
+
+// userDataExtensions collects the additional node configuration from the machine and
+// cluster specs that needs to be merged into the machine's bootstrap data.
+func (r *AWSMachineReconciler) userDataExtensions(ctx context.Context, ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope) (*userdata.ExtensionsInput, error) {
+	input := &userdata.ExtensionsInput{}
+
+	trustedCAs, err := machineScope.GetAdditionalTrustedCAs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if trustedCAs != nil {
+		certs, err := userdata.ParseCACertificates(trustedCAs)
+		if err != nil {
+			return nil, err
+		}
+		input.TrustedCACertificates = certs
+	}
+
+	registryCredentials, err := machineScope.GetRegistryCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if registryCredentials != nil {
+		creds, err := userdata.ParseRegistryCredentials(registryCredentials)
+		if err != nil {
+			return nil, err
+		}
+		input.RegistryCredentials = creds
+	}
+
+	for _, mirror := range machineScope.RegistryMirrors() {
+		m := userdata.RegistryMirror{Registry: mirror.Registry, Endpoint: mirror.Endpoint}
+		if mirror.TLS != nil {
+			m.InsecureSkipVerify = mirror.TLS.InsecureSkipVerify
+		}
+		ca, err := machineScope.GetRegistryMirrorCA(ctx, mirror)
+		if err != nil {
+			return nil, err
+		}
+		if ca != nil {
+			certs, err := userdata.ParseCACertificates(ca)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid CA certificates of registry mirror %q", mirror.Endpoint)
+			}
+			m.CACertificates = certs
+		}
+		input.RegistryMirrors = append(input.RegistryMirrors, m)
+	}
+
+	if ntp := machineScope.NTP(); ntp != nil {
+		input.NTP = &userdata.NTP{
+			Servers:        ntp.Servers,
+			AmazonTimeSync: ntp.AmazonTimeSync == nil || *ntp.AmazonTimeSync,
+		}
+	}
+
+	if volume := machineScope.ContainerRuntimeVolume(); volume != nil {
+		mountPath := volume.MountPath
+		if mountPath == "" {
+			mountPath = infrav1.DefaultContainerRuntimeDataRoot
+		}
+		input.ContainerRuntimeVolume = &userdata.ContainerRuntimeVolume{Device: volume.DeviceName, MountPath: mountPath}
+	}
+
+	if machineScope.AWSMachine.Spec.LoadIPVSModules {
+		input.KernelModules = append(input.KernelModules, userdata.IPVSKernelModules...)
+	}
+
+	for _, sysctl := range machineScope.AWSMachine.Spec.Sysctls {
+		input.Sysctls = append(input.Sysctls, userdata.Sysctl{Name: sysctl.Name, Value: sysctl.Value})
+	}
+
+	if imageGC := machineScope.AWSMachine.Spec.ImageGC; imageGC != nil {
+		input.ImageGC = &userdata.ImageGC{
+			HighThresholdPercent: imageGC.HighThresholdPercent,
+			LowThresholdPercent:  imageGC.LowThresholdPercent,
+		}
+	}
+
+	if logRotation := machineScope.AWSMachine.Spec.ContainerLogRotation; logRotation != nil {
+		input.ContainerLogRotation = &userdata.ContainerLogRotation{
+			MaxSize:  logRotation.MaxSize,
+			MaxFiles: logRotation.MaxFiles,
+		}
+	}
+
+	if eviction := machineScope.AWSMachine.Spec.EvictionThresholds; eviction != nil {
+		input.EvictionThresholds = &userdata.EvictionThresholds{
+			Hard: evictionSignals(eviction.Hard),
+			Soft: evictionSignals(eviction.Soft),
+		}
+		if eviction.SoftGracePeriod != nil {
+			input.EvictionThresholds.SoftGracePeriod = eviction.SoftGracePeriod.Duration
+		}
+	}
+
+	input.NodeLabels = machineScope.AWSMachine.Spec.NodeLabels
+	for _, taint := range machineScope.AWSMachine.Spec.NodeTaints {
+		input.NodeTaints = append(input.NodeTaints, userdata.Taint{Key: taint.Key, Value: taint.Value, Effect: string(taint.Effect)})
+	}
+
+	if driver := machineScope.AWSMachine.Spec.NVIDIADriver; driver != nil {
+		install, err := r.shouldInstallNVIDIADriver(ec2svc, machineScope, driver)
+		if err != nil {
+			return nil, err
+		}
+		if install {
+			input.NVIDIADriverVersion = driver.Version
+		}
+	}
+
+	if bootConfig := machineScope.AdditionalBootConfig(); bootConfig != nil {
+		for _, unit := range bootConfig.SystemdUnits {
+			input.SystemdUnits = append(input.SystemdUnits, userdata.SystemdUnit{Name: unit.Name, Content: unit.Content})
+		}
+		for _, script := range bootConfig.Scripts {
+			input.BootScripts = append(input.BootScripts, userdata.BootScript{Name: script.Name, Content: script.Content})
+		}
+	}
+
+	return input, nil
+}
+
+func evictionSignals(signals *infrav1.EvictionSignals) *userdata.EvictionSignals {
+	if signals == nil {
+		return nil
+	}
+	return &userdata.EvictionSignals{
+		MemoryAvailable:  signals.MemoryAvailable,
+		NodeFSAvailable:  signals.NodeFSAvailable,
+		ImageFSAvailable: signals.ImageFSAvailable,
+	}
+}
+
+// shouldInstallNVIDIADriver returns true if the NVIDIA driver should be installed on the machine's
+// instance. Unless configured otherwise, it is only installed on instance types with NVIDIA GPUs.
+func (r *AWSMachineReconciler) shouldInstallNVIDIADriver(ec2svc services.EC2MachineInterface, machineScope *scope.MachineScope, driver *infrav1.NVIDIADriverOptions) (bool, error) {
+	switch driver.Install {
+	case infrav1.GPUDriverInstallNever:
+		return false, nil
+	case infrav1.GPUDriverInstallAlways:
+		return true, nil
+	}
+
+	hasGPUs, err := ec2svc.InstanceTypeHasNVIDIAGPUs(machineScope.InstanceType())
+	if err != nil {
+		return false, err
+	}
+	if !hasGPUs {
+		machineScope.V(2).Info("Instance type has no NVIDIA GPUs, skipping driver installation", "instance-type", machineScope.InstanceType())
+	}
+
+	return hasGPUs, nil
+}
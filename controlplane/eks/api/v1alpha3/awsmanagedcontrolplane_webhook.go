@@ -21,6 +21,7 @@ import (
 	"net"
 
 	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -91,6 +92,14 @@ func (r *AWSManagedControlPlane) ValidateCreate() error {
 
 	allErrs = append(allErrs, r.validateEKSVersion(nil)...)
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetGroups(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateEgress(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.NatGatewayMonitoring.Validate(field.NewPath("spec", "networkSpec", "natGatewayMonitoring"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkBorderGroups(field.NewPath("spec", "networkSpec"), r.Spec.Region)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateBlackholeRoutes(field.NewPath("spec", "networkSpec"), aws.StringValue(r.Spec.SecondaryCidrBlock))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateInstanceTenancy(nil, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateDHCPOptionsSet(nil, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSharedVPC(nil, field.NewPath("spec", "networkSpec"))...)
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
@@ -122,6 +131,14 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) error {
 	allErrs = append(allErrs, r.validateEKSClusterNameSame(oldAWSManagedControlplane)...)
 	allErrs = append(allErrs, r.validateEKSVersion(oldAWSManagedControlplane)...)
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetGroups(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateEgress(field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.NatGatewayMonitoring.Validate(field.NewPath("spec", "networkSpec", "natGatewayMonitoring"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkBorderGroups(field.NewPath("spec", "networkSpec"), r.Spec.Region)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateBlackholeRoutes(field.NewPath("spec", "networkSpec"), aws.StringValue(r.Spec.SecondaryCidrBlock))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateInstanceTenancy(&oldAWSManagedControlplane.Spec.NetworkSpec, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateDHCPOptionsSet(&oldAWSManagedControlplane.Spec.NetworkSpec, field.NewPath("spec", "networkSpec"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSharedVPC(&oldAWSManagedControlplane.Spec.NetworkSpec, field.NewPath("spec", "networkSpec"))...)
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
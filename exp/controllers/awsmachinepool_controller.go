@@ -18,7 +18,6 @@ package controllers
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
 	"sigs.k8s.io/cluster-api/util"
@@ -264,7 +263,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	providerIDList := make([]string, len(asg.Instances))
 
 	for i, ec2 := range asg.Instances {
-		providerIDList[i] = fmt.Sprintf("aws:///%s/%s", ec2.AvailabilityZone, ec2.ID)
+		providerIDList[i] = scope.BuildProviderID("", ec2.AvailabilityZone, ec2.ID)
 	}
 
 	machinePoolScope.SetAnnotation("cluster-api-provider-aws", "true")
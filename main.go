@@ -46,6 +46,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/exp/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/endpoints"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/healthreport"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-aws/version"
 	// +kubebuilder:scaffold:imports
@@ -80,6 +81,13 @@ var (
 	webhookPort              int
 	healthAddr               string
 	serviceEndpoints         string
+	skipQuorumCheck          bool
+	recoveryThreshold        time.Duration
+	reservationWarning       time.Duration
+	reservationReplacement   time.Duration
+	costAllocationTags       string
+	impairedAZAvoidance      time.Duration
+	healthReportTimeout      time.Duration
 )
 
 func main() {
@@ -140,12 +148,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	costAllocationTagPolicy, err := controllers.ParseCostAllocationTagPolicy(costAllocationTags)
+	if err != nil {
+		setupLog.Error(err, "unable to parse cost allocation tags", "controller", "AWSMachine")
+		os.Exit(1)
+	}
+
+	healthReporter := healthreport.NewReporter(ctrl.Log.WithName("healthreport"), healthreport.Options{Timeout: healthReportTimeout})
+	if err := mgr.Add(healthReporter); err != nil {
+		setupLog.Error(err, "unable to add health reporter to manager")
+		os.Exit(1)
+	}
+
 	if webhookPort == 0 {
 		if err = (&controllers.AWSMachineReconciler{
-			Client:    mgr.GetClient(),
-			Log:       ctrl.Log.WithName("controllers").WithName("AWSMachine"),
-			Recorder:  mgr.GetEventRecorderFor("awsmachine-controller"),
-			Endpoints: AWSServiceEndpoints,
+			Client:          mgr.GetClient(),
+			Log:             ctrl.Log.WithName("controllers").WithName("AWSMachine"),
+			Recorder:        mgr.GetEventRecorderFor("awsmachine-controller"),
+			Endpoints:       AWSServiceEndpoints,
+			SkipQuorumCheck: skipQuorumCheck,
+			RecoveryPolicy: controllers.InstanceRecoveryPolicy{
+				Threshold:       recoveryThreshold,
+				AvoidanceWindow: impairedAZAvoidance,
+			},
+			CapacityReservationExpiryPolicy: controllers.CapacityReservationExpiryPolicy{
+				WarningThreshold:     reservationWarning,
+				ReplacementThreshold: reservationReplacement,
+			},
+			CostAllocationTags: costAllocationTagPolicy,
+			HealthReporter:     healthReporter,
 		}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 			os.Exit(1)
@@ -344,5 +375,47 @@ func initFlags(fs *pflag.FlagSet) {
 		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}...",
 	)
 
+	fs.BoolVar(&skipQuorumCheck,
+		"skip-quorum-check",
+		false,
+		"Terminate control plane instances without waiting for the remaining control plane machines to maintain etcd quorum.",
+	)
+
+	fs.DurationVar(&recoveryThreshold,
+		"instance-recovery-threshold",
+		0,
+		"How long the EC2 status checks of an instance may stay impaired before its AWSMachine is marked as failed (e.g. 10m). Disabled when zero.",
+	)
+
+	fs.DurationVar(&impairedAZAvoidance,
+		"impaired-az-avoidance-window",
+		time.Hour,
+		"How long the availability zone of an instance marked as failed by instance recovery should be avoided (e.g. 1h)",
+	)
+
+	fs.DurationVar(&reservationWarning,
+		"capacity-reservation-warning-threshold",
+		0,
+		"How long before the time-bounded capacity reservation of an instance, e.g. a Capacity Block, ends the CapacityReservationActive condition of its AWSMachine turns false (e.g. 2h). Disabled when zero.",
+	)
+
+	fs.DurationVar(&reservationReplacement,
+		"capacity-reservation-replacement-threshold",
+		0,
+		"How long before the time-bounded capacity reservation of a worker instance ends its AWSMachine is marked as failed so that it is replaced (e.g. 30m). Disabled when zero.",
+	)
+
+	fs.StringVar(&costAllocationTags,
+		"cost-allocation-tags",
+		"",
+		"Tags every EC2 instance is expected to carry for cost allocation, with their allowed values: key1=value1|value2,key2. Missing or disallowed tags are reported on the CostAllocationTagsValid condition of the AWSMachine.",
+	)
+
+	fs.DurationVar(&healthReportTimeout,
+		"health-report-timeout",
+		healthreport.DefaultOptions.Timeout,
+		"How long a single delivery of a machine health transition to a cluster's health reporting endpoint may take before it is retried (e.g. 10s)",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
@@ -24,24 +24,27 @@ import (
 )
 
 const (
-	AuthFailure             = "AuthFailure"
-	InUseIPAddress          = "InvalidIPAddress.InUse"
-	GroupNotFound           = "InvalidGroup.NotFound"
-	PermissionNotFound      = "InvalidPermission.NotFound"
-	VPCNotFound             = "InvalidVpcID.NotFound"
-	SubnetNotFound          = "InvalidSubnetID.NotFound"
-	InternetGatewayNotFound = "InvalidInternetGatewayID.NotFound"
-	NATGatewayNotFound      = "InvalidNatGatewayID.NotFound"
-	GatewayNotFound         = "InvalidGatewayID.NotFound"
-	EIPNotFound             = "InvalidElasticIpID.NotFound"
-	RouteTableNotFound      = "InvalidRouteTableID.NotFound"
-	LoadBalancerNotFound    = "LoadBalancerNotFound"
-	ResourceNotFound        = "InvalidResourceID.NotFound"
-	InvalidSubnet           = "InvalidSubnet"
-	AssociationIDNotFound   = "InvalidAssociationID.NotFound"
-	InvalidInstanceID       = "InvalidInstanceID.NotFound"
-	ResourceExists          = "ResourceExistsException"
-	NoCredentialProviders   = "NoCredentialProviders"
+	AuthFailure                = "AuthFailure"
+	InUseIPAddress             = "InvalidIPAddress.InUse"
+	GroupNotFound              = "InvalidGroup.NotFound"
+	PermissionNotFound         = "InvalidPermission.NotFound"
+	VPCNotFound                = "InvalidVpcID.NotFound"
+	SubnetNotFound             = "InvalidSubnetID.NotFound"
+	InternetGatewayNotFound    = "InvalidInternetGatewayID.NotFound"
+	NATGatewayNotFound         = "InvalidNatGatewayID.NotFound"
+	GatewayNotFound            = "InvalidGatewayID.NotFound"
+	EIPNotFound                = "InvalidElasticIpID.NotFound"
+	RouteTableNotFound         = "InvalidRouteTableID.NotFound"
+	LoadBalancerNotFound       = "LoadBalancerNotFound"
+	ResourceNotFound           = "InvalidResourceID.NotFound"
+	InvalidSubnet              = "InvalidSubnet"
+	AssociationIDNotFound      = "InvalidAssociationID.NotFound"
+	InvalidInstanceID          = "InvalidInstanceID.NotFound"
+	ResourceExists             = "ResourceExistsException"
+	NoCredentialProviders      = "NoCredentialProviders"
+	InsufficientCapacity       = "InsufficientInstanceCapacity"
+	DHCPOptionsNotFound        = "InvalidDhcpOptionID.NotFound"
+	VolumeModificationNotFound = "InvalidVolumeModification.NotFound"
 )
 
 var _ error = &EC2Error{}
@@ -110,6 +113,15 @@ func IsFailedDependency(err error) bool {
 	return ReasonForError(err) == http.StatusFailedDependency
 }
 
+// IsInsufficientCapacity returns true if the error reports that there is not enough capacity
+// available for the requested instance type.
+func IsInsufficientCapacity(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == InsufficientCapacity
+	}
+	return false
+}
+
 // IsNotFound returns true if the error was created by NewNotFound.
 func IsNotFound(err error) bool {
 	if ReasonForError(err) == http.StatusNotFound {
@@ -137,6 +149,10 @@ func IsInvalidNotFoundError(err error) bool {
 			return true
 		case InvalidInstanceID:
 			return true
+		case DHCPOptionsNotFound:
+			return true
+		case VolumeModificationNotFound:
+			return true
 		case ssm.ErrCodeParameterNotFound:
 			return true
 		}
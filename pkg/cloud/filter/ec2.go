@@ -143,6 +143,38 @@ func (ec2Filters) SubnetStates(states ...string) *ec2.Filter {
 	}
 }
 
+// HostStates returns a filter based on the list of Dedicated Host states passed in.
+func (ec2Filters) HostStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(filterNameState),
+		Values: aws.StringSlice(states),
+	}
+}
+
+// HostAutoPlacement returns a filter based on whether Dedicated Hosts accept untargeted launches.
+func (ec2Filters) HostAutoPlacement(autoPlacement string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("auto-placement"),
+		Values: aws.StringSlice([]string{autoPlacement}),
+	}
+}
+
+// PlacementGroupName returns a filter based on the name of the placement group instances run in.
+func (ec2Filters) PlacementGroupName(name string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("placement-group-name"),
+		Values: aws.StringSlice([]string{name}),
+	}
+}
+
+// SecurityGroupIDs returns a filter based on the IDs of security groups.
+func (ec2Filters) SecurityGroupIDs(ids ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("group-id"),
+		Values: aws.StringSlice(ids),
+	}
+}
+
 func (ec2Filters) AvailabilityZone(zone string) *ec2.Filter {
 	return &ec2.Filter{
 		Name:   aws.String(filterAvailabilityZone),
@@ -22,18 +22,26 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/eventbridge"
 	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/aws/aws-sdk-go/service/outposts/outpostsiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -63,6 +71,16 @@ func NewASGClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr
 	return asgClient
 }
 
+// NewCloudWatchClient creates a new CloudWatch API client for a given session
+func NewCloudWatchClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr.Logger, target runtime.Object) cloudwatchiface.CloudWatchAPI {
+	cloudWatchClient := cloudwatch.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger)).WithLogger(awslogs.NewWrapLogr(logger)))
+	cloudWatchClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	cloudWatchClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	cloudWatchClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return cloudWatchClient
+}
+
 // NewEC2Client creates a new EC2 API client for a given session
 func NewEC2Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr.Logger, target runtime.Object) ec2iface.EC2API {
 	ec2Client := ec2.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger)).WithLogger(awslogs.NewWrapLogr(logger)))
@@ -91,6 +109,16 @@ func NewELBClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr
 	return elbClient
 }
 
+// NewELBv2Client creates a new ELBv2 API client for a given session
+func NewELBv2Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr.Logger, target runtime.Object) elbv2iface.ELBV2API {
+	elbv2Client := elbv2.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger)).WithLogger(awslogs.NewWrapLogr(logger)))
+	elbv2Client.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	elbv2Client.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	elbv2Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return elbv2Client
+}
+
 // NewEventBridgeClient creates a new EventBridge API client for a given session
 func NewEventBridgeClient(scopeUser cloud.ScopeUsage, session cloud.Session, target runtime.Object) eventbridgeiface.EventBridgeAPI {
 	eventBridgeClient := eventbridge.New(session.Session())
@@ -184,6 +212,26 @@ func NewSSMClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr
 	return ssmClient
 }
 
+// NewOutpostsClient creates a new Outposts API client for a given session
+func NewOutpostsClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr.Logger, target runtime.Object) outpostsiface.OutpostsAPI {
+	outpostsClient := outposts.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger)).WithLogger(awslogs.NewWrapLogr(logger)))
+	outpostsClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	outpostsClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	outpostsClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return outpostsClient
+}
+
+// NewRoute53Client creates a new Route53 API client for a given session
+func NewRoute53Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logr.Logger, target runtime.Object) route53iface.Route53API {
+	route53Client := route53.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger)).WithLogger(awslogs.NewWrapLogr(logger)))
+	route53Client.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	route53Client.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	route53Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return route53Client
+}
+
 func recordAWSPermissionsIssue(target runtime.Object) func(r *request.Request) {
 	return func(r *request.Request) {
 		if awsErr, ok := r.Error.(awserr.Error); ok {
@@ -205,6 +253,7 @@ func getUserAgentHandler() request.NamedHandler {
 // AWSClients contains all the aws clients used by the scopes.
 type AWSClients struct {
 	ASG             autoscalingiface.AutoScalingAPI
+	CloudWatch      cloudwatchiface.CloudWatchAPI
 	EC2             ec2iface.EC2API
 	ELB             elbiface.ELBAPI
 	SecretsManager  secretsmanageriface.SecretsManagerAPI
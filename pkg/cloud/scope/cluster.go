@@ -23,6 +23,7 @@ import (
 	awsclient "github.com/aws/aws-sdk-go/aws/client"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/klogr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud"
@@ -108,6 +109,21 @@ func (s *ClusterScope) Subnets() infrav1.Subnets {
 	return s.AWSCluster.Spec.NetworkSpec.Subnets
 }
 
+// SubnetGroups returns the cluster's groups of subnets with a dedicated default route.
+func (s *ClusterScope) SubnetGroups() []infrav1.SubnetGroupSpec {
+	return s.AWSCluster.Spec.NetworkSpec.SubnetGroups
+}
+
+// NatGatewayMonitoring returns the cluster's NAT gateway monitoring spec.
+func (s *ClusterScope) NatGatewayMonitoring() *infrav1.NatGatewayMonitoringSpec {
+	return s.AWSCluster.Spec.NetworkSpec.NatGatewayMonitoring
+}
+
+// BlackholeRoutes returns the cluster's routes dropping the traffic to their destination.
+func (s *ClusterScope) BlackholeRoutes() []infrav1.BlackholeRoute {
+	return s.AWSCluster.Spec.NetworkSpec.BlackholeRoutes
+}
+
 // SetSubnets updates the clusters subnets.
 func (s *ClusterScope) SetSubnets(subnets infrav1.Subnets) {
 	s.AWSCluster.Spec.NetworkSpec.Subnets = subnets
@@ -220,6 +236,7 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.SubnetsReadyCondition,
 			infrav1.InternetGatewayReadyCondition,
 			infrav1.NatGatewaysReadyCondition,
+			infrav1.NatGatewaysHealthyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.BastionHostReadyCondition,
@@ -310,3 +327,36 @@ func (s *ClusterScope) ImageLookupOrg() string {
 func (s *ClusterScope) ImageLookupBaseOS() string {
 	return s.AWSCluster.Spec.ImageLookupBaseOS
 }
+
+// AdditionalTrustedCAs returns the reference to the secret holding additional CA certificates
+// to be trusted by the cluster machines, if any.
+func (s *ClusterScope) AdditionalTrustedCAs() *corev1.SecretKeySelector {
+	return s.AWSCluster.Spec.AdditionalTrustedCAs
+}
+
+// RegistryCredentials returns the reference to the secret holding the container registry credentials
+// of the cluster machines, if any.
+func (s *ClusterScope) RegistryCredentials() *corev1.SecretKeySelector {
+	return s.AWSCluster.Spec.RegistryCredentials
+}
+
+// RegistryMirrors returns the mirrors the cluster machines pull images through, if any.
+func (s *ClusterScope) RegistryMirrors() []infrav1.RegistryMirror {
+	return s.AWSCluster.Spec.RegistryMirrors
+}
+
+// DefaultInstanceProfiles returns the instance profiles the cluster's machines are launched with by
+// role, if any.
+func (s *ClusterScope) DefaultInstanceProfiles() *infrav1.DefaultInstanceProfiles {
+	return s.AWSCluster.Spec.DefaultInstanceProfiles
+}
+
+// HealthReporting returns the external endpoint machine health transitions are reported to, if any.
+func (s *ClusterScope) HealthReporting() *infrav1.HealthReportingSpec {
+	return s.AWSCluster.Spec.HealthReporting
+}
+
+// NTP returns the time servers the cluster machines synchronize their clocks with, if any.
+func (s *ClusterScope) NTP() *infrav1.NTPSpec {
+	return s.AWSCluster.Spec.NTP
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PlannedChanges returns the changes a dry run would have patched on the AWSMachine as of the last
+// time it was patched or closed, one per changed field in the form "status.ready: true". Fields
+// that would have been removed are reported as null, lists are reported as a whole. It returns nil
+// when the scope is not a dry run or nothing changed.
+func (m *MachineScope) PlannedChanges() []string {
+	return m.plannedChanges
+}
+
+// planChanges records the changes made to the AWSMachine since the scope was created in place of
+// patching them.
+func (m *MachineScope) planChanges() error {
+	data, err := client.MergeFrom(m.original).Data(m.AWSMachine)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate the changes to the AWSMachine")
+	}
+
+	changes := map[string]interface{}{}
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return errors.Wrap(err, "failed to unmarshal the changes to the AWSMachine")
+	}
+
+	var planned []string
+	if err := flattenChanges("", changes, &planned); err != nil {
+		return err
+	}
+	sort.Strings(planned)
+	m.plannedChanges = planned
+	return nil
+}
+
+// flattenChanges appends the fields of a JSON merge patch to planned, by path.
+func flattenChanges(path string, changes map[string]interface{}, planned *[]string) error {
+	for key, value := range changes {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			if err := flattenChanges(fieldPath, nested, planned); err != nil {
+				return err
+			}
+			continue
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the change to %s", fieldPath)
+		}
+		*planned = append(*planned, fmt.Sprintf("%s: %s", fieldPath, encoded))
+	}
+	return nil
+}
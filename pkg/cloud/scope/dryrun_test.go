@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+func TestDryRun(t *testing.T) {
+	scheme, err := setupScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterName := "my-cluster"
+	awsMachine := newAWSMachine(clusterName, "my-machine-0")
+	c := &conflictingClient{Client: fake.NewFakeClientWithScheme(scheme, awsMachine.DeepCopy())}
+	recorder := record.NewFakeRecorder(10)
+
+	scope, err := NewMachineScope(MachineScopeParams{
+		Client:       c,
+		Machine:      newMachine(clusterName, "my-machine-0"),
+		Cluster:      newCluster(clusterName),
+		InfraCluster: &ClusterScope{AWSCluster: newAWSCluster(clusterName)},
+		AWSMachine:   awsMachine,
+		Recorder:     recorder,
+		DryRun:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scope.Close(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if changes := scope.PlannedChanges(); len(changes) != 0 {
+		t.Fatalf("Expected no planned changes for an unchanged AWSMachine, got %v", changes)
+	}
+
+	if err := scope.SetProviderID("i-0123456789abcdef0", "us-east-1a"); err != nil {
+		t.Fatal(err)
+	}
+	scope.SetInstanceState(infrav1.InstanceStateRunning)
+	scope.SetReady()
+	scope.RecordInstanceRunning("i-0123456789abcdef0")
+
+	if err := scope.PatchStatus(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := scope.Close(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.patches != 0 {
+		t.Fatalf("Expected a dry run not to patch the AWSMachine, got %d patches", c.patches)
+	}
+	if len(recorder.Events) != 0 {
+		t.Fatalf("Expected a dry run not to record events, got %q", <-recorder.Events)
+	}
+
+	planned := strings.Join(scope.PlannedChanges(), "\n")
+	for _, change := range []string{
+		`spec.providerID: "aws:///us-east-1a/i-0123456789abcdef0"`,
+		`status.instanceState: "running"`,
+		`status.ready: true`,
+	} {
+		if !strings.Contains(planned, change) {
+			t.Fatalf("Expected the planned changes to contain %q, got:\n%s", change, planned)
+		}
+	}
+
+	persisted := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: awsMachine.Namespace, Name: awsMachine.Name}, persisted); err != nil {
+		t.Fatal(err)
+	}
+	if persisted.Spec.ProviderID != nil || persisted.Status.Ready {
+		t.Fatalf("Expected the AWSMachine to be left untouched, got %+v", persisted)
+	}
+	if scope.AWSMachine.Spec.ProviderID == nil || !scope.AWSMachine.Status.Ready {
+		t.Fatal("Expected the AWSMachine to be changed in memory")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// BuildInstanceSpec returns the instance the AWSMachine asks for, as far as the AWSMachine, its Machine
+// and the AWSCluster tell: its type, IAM instance profile, volumes, network interfaces, tags, subnet and
+// placement. The subnet is the one SubnetID picks, with filtered subnets looked up by the describer.
+// The image is only set when the AWSMachine names one. Looking up the image for the Kubernetes version
+// and the security groups are left to the EC2 service, which needs to query EC2 for them.
+func (m *MachineScope) BuildInstanceSpec(subnets SubnetDescriber) (*infrav1.Instance, error) {
+	if !m.HasCluster() {
+		return nil, &InfraClusterMissingError{Machine: m.Name()}
+	}
+
+	instance := &infrav1.Instance{
+		Type:              m.InstanceType(),
+		IAMProfile:        m.GetInstanceProfile(),
+		RootVolume:        m.AWSMachine.Spec.RootVolume,
+		NonRootVolumes:    m.AWSMachine.Spec.NonRootVolumes,
+		NetworkInterfaces: m.AWSMachine.Spec.NetworkInterfaces,
+	}
+	if instance.Type == "" {
+		return nil, errors.New("either AWSMachine's spec.instanceType or spec.instanceRequirements must be defined")
+	}
+	// Malformed instance profiles fail the machine with a clear message rather than an opaque RunInstances error.
+	if errs := infrav1.ValidateIAMInstanceProfile(m.AWSMachine.Spec.IAMInstanceProfile, field.NewPath("spec", "iamInstanceProfile")); len(errs) > 0 {
+		return nil, errors.Wrap(errs.ToAggregate(), "invalid AWSMachine")
+	}
+
+	if volume := m.ContainerRuntimeVolume(); volume != nil {
+		instance.NonRootVolumes = append(append([]*infrav1.Volume{}, instance.NonRootVolumes...), volume.Volume.DeepCopy())
+	}
+
+	switch {
+	case m.AWSMachine.Spec.AMI.ID != nil:
+		instance.ImageID = *m.AWSMachine.Spec.AMI.ID
+	case m.Machine.Spec.Version == nil:
+		return nil, errors.New("either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
+	}
+
+	// The merger of the AWSCluster and AWSMachine tags.
+	instance.Tags = infrav1.Build(infrav1.BuildParams{
+		ClusterName: m.Cluster.Name,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(m.Name()),
+		Role:        aws.String(m.Role()),
+		Additional:  m.AdditionalTags(),
+	}.WithCloudProvider(m.Cluster.Name).WithMachineName(m.Machine))
+	instance.VolumeTags = m.VolumeTags()
+
+	instance.SpotMarketOptions, _ = m.GetSpotMarketOptions()
+	instance.Tenancy = m.AWSMachine.Spec.Tenancy
+	instance.PlacementGroupName = m.PlacementGroupName()
+	instance.CapacityReservationPreference = m.CapacityReservationPreference()
+	instance.CapacityReservationID = m.CapacityReservationID()
+
+	subnetID, err := m.SubnetID(subnets)
+	if err != nil {
+		return nil, err
+	}
+	instance.SubnetID = subnetID
+
+	// Control plane components rely on the instance metadata, e.g. to find the instance's identity
+	// and the region, so the service can only be turned off for workers.
+	if options := m.InstanceMetadataOptions(); options != nil && options.HTTPEndpoint == infrav1.InstanceMetadataEndpointStateDisabled && m.IsControlPlane() {
+		return nil, errors.New("the instance metadata service cannot be disabled for control plane machines")
+	}
+	instance.InstanceMetadataOptions = m.InstanceMetadataOptions()
+
+	return instance, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+func TestBuildInstanceSpec(t *testing.T) {
+	testCases := []struct {
+		name    string
+		setup   func(m *MachineScope)
+		wantErr string
+		check   func(t *testing.T, instance *infrav1.Instance)
+	}{
+		{
+			name: "builds the instance of the AWSMachine",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.AWSMachine.Spec.IAMInstanceProfile = "nodes"
+				m.AWSMachine.Spec.AdditionalTags = infrav1.Tags{"team": "infra"}
+				m.AWSMachine.Spec.RootVolume = &infrav1.Volume{Size: 50, Type: "gp3"}
+			},
+			check: func(t *testing.T, instance *infrav1.Instance) {
+				if instance.Type != "m5.large" || instance.ImageID != "ami-1" || instance.IAMProfile != "nodes" {
+					t.Fatalf("Unexpected instance %+v", instance)
+				}
+				if instance.RootVolume == nil || instance.RootVolume.Size != 50 {
+					t.Fatalf("Expected the root volume of the AWSMachine, got %+v", instance.RootVolume)
+				}
+				if instance.Tags["team"] != "infra" || instance.Tags[infrav1.ClusterTagKey("my-cluster")] != string(infrav1.ResourceLifecycleOwned) {
+					t.Fatalf("Expected the merged tags, got %v", instance.Tags)
+				}
+			},
+		},
+		{
+			name: "leaves the image to be looked up for the Kubernetes version",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.Machine.Spec.Version = pointer.StringPtr("v1.20.4")
+			},
+			check: func(t *testing.T, instance *infrav1.Instance) {
+				if instance.ImageID != "" {
+					t.Fatalf("Expected no image, got %q", instance.ImageID)
+				}
+			},
+		},
+		{
+			name: "requires an instance type",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+			},
+			wantErr: "spec.instanceType",
+		},
+		{
+			name: "requires an image or a Kubernetes version",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+			},
+			wantErr: "spec.ami.id",
+		},
+		{
+			name: "launches the instance with the instance profile ARN of the AWSMachine",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.AWSMachine.Spec.IAMInstanceProfile = "arn:aws:iam::123456789012:instance-profile/s3-nodes"
+			},
+			check: func(t *testing.T, instance *infrav1.Instance) {
+				if instance.IAMProfile != "arn:aws:iam::123456789012:instance-profile/s3-nodes" {
+					t.Fatalf("Expected the instance profile of the AWSMachine, got %q", instance.IAMProfile)
+				}
+			},
+		},
+		{
+			name: "requires a valid instance profile",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.AWSMachine.Spec.IAMInstanceProfile = "s3 nodes"
+			},
+			wantErr: "spec.iamInstanceProfile",
+		},
+		{
+			name: "runs workers on the spot market",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.AWSMachine.Spec.SpotMarketOptions = &infrav1.SpotMarketOptions{MaxPrice: pointer.StringPtr("0.05")}
+			},
+			check: func(t *testing.T, instance *infrav1.Instance) {
+				if instance.SpotMarketOptions == nil || *instance.SpotMarketOptions.MaxPrice != "0.05" {
+					t.Fatalf("Expected the spot options of the AWSMachine, got %+v", instance.SpotMarketOptions)
+				}
+			},
+		},
+		{
+			name: "keeps running existing control plane machines on the spot market",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.AWSMachine.Spec.SpotMarketOptions = &infrav1.SpotMarketOptions{}
+				m.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+			},
+			check: func(t *testing.T, instance *infrav1.Instance) {
+				if instance.SpotMarketOptions == nil {
+					t.Fatal("Expected existing control plane machines to keep running on the spot market")
+				}
+			},
+		},
+		{
+			name: "places the instance in the subnet of the failure domain",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1b")
+			},
+			check: func(t *testing.T, instance *infrav1.Instance) {
+				if instance.SubnetID != "subnet-private-b" {
+					t.Fatalf("Expected the subnet of the failure domain, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "requires a subnet in the failure domain",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.InstanceType = "m5.large"
+				m.AWSMachine.Spec.AMI.ID = pointer.StringPtr("ami-1")
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1d")
+			},
+			wantErr: `availability zone "us-east-1d"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := setupMachineScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			scope.InfraCluster.(*ClusterScope).AWSCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+				{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-private-b", AvailabilityZone: "us-east-1b"},
+			}
+			tc.setup(scope)
+
+			instance, err := scope.BuildInstanceSpec(&vpcSubnets{})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Expected an error about %s, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tc.check(t, instance)
+		})
+	}
+}
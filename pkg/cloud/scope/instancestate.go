@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// InstanceStateTransitionError is returned for instance state changes the EC2 instance lifecycle does not allow,
+// which usually come from a stale describe of the instance.
+type InstanceStateTransitionError struct {
+	From infrav1.InstanceState
+	To   infrav1.InstanceState
+}
+
+// Error implements the Error interface.
+func (e *InstanceStateTransitionError) Error() string {
+	return fmt.Sprintf("invalid instance state transition from %q to %q", e.From, e.To)
+}
+
+// isValidInstanceStateTransition returns true if an instance in the from state can be seen in the to state later on.
+// Instances are only observed every now and then, so any state that can be reached through the lifecycle is
+// allowed, not only the next one. Instances that are shutting down or terminated never come back.
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-lifecycle.html
+func isValidInstanceStateTransition(from, to infrav1.InstanceState) bool {
+	switch from {
+	case infrav1.InstanceStateTerminated:
+		return to == infrav1.InstanceStateTerminated
+	case infrav1.InstanceStateShuttingDown:
+		return to == infrav1.InstanceStateShuttingDown || to == infrav1.InstanceStateTerminated
+	default:
+		return true
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+func TestTransitionInstanceState(t *testing.T) {
+	states := []infrav1.InstanceState{
+		infrav1.InstanceStatePending,
+		infrav1.InstanceStateRunning,
+		infrav1.InstanceStateStopping,
+		infrav1.InstanceStateStopped,
+		infrav1.InstanceStateShuttingDown,
+		infrav1.InstanceStateTerminated,
+	}
+
+	// The states an instance in the given state can be seen in later on.
+	allowed := map[infrav1.InstanceState][]infrav1.InstanceState{
+		infrav1.InstanceStatePending:      states,
+		infrav1.InstanceStateRunning:      states,
+		infrav1.InstanceStateStopping:     states,
+		infrav1.InstanceStateStopped:      states,
+		infrav1.InstanceStateShuttingDown: {infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+		infrav1.InstanceStateTerminated:   {infrav1.InstanceStateTerminated},
+	}
+
+	for _, from := range states {
+		for _, to := range states {
+			valid := false
+			for _, s := range allowed[from] {
+				if s == to {
+					valid = true
+				}
+			}
+
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				scope, err := setupMachineScope()
+				if err != nil {
+					t.Fatal(err)
+				}
+				scope.SetInstanceState(from)
+
+				err = scope.TransitionInstanceState(to)
+				if valid {
+					if err != nil {
+						t.Fatalf("Expected the transition to be allowed, got %v", err)
+					}
+					if state := *scope.GetInstanceState(); state != to {
+						t.Fatalf("Expected instance state %q, got %q", to, state)
+					}
+					return
+				}
+				if _, ok := err.(*InstanceStateTransitionError); !ok {
+					t.Fatalf("Expected an InstanceStateTransitionError, got %v", err)
+				}
+				if state := *scope.GetInstanceState(); state != from {
+					t.Fatalf("Expected the instance state to stay %q, got %q", from, state)
+				}
+			})
+		}
+	}
+}
+
+func TestTransitionInstanceStateWithoutState(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scope.TransitionInstanceState(infrav1.InstanceStateTerminated); err != nil {
+		t.Fatalf("Expected any first instance state to be allowed, got %v", err)
+	}
+	if state := *scope.GetInstanceState(); state != infrav1.InstanceStateTerminated {
+		t.Fatalf("Expected instance state %q, got %q", infrav1.InstanceStateTerminated, state)
+	}
+}
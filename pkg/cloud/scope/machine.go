@@ -19,12 +19,20 @@ package scope
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/klogr"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
@@ -35,6 +43,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // MachineScopeParams defines the input parameters used to create a new MachineScope.
@@ -45,6 +54,46 @@ type MachineScopeParams struct {
 	Machine      *clusterv1.Machine
 	InfraCluster EC2Scope
 	AWSMachine   *infrav1.AWSMachine
+
+	// PatchBackoff is the backoff between the attempts to patch the AWSMachine again after a patch
+	// failed with a conflict. Defaults to a few attempts within a couple of seconds.
+	PatchBackoff *wait.Backoff
+
+	// Recorder records the events of the AWSMachine. No events are recorded without it.
+	Recorder record.EventRecorder
+
+	// AllowMissingCluster allows creating the scope without InfraCluster, while the AWSCluster or
+	// AWSManagedControlPlane is not reconciled yet. Methods that need it then return an
+	// InfraClusterMissingError.
+	AllowMissingCluster bool
+
+	// DryRun computes the changes to the AWSMachine without persisting them. The scope's setters still
+	// change the AWSMachine in memory, but PatchObject, PatchStatus and Close only record the changes
+	// they would have patched, see PlannedChanges, and no events are recorded. The calls the
+	// controller makes to AWS are not affected.
+	DryRun bool
+}
+
+// ErrBootstrapDataNotReady is returned when the Machine does not reference its bootstrap data secret yet.
+var ErrBootstrapDataNotReady = errors.New("bootstrap data is not ready: linked Machine's bootstrap.dataSecretName is nil")
+
+// InfraClusterMissingError is returned by the methods of a MachineScope created without InfraCluster that
+// need it.
+type InfraClusterMissingError struct {
+	Machine string
+}
+
+// Error implements the Error interface.
+func (e *InfraClusterMissingError) Error() string {
+	return fmt.Sprintf("the AWSCluster or AWSManagedControlPlane of AWSMachine %s is not available yet", e.Machine)
+}
+
+// defaultPatchBackoff retries patching the AWSMachine after 100ms, 200ms, 400ms and 800ms.
+var defaultPatchBackoff = wait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    4,
 }
 
 // NewMachineScope creates a new MachineScope from the supplied parameters.
@@ -62,7 +111,7 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 	if params.AWSMachine == nil {
 		return nil, errors.New("aws machine is required when creating a MachineScope")
 	}
-	if params.InfraCluster == nil {
+	if params.InfraCluster == nil && !params.AllowMissingCluster {
 		return nil, errors.New("aws cluster is required when creating a MachineScope")
 	}
 
@@ -70,14 +119,22 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		params.Logger = klogr.New()
 	}
 
+	if params.PatchBackoff == nil {
+		params.PatchBackoff = &defaultPatchBackoff
+	}
+
 	helper, err := patch.NewHelper(params.AWSMachine, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 	return &MachineScope{
-		Logger:      params.Logger,
-		client:      params.Client,
-		patchHelper: helper,
+		Logger:       params.Logger,
+		client:       params.Client,
+		patchHelper:  helper,
+		patchBackoff: *params.PatchBackoff,
+		original:     params.AWSMachine.DeepCopy(),
+		recorder:     params.Recorder,
+		dryRun:       params.DryRun,
 
 		Cluster:      params.Cluster,
 		Machine:      params.Machine,
@@ -89,8 +146,17 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 // MachineScope defines a scope defined around a machine and its cluster.
 type MachineScope struct {
 	logr.Logger
-	client      client.Client
-	patchHelper *patch.Helper
+	client       client.Client
+	patchHelper  *patch.Helper
+	patchBackoff wait.Backoff
+	// original is the AWSMachine as it was before this reconciliation changed it.
+	original *infrav1.AWSMachine
+	recorder record.EventRecorder
+	// additionalTags caches the merged tags returned by AdditionalTags.
+	additionalTags infrav1.Tags
+	dryRun         bool
+	// plannedChanges are the changes a dry run would have patched, see PlannedChanges.
+	plannedChanges []string
 
 	Cluster      *clusterv1.Cluster
 	Machine      *clusterv1.Machine
@@ -98,6 +164,11 @@ type MachineScope struct {
 	AWSMachine   *infrav1.AWSMachine
 }
 
+// HasCluster returns true if the scope has the AWSCluster or AWSManagedControlPlane of the machine.
+func (m *MachineScope) HasCluster() bool {
+	return m.InfraCluster != nil
+}
+
 // Name returns the AWSMachine name.
 func (m *MachineScope) Name() string {
 	return m.AWSMachine.Name
@@ -138,10 +209,15 @@ func (m *MachineScope) GetProviderID() string {
 	return ""
 }
 
-// SetProviderID sets the AWSMachine providerID in spec.
-func (m *MachineScope) SetProviderID(instanceID, availabilityZone string) {
-	providerID := fmt.Sprintf("aws:///%s/%s", availabilityZone, instanceID)
+// SetProviderID sets the AWSMachine providerID in spec. It returns a ProviderIDError and leaves the
+// spec untouched if the instance ID and availability zone do not make a valid provider ID.
+func (m *MachineScope) SetProviderID(instanceID, availabilityZone string) error {
+	providerID := BuildProviderID("", availabilityZone, instanceID)
+	if err := ValidateProviderID(providerID); err != nil {
+		return err
+	}
 	m.AWSMachine.Spec.ProviderID = pointer.StringPtr(providerID)
+	return nil
 }
 
 // SetInstanceID sets the AWSMachine instanceID in spec.
@@ -154,11 +230,22 @@ func (m *MachineScope) GetInstanceState() *infrav1.InstanceState {
 	return m.AWSMachine.Status.InstanceState
 }
 
-// SetInstanceState sets the AWSMachine status instance state.
+// SetInstanceState sets the AWSMachine status instance state, whatever the current one is.
+// Use TransitionInstanceState for states observed on the instance.
 func (m *MachineScope) SetInstanceState(v infrav1.InstanceState) {
 	m.AWSMachine.Status.InstanceState = &v
 }
 
+// TransitionInstanceState sets the AWSMachine status instance state if the instance can move there from
+// the current one, and returns an InstanceStateTransitionError otherwise, leaving the current state as is.
+func (m *MachineScope) TransitionInstanceState(v infrav1.InstanceState) error {
+	if current := m.GetInstanceState(); current != nil && !isValidInstanceStateTransition(*current, v) {
+		return &InstanceStateTransitionError{From: *current, To: v}
+	}
+	m.SetInstanceState(v)
+	return nil
+}
+
 // SetReady sets the AWSMachine Ready Status
 func (m *MachineScope) SetReady() {
 	m.AWSMachine.Status.Ready = true
@@ -234,14 +321,177 @@ func (m *MachineScope) SetSecretCount(i int32) {
 	m.AWSMachine.Spec.CloudInit.SecretCount = i
 }
 
-// SetAddresses sets the AWSMachine address status.
+// SetAddresses sets the AWSMachine address status. Addresses listed more than once, like the private IP
+// some instance descriptions report twice, are only kept the first time.
 func (m *MachineScope) SetAddresses(addrs []clusterv1.MachineAddress) {
-	m.AWSMachine.Status.Addresses = addrs
+	var unique []clusterv1.MachineAddress
+	seen := make(map[clusterv1.MachineAddress]bool, len(addrs))
+	for _, addr := range addrs {
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		unique = append(unique, addr)
+	}
+	m.AWSMachine.Status.Addresses = unique
+}
+
+// GetAddress returns the first address of the given type in the AWSMachine address status, and whether
+// there is one. Addresses are kept in the order EC2 describes the instance's network interfaces in, so
+// the first one is not necessarily that of the primary network interface.
+func (m *MachineScope) GetAddress(addressType clusterv1.MachineAddressType) (string, bool) {
+	for _, addr := range m.AWSMachine.Status.Addresses {
+		if addr.Type == addressType {
+			return addr.Address, true
+		}
+	}
+	return "", false
+}
+
+// GetInternalIP returns the first internal IP address of the AWSMachine, and whether there is one.
+func (m *MachineScope) GetInternalIP() (string, bool) {
+	return m.GetAddress(clusterv1.MachineInternalIP)
+}
+
+// GetExternalDNS returns the first external DNS name of the AWSMachine, and whether there is one.
+func (m *MachineScope) GetExternalDNS() (string, bool) {
+	return m.GetAddress(clusterv1.MachineExternalDNS)
+}
+
+// SetImageID sets the ID of the AMI the AWSMachine's instance was launched from.
+func (m *MachineScope) SetImageID(imageID string) {
+	m.AWSMachine.Status.ImageID = imageID
+}
+
+// SetOutpostARN sets the ARN of the Outpost the AWSMachine's instance was launched on.
+func (m *MachineScope) SetOutpostARN(outpostARN string) {
+	m.AWSMachine.Status.OutpostARN = outpostARN
+}
+
+// TerminationLogUpload returns the S3 bucket the journal of the AWSMachine's instance is uploaded to
+// before it is terminated, or nil to not upload it.
+func (m *MachineScope) TerminationLogUpload() *infrav1.TerminationLogUpload {
+	return m.AWSMachine.Spec.TerminationLogUpload
+}
+
+// SetTerminationLogLocation sets the S3 location the journal of the AWSMachine's instance was uploaded to.
+func (m *MachineScope) SetTerminationLogLocation(location string) {
+	m.AWSMachine.Status.TerminationLogLocation = location
+}
+
+// SetCapacityReservationEndTime sets when the capacity reservation of the AWSMachine's instance ends.
+func (m *MachineScope) SetCapacityReservationEndTime(end *metav1.Time) {
+	m.AWSMachine.Status.CapacityReservationEndTime = end
+}
+
+// SetRootVolume sets the root volume of the AWSMachine's instance.
+func (m *MachineScope) SetRootVolume(volume *infrav1.RootVolumeStatus) {
+	m.AWSMachine.Status.RootVolume = volume
+}
+
+// GetFailureDomain returns the failure domain the machine is placed in. The failure domain of the
+// Machine, which is how KubeadmControlPlane spreads machines across failure domains, takes precedence
+// over the one of the AWSMachine. It returns nil if neither sets one.
+func (m *MachineScope) GetFailureDomain() *string {
+	if m.Machine.Spec.FailureDomain != nil && *m.Machine.Spec.FailureDomain != "" {
+		return m.Machine.Spec.FailureDomain
+	}
+	if m.AWSMachine.Spec.FailureDomain != nil && *m.AWSMachine.Spec.FailureDomain != "" {
+		return m.AWSMachine.Spec.FailureDomain
+	}
+	return nil
+}
+
+// SetFailureDomain sets the availability zone the AWSMachine's instance was launched in.
+func (m *MachineScope) SetFailureDomain(zone string) {
+	m.AWSMachine.Status.AvailabilityZone = zone
+}
+
+// InstanceMetadataOptions returns the options of the instance metadata service requested for the
+// AWSMachine's instance, or nil to keep the AWS defaults.
+func (m *MachineScope) InstanceMetadataOptions() *infrav1.InstanceMetadataOptions {
+	return m.AWSMachine.Spec.InstanceMetadataOptions
+}
+
+// GetBootstrapDataHash returns the hash of the user data the AWSMachine's instance was launched with,
+// if known.
+func (m *MachineScope) GetBootstrapDataHash() *string {
+	return m.AWSMachine.Status.BootstrapDataHash
+}
+
+// SetBootstrapDataHash records the hash of the user data the AWSMachine's instance was launched with.
+func (m *MachineScope) SetBootstrapDataHash(hash string) {
+	m.AWSMachine.Status.BootstrapDataHash = &hash
+}
+
+// SetInstanceMetadataOptions sets the options of the instance metadata service the AWSMachine's
+// instance runs with.
+func (m *MachineScope) SetInstanceMetadataOptions(options *infrav1.InstanceMetadataOptions) {
+	m.AWSMachine.Status.InstanceMetadataOptions = options
+}
+
+// InstanceRequirements returns the attributes of the instance type the AWSMachine's instance is created
+// as, if it is not set by name.
+func (m *MachineScope) InstanceRequirements() *infrav1.InstanceRequirements {
+	return m.AWSMachine.Spec.InstanceRequirements
+}
+
+// InstanceType returns the type the AWSMachine's instance is created as: the type set on the spec, or
+// the one picked from the instance requirements otherwise.
+func (m *MachineScope) InstanceType() string {
+	if m.AWSMachine.Spec.InstanceType != "" {
+		return m.AWSMachine.Spec.InstanceType
+	}
+	return m.AWSMachine.Status.InstanceType
+}
+
+// SetInstanceType sets the type the AWSMachine's instance is created as.
+func (m *MachineScope) SetInstanceType(instanceType string) {
+	m.AWSMachine.Status.InstanceType = instanceType
+}
+
+// ContainerRuntimeVolume returns the volume dedicated to the storage of the container runtime of the
+// AWSMachine's instance, if any.
+func (m *MachineScope) ContainerRuntimeVolume() *infrav1.ContainerRuntimeVolume {
+	return m.AWSMachine.Spec.ContainerRuntimeVolume
+}
+
+// AdditionalBootConfig returns the systemd units and install scripts set up at boot on the AWSMachine's
+// instance, if any.
+func (m *MachineScope) AdditionalBootConfig() *infrav1.AdditionalBootConfig {
+	return m.AWSMachine.Spec.AdditionalBootConfig
+}
+
+// PlacementGroupName returns the name of the spread placement group the AWSMachine's instance is
+// launched in, if any.
+func (m *MachineScope) PlacementGroupName() string {
+	return m.AWSMachine.Spec.PlacementGroupName
+}
+
+// HostPlacement returns how the AWSMachine's instance is placed on a Dedicated Host, if configured.
+func (m *MachineScope) HostPlacement() *infrav1.HostPlacement {
+	return m.AWSMachine.Spec.HostPlacement
+}
+
+// CapacityReservationPreference returns whether the AWSMachine's instance consumes reserved capacity.
+func (m *MachineScope) CapacityReservationPreference() infrav1.CapacityReservationPreference {
+	return m.AWSMachine.Spec.CapacityReservationPreference
+}
+
+// CapacityReservationID returns the ID of the capacity reservation the AWSMachine's instance is
+// launched into with the targeted preference, if any.
+func (m *MachineScope) CapacityReservationID() *string {
+	return m.AWSMachine.Spec.CapacityReservationID
+}
+
+// SetAssignedPrivateIP sets the AWSMachine's address assigned from its subnet's private IP pool.
+func (m *MachineScope) SetAssignedPrivateIP(ip string) {
+	m.AWSMachine.Status.AssignedPrivateIP = ip
 }
 
 // GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName as base64.
-func (m *MachineScope) GetBootstrapData() (string, error) {
-	value, err := m.GetRawBootstrapData()
+func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
+	value, err := m.GetRawBootstrapData(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -249,28 +499,264 @@ func (m *MachineScope) GetBootstrapData() (string, error) {
 }
 
 // GetRawBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
-func (m *MachineScope) GetRawBootstrapData() ([]byte, error) {
+// It returns ErrBootstrapDataNotReady if the Machine does not reference the secret yet.
+func (m *MachineScope) GetRawBootstrapData(ctx context.Context) ([]byte, error) {
 	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
-		return nil, errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
+		return nil, ErrBootstrapDataNotReady
 	}
 
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.Machine.Spec.Bootstrap.DataSecretName}
-	if err := m.client.Get(context.TODO(), key, secret); err != nil {
-		return nil, errors.Wrapf(err, "failed to retrieve bootstrap data secret for AWSMachine %s/%s", m.Namespace(), m.Name())
+	if err := m.client.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve bootstrap data secret %s for AWSMachine %s/%s", key, m.Namespace(), m.Name())
 	}
 
 	value, ok := secret.Data["value"]
 	if !ok {
-		return nil, errors.New("error retrieving bootstrap data: secret value key is missing")
+		return nil, errors.Errorf("error retrieving bootstrap data: secret %s is missing the value key", key)
+	}
+
+	return value, nil
+}
+
+// GetAdditionalTrustedCAs returns the PEM-encoded CA certificates referenced by the AWSCluster
+// that the machine should trust, or nil if none are configured.
+func (m *MachineScope) GetAdditionalTrustedCAs(ctx context.Context) ([]byte, error) {
+	clusterScope, ok := m.InfraCluster.(*ClusterScope)
+	if !ok || clusterScope.AdditionalTrustedCAs() == nil {
+		return nil, nil
+	}
+
+	ref := clusterScope.AdditionalTrustedCAs()
+	value, err := m.getSecretValue(ctx, ref.Name, ref.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve additional trusted CA certificates")
 	}
 
 	return value, nil
 }
 
+// GetRegistryCredentials returns the container registry credentials referenced by the AWSCluster
+// in the Docker config JSON format, or nil if none are configured.
+func (m *MachineScope) GetRegistryCredentials(ctx context.Context) ([]byte, error) {
+	clusterScope, ok := m.InfraCluster.(*ClusterScope)
+	if !ok || clusterScope.RegistryCredentials() == nil {
+		return nil, nil
+	}
+
+	ref := clusterScope.RegistryCredentials()
+	value, err := m.getSecretValue(ctx, ref.Name, ref.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve registry credentials")
+	}
+
+	return value, nil
+}
+
+// RegistryMirrors returns the mirrors configured on the AWSCluster that the machine pulls images
+// through, if any.
+func (m *MachineScope) RegistryMirrors() []infrav1.RegistryMirror {
+	clusterScope, ok := m.InfraCluster.(*ClusterScope)
+	if !ok {
+		return nil
+	}
+	return clusterScope.RegistryMirrors()
+}
+
+// GetInstanceProfile returns the instance profile the AWSMachine's instance is launched with: the one
+// set on the AWSMachine, or else the cluster's default instance profile for the machine's role. The
+// instance profile set on the AWSMachine is the name or the ARN of an instance profile.
+func (m *MachineScope) GetInstanceProfile() string {
+	if m.AWSMachine.Spec.IAMInstanceProfile != "" {
+		return m.AWSMachine.Spec.IAMInstanceProfile
+	}
+
+	clusterScope, ok := m.InfraCluster.(*ClusterScope)
+	if !ok || clusterScope.DefaultInstanceProfiles() == nil {
+		return ""
+	}
+	if m.IsControlPlane() {
+		return clusterScope.DefaultInstanceProfiles().ControlPlane
+	}
+	return clusterScope.DefaultInstanceProfiles().Node
+}
+
+// GetRegistryMirrorCA returns the PEM-encoded CA certificates the certificate of the given registry
+// mirror is verified against, or nil if none are configured.
+func (m *MachineScope) GetRegistryMirrorCA(ctx context.Context, mirror infrav1.RegistryMirror) ([]byte, error) {
+	if mirror.TLS == nil || mirror.TLS.CASecretRef == nil {
+		return nil, nil
+	}
+
+	ref := mirror.TLS.CASecretRef
+	value, err := m.getSecretValue(ctx, ref.Name, ref.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve CA certificates of registry mirror %q", mirror.Endpoint)
+	}
+
+	return value, nil
+}
+
+// HealthReporting returns the external endpoint configured on the AWSCluster that the machine's
+// health transitions are reported to, or nil if none is configured.
+func (m *MachineScope) HealthReporting() *infrav1.HealthReportingSpec {
+	clusterScope, ok := m.InfraCluster.(*ClusterScope)
+	if !ok {
+		return nil
+	}
+	return clusterScope.HealthReporting()
+}
+
+// NTP returns the time servers configured on the AWSCluster that the machine synchronizes its
+// clock with, or nil if none are configured.
+func (m *MachineScope) NTP() *infrav1.NTPSpec {
+	clusterScope, ok := m.InfraCluster.(*ClusterScope)
+	if !ok {
+		return nil
+	}
+	return clusterScope.NTP()
+}
+
+// GetHealthReportingAuthorization returns the value of the Authorization header to send to the
+// health reporting endpoint, or an empty string if none is configured.
+func (m *MachineScope) GetHealthReportingAuthorization(ctx context.Context) (string, error) {
+	spec := m.HealthReporting()
+	if spec == nil || spec.AuthorizationSecretRef == nil {
+		return "", nil
+	}
+
+	value, err := m.getSecretValue(ctx, spec.AuthorizationSecretRef.Name, spec.AuthorizationSecretRef.Key)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve health reporting authorization")
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
+
+// getSecretValue returns the value of the given key of a secret in the AWSMachine's namespace.
+func (m *MachineScope) getSecretValue(ctx context.Context, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: m.Namespace(), Name: name}
+	if err := m.client.Get(ctx, secretKey, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve secret %s/%s", m.Namespace(), name)
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, errors.Errorf("secret %s/%s is missing key %q", m.Namespace(), name, key)
+	}
+
+	return value, nil
+}
+
+// Eventf records an event of the AWSMachine. It does nothing when the scope has no recorder or is a
+// dry run.
+func (m *MachineScope) Eventf(eventType, reason, messageFmt string, args ...interface{}) {
+	if m.recorder == nil || m.dryRun {
+		return
+	}
+	m.recorder.Eventf(m.AWSMachine, eventType, reason, messageFmt, args...)
+}
+
+// RecordInstanceCreated records that the AWSMachine's instance was created.
+func (m *MachineScope) RecordInstanceCreated(instanceID string) {
+	m.Eventf(corev1.EventTypeNormal, "SuccessfulCreate", "Created new %s instance with id %q", m.Role(), instanceID)
+}
+
+// RecordInstanceRunning records that the AWSMachine's instance entered the running state.
+func (m *MachineScope) RecordInstanceRunning(instanceID string) {
+	m.Eventf(corev1.EventTypeNormal, "InstanceRunning", "Instance %q is running", instanceID)
+}
+
+// RecordInstanceTerminated records that the AWSMachine's instance was terminated.
+func (m *MachineScope) RecordInstanceTerminated(instanceID string) {
+	m.Eventf(corev1.EventTypeNormal, "SuccessfulTerminate", "Terminated instance %q", instanceID)
+}
+
+// GetCondition returns the condition of the given type of the AWSMachine, or nil if it is not set.
+func (m *MachineScope) GetCondition(conditionType clusterv1.ConditionType) *clusterv1.Condition {
+	return conditions.Get(m.AWSMachine, conditionType)
+}
+
+// SetCondition sets the condition of the given type of the AWSMachine through the cluster-api conditions
+// utilities, which keep the last transition time of a condition while its state does not change. True
+// conditions carry no reason, message or severity.
+func (m *MachineScope) SetCondition(conditionType clusterv1.ConditionType, status corev1.ConditionStatus, severity clusterv1.ConditionSeverity, reason, message string) {
+	switch status {
+	case corev1.ConditionTrue:
+		conditions.MarkTrue(m.AWSMachine, conditionType)
+	case corev1.ConditionFalse:
+		conditions.MarkFalse(m.AWSMachine, conditionType, reason, severity, "%s", message)
+	default:
+		conditions.MarkUnknown(m.AWSMachine, conditionType, reason, "%s", message)
+	}
+}
+
 // PatchObject persists the machine spec and status.
-func (m *MachineScope) PatchObject() error {
-	// Always update the readyCondition by summarizing the state of other conditions.
+func (m *MachineScope) PatchObject(ctx context.Context) error {
+	m.setReadySummary()
+	if m.dryRun {
+		return m.planChanges()
+	}
+
+	err := m.patchHelper.Patch(ctx, m.AWSMachine, patch.WithOwnedConditions{Conditions: ownedConditions})
+
+	// Other controllers kept updating the AWSMachine in the meantime, so retry with its latest version
+	// rather than dropping the changes of this reconciliation.
+	backoff := m.patchBackoff
+	for isConflict(err) && backoff.Steps > 0 {
+		m.V(2).Info("Conflict patching the AWSMachine, retrying with its latest version")
+		time.Sleep(backoff.Step())
+		err = m.rebaseAndPatch(ctx)
+	}
+	return err
+}
+
+// PatchStatus persists the machine status only, through the status subresource. Changes to the spec,
+// labels, annotations and finalizers are left to PatchObject, so that status updates do not revert
+// spec changes made by others in the meantime. Like PatchObject, it retries conflicts with the latest
+// version of the AWSMachine.
+func (m *MachineScope) PatchStatus(ctx context.Context) error {
+	m.setReadySummary()
+	if m.dryRun {
+		return m.planChanges()
+	}
+
+	// The patch helper diffs against the AWSMachine as it was before this reconciliation changed it, so
+	// only the status differs from it.
+	patched := m.original.DeepCopy()
+	m.AWSMachine.Status.DeepCopyInto(&patched.Status)
+	err := m.patchHelper.Patch(ctx, patched, patch.WithOwnedConditions{Conditions: ownedConditions})
+
+	backoff := m.patchBackoff
+	for isConflict(err) && backoff.Steps > 0 {
+		m.V(2).Info("Conflict patching the AWSMachine status, retrying with its latest version")
+		time.Sleep(backoff.Step())
+		patched, err = m.rebaseAndPatchStatus(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The patched AWSMachine becomes the base of later patches, so that they neither send the status
+	// again nor revert changes others made in the meantime.
+	rebased, err := rebaseAWSMachine(m.original, m.AWSMachine, patched)
+	if err != nil {
+		return err
+	}
+	helper, err := patch.NewHelper(patched, m.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+	rebased.DeepCopyInto(m.AWSMachine)
+	m.additionalTags = nil
+	m.original = patched
+	m.patchHelper = helper
+	return nil
+}
+
+// setReadySummary updates the Ready condition by summarizing the state of the other conditions.
+func (m *MachineScope) setReadySummary() {
 	// A step counter is added to represent progress during the provisioning process (instead we are hiding during the deletion process).
 	applicableConditions := []clusterv1.ConditionType{
 		infrav1.InstanceReadyCondition,
@@ -283,35 +769,172 @@ func (m *MachineScope) PatchObject() error {
 
 	conditions.SetSummary(m.AWSMachine,
 		conditions.WithConditions(applicableConditions...),
-		conditions.WithStepCounterIf(m.AWSMachine.ObjectMeta.DeletionTimestamp.IsZero()),
+		conditions.WithStepCounterIf(!m.IsBeingDeleted()),
 		conditions.WithStepCounter(),
 	)
+}
+
+// ownedConditions are the conditions of the AWSMachine set by its controller.
+var ownedConditions = []clusterv1.ConditionType{
+	clusterv1.ReadyCondition,
+	infrav1.InstanceReadyCondition,
+	infrav1.SecurityGroupsReadyCondition,
+	infrav1.ELBAttachedCondition,
+	infrav1.CostAllocationTagsValidCondition,
+	infrav1.BootstrapDataUpToDateCondition,
+	infrav1.CapacityReservationActiveCondition,
+	infrav1.RootVolumeTypeUpToDateCondition,
+}
+
+// rebaseAndPatch re-applies the changes made to the AWSMachine during this reconciliation onto its
+// latest version and patches it.
+func (m *MachineScope) rebaseAndPatch(ctx context.Context) error {
+	latest := &infrav1.AWSMachine{}
+	if err := m.client.Get(ctx, types.NamespacedName{Namespace: m.AWSMachine.Namespace, Name: m.AWSMachine.Name}, latest); err != nil {
+		return errors.Wrap(err, "failed to get the latest AWSMachine")
+	}
+
+	rebased, err := rebaseAWSMachine(m.original, m.AWSMachine, latest)
+	if err != nil {
+		return err
+	}
 
-	return m.patchHelper.Patch(
-		context.TODO(),
-		m.AWSMachine,
-		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
-			clusterv1.ReadyCondition,
-			infrav1.InstanceReadyCondition,
-			infrav1.SecurityGroupsReadyCondition,
-			infrav1.ELBAttachedCondition,
-		}})
+	helper, err := patch.NewHelper(latest, m.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+	if err := helper.Patch(ctx, rebased, patch.WithOwnedConditions{Conditions: ownedConditions}); err != nil {
+		return err
+	}
+
+	rebased.DeepCopyInto(m.AWSMachine)
+	// The rebase may have brought in tags other controllers set, which the cached tags do not have.
+	m.additionalTags = nil
+	m.original = latest
+	m.patchHelper = helper
+	return nil
 }
 
-// Close the MachineScope by updating the machine spec, machine status.
-func (m *MachineScope) Close() error {
-	return m.PatchObject()
+// rebaseAndPatchStatus re-applies the status changes made to the AWSMachine during this reconciliation
+// onto its latest version and patches them, returning the patched AWSMachine.
+func (m *MachineScope) rebaseAndPatchStatus(ctx context.Context) (*infrav1.AWSMachine, error) {
+	latest := &infrav1.AWSMachine{}
+	if err := m.client.Get(ctx, types.NamespacedName{Namespace: m.AWSMachine.Namespace, Name: m.AWSMachine.Name}, latest); err != nil {
+		return nil, errors.Wrap(err, "failed to get the latest AWSMachine")
+	}
+
+	rebased, err := rebaseAWSMachine(m.original, m.AWSMachine, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	helper, err := patch.NewHelper(latest, m.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+	patched := latest.DeepCopy()
+	rebased.Status.DeepCopyInto(&patched.Status)
+	if err := helper.Patch(ctx, patched, patch.WithOwnedConditions{Conditions: ownedConditions}); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// rebaseAWSMachine returns the latest AWSMachine with the changes from original to modified applied.
+func rebaseAWSMachine(original, modified, latest *infrav1.AWSMachine) (*infrav1.AWSMachine, error) {
+	// Conditions are merged separately, as other controllers may own some of them.
+	before, after := original.DeepCopy(), modified.DeepCopy()
+	before.Status.Conditions, after.Status.Conditions = nil, nil
+
+	changes, err := client.MergeFrom(before).Data(after)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate the changes to the AWSMachine")
+	}
+	latestJSON, err := json.Marshal(latest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the latest AWSMachine")
+	}
+	rebasedJSON, err := jsonpatch.MergePatch(latestJSON, changes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply the changes to the latest AWSMachine")
+	}
+
+	rebased := &infrav1.AWSMachine{}
+	if err := json.Unmarshal(rebasedJSON, rebased); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the rebased AWSMachine")
+	}
+	if err := conditions.NewPatch(original, modified).Apply(rebased, conditions.WithOwnedConditions(ownedConditions...)); err != nil {
+		return nil, errors.Wrap(err, "failed to apply the condition changes to the latest AWSMachine")
+	}
+	return rebased, nil
+}
+
+// isConflict returns true if the error, or any of the errors it aggregates, reports that others kept
+// changing the AWSMachine while it was patched. Only the conditions are patched with an optimistic lock
+// by the patch helper, which retries conflicts itself and returns wait.ErrWaitTimeout once it gives up.
+func isConflict(err error) bool {
+	if aggregate, ok := err.(kerrors.Aggregate); ok {
+		for _, e := range aggregate.Errors() {
+			if isConflict(e) {
+				return true
+			}
+		}
+		return false
+	}
+	return errors.Cause(err) == wait.ErrWaitTimeout
+}
+
+// Close the MachineScope by updating the machine spec, machine status. Patches failing with a conflict
+// are retried with the latest version of the AWSMachine. A dry run only records the changes, see
+// PlannedChanges.
+func (m *MachineScope) Close(ctx context.Context) error {
+	return m.PatchObject(ctx)
 }
 
 // AdditionalTags merges AdditionalTags from the scope's AWSCluster and AWSMachine. If the same key is present in both,
-// the value from AWSMachine takes precedence. The returned Tags will never be nil.
+// the value from AWSMachine takes precedence. Keys listed in the AWSMachine's ExcludeClusterTags are dropped from the
+// AWSCluster's tags before merging. Only the AWSMachine's tags are returned while the scope has no AWSCluster.
+// The returned Tags are a copy that will never be nil.
+//
+// The merged tags are computed once per scope, i.e. once per reconcile. Changes to the AWSMachine's tags made
+// through SetAdditionalTags are picked up, changes made to the spec directly are not.
 func (m *MachineScope) AdditionalTags() infrav1.Tags {
+	if m.additionalTags == nil {
+		tags := make(infrav1.Tags)
+
+		// Start with the cluster-wide tags, less the ones the Machine excludes...
+		if m.HasCluster() {
+			tags.Merge(m.InfraCluster.AdditionalTags())
+		}
+		for _, key := range m.AWSMachine.Spec.ExcludeClusterTags {
+			delete(tags, key)
+		}
+		// ... and merge in the Machine's
+		tags.Merge(m.AWSMachine.Spec.AdditionalTags)
+
+		m.additionalTags = tags
+	}
+
+	return m.additionalTags.DeepCopy()
+}
+
+// SetAdditionalTags sets the AWSMachine's additional tags.
+func (m *MachineScope) SetAdditionalTags(tags infrav1.Tags) {
+	m.AWSMachine.Spec.AdditionalTags = tags
+	m.additionalTags = nil
+}
+
+// VolumeTags returns the tags of the EBS volumes of the AWSMachine's instance: the additional tags of
+// the AWSCluster and AWSMachine merged with the AWSMachine's volume tags, which take precedence. It
+// returns empty Tags when the AWSMachine has no volume tags.
+func (m *MachineScope) VolumeTags() infrav1.Tags {
 	tags := make(infrav1.Tags)
+	if len(m.AWSMachine.Spec.VolumeTags) == 0 {
+		return tags
+	}
 
-	// Start with the cluster-wide tags...
-	tags.Merge(m.InfraCluster.AdditionalTags())
-	// ... and merge in the Machine's
-	tags.Merge(m.AWSMachine.Spec.AdditionalTags)
+	tags.Merge(m.AdditionalTags())
+	tags.Merge(m.AWSMachine.Spec.VolumeTags)
 
 	return tags
 }
@@ -335,17 +958,54 @@ func (m *MachineScope) InstanceIsInKnownState() bool {
 	return state != nil && infrav1.InstanceKnownStates.Has(string(*state))
 }
 
-func (m *MachineScope) AWSMachineIsDeleted() bool {
+// IsBeingDeleted returns true if the AWSMachine is being deleted.
+func (m *MachineScope) IsBeingDeleted() bool {
 	return !m.AWSMachine.ObjectMeta.DeletionTimestamp.IsZero()
 }
 
+// AWSMachineIsDeleted returns true if the AWSMachine is being deleted.
+//
+// Deprecated: use IsBeingDeleted.
+func (m *MachineScope) AWSMachineIsDeleted() bool {
+	return m.IsBeingDeleted()
+}
+
+// EnsureFinalizer adds the finalizer to the AWSMachine unless it already has it, and returns true if it
+// was added. The change is persisted when the scope is patched.
+func (m *MachineScope) EnsureFinalizer(name string) bool {
+	if controllerutil.ContainsFinalizer(m.AWSMachine, name) {
+		return false
+	}
+	controllerutil.AddFinalizer(m.AWSMachine, name)
+	return true
+}
+
+// RemoveFinalizer removes the finalizer from the AWSMachine, if it has it. The change is persisted when
+// the scope is patched.
+func (m *MachineScope) RemoveFinalizer(name string) {
+	controllerutil.RemoveFinalizer(m.AWSMachine, name)
+}
+
+// IsEKSManaged returns true if the machine belongs to an EKS cluster. It returns false while the scope
+// has no AWSCluster or AWSManagedControlPlane.
 func (m *MachineScope) IsEKSManaged() bool {
+	if !m.HasCluster() {
+		return false
+	}
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == "AWSManagedControlPlane"
 }
 
 // SetInterruptible sets the AWSMachine status Interruptible
 func (m *MachineScope) SetInterruptible() {
-	if m.AWSMachine.Spec.SpotMarketOptions != nil {
+	if _, ok := m.GetSpotMarketOptions(); ok {
 		m.AWSMachine.Status.Interruptible = true
 	}
 }
+
+// GetSpotMarketOptions returns the options to run the machine's instance on the spot market with,
+// and false when the instance is on-demand. New control plane machines with spot options are rejected
+// by the AWSMachine webhook, existing ones keep running on the spot market.
+func (m *MachineScope) GetSpotMarketOptions() (*infrav1.SpotMarketOptions, bool) {
+	spot := m.AWSMachine.Spec.SpotMarketOptions
+	return spot, spot != nil
+}
@@ -17,15 +17,28 @@ limitations under the License.
 package scope
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -136,13 +149,52 @@ func setupMachineScope() (*MachineScope, error) {
 	)
 }
 
+func TestNewMachineScopeWithoutCluster(t *testing.T) {
+	scheme, err := setupScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	awsMachine := newAWSMachine("my-cluster", "my-machine-0")
+	awsMachine.Spec.AdditionalTags = infrav1.Tags{"team": "infra"}
+	params := MachineScopeParams{
+		Client:     fake.NewFakeClientWithScheme(scheme, awsMachine.DeepCopy()),
+		Machine:    newMachine("my-cluster", "my-machine-0"),
+		Cluster:    newCluster("my-cluster"),
+		AWSMachine: awsMachine,
+	}
+
+	if _, err := NewMachineScope(params); err == nil {
+		t.Fatal("Expected an error without AWSCluster")
+	}
+
+	params.AllowMissingCluster = true
+	scope, err := NewMachineScope(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scope.HasCluster() {
+		t.Fatal("Expected the scope to have no cluster")
+	}
+	if tags := scope.AdditionalTags(); !reflect.DeepEqual(tags, infrav1.Tags{"team": "infra"}) {
+		t.Fatalf("Expected the tags of the AWSMachine, got %v", tags)
+	}
+	if scope.IsEKSManaged() {
+		t.Fatal("Expected the machine not to be EKS managed")
+	}
+	if _, err := scope.BuildInstanceSpec(&vpcSubnets{}); err == nil {
+		t.Fatal("Expected an error building the instance without cluster")
+	} else if _, ok := err.(*InfraClusterMissingError); !ok {
+		t.Fatalf("Expected an InfraClusterMissingError, got %v", err)
+	}
+}
+
 func TestGetBootstrapDataIsBase64Encoded(t *testing.T) {
 	scope, err := setupMachineScope()
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	userdata, err := scope.GetBootstrapData()
+	userdata, err := scope.GetBootstrapData(context.TODO())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -158,7 +210,7 @@ func TestGetRawBootstrapDataIsNotBase64Encoded(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	userdata, err := scope.GetRawBootstrapData()
+	userdata, err := scope.GetRawBootstrapData(context.TODO())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -168,6 +220,44 @@ func TestGetRawBootstrapDataIsNotBase64Encoded(t *testing.T) {
 	}
 }
 
+func TestGetRawBootstrapDataNotReady(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope.Machine.Spec.Bootstrap.DataSecretName = nil
+	if _, err := scope.GetRawBootstrapData(context.TODO()); err != ErrBootstrapDataNotReady {
+		t.Fatalf("Expected ErrBootstrapDataNotReady, got %v", err)
+	}
+}
+
+func TestGetRawBootstrapDataMissing(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope.Machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr("missing")
+	if _, err := scope.GetRawBootstrapData(context.TODO()); err == nil || err == ErrBootstrapDataNotReady {
+		t.Fatalf("Expected an error for the missing secret, got %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := scope.client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-machine-0"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	secret.Data = map[string][]byte{"format": []byte("cloud-config")}
+	if err := scope.client.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	scope.Machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr("my-machine-0")
+	if _, err := scope.GetRawBootstrapData(context.TODO()); err == nil || err == ErrBootstrapDataNotReady {
+		t.Fatalf("Expected an error for the missing value key, got %v", err)
+	}
+}
+
 func TestUseSecretsManagerTrue(t *testing.T) {
 	scope, err := setupMachineScope()
 	if err != nil {
@@ -210,17 +300,704 @@ func TestSetProviderID(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	scope.SetProviderID("test-id", "test-zone-1a")
+	if err := scope.SetProviderID("i-0123456789abcdef0", "us-east-1a"); err != nil {
+		t.Fatal(err)
+	}
 	providerID := *scope.AWSMachine.Spec.ProviderID
-	expectedProviderID := "aws:///test-zone-1a/test-id"
+	expectedProviderID := "aws:///us-east-1a/i-0123456789abcdef0"
 	if providerID != expectedProviderID {
 		t.Fatalf("Expected providerID %s, got %s", expectedProviderID, providerID)
 	}
 
-	scope.SetProviderID("test-id", "")
-	providerID = *scope.AWSMachine.Spec.ProviderID
-	expectedProviderID = "aws:////test-id"
-	if providerID != expectedProviderID {
-		t.Fatalf("Expected providerID %s, got %s", expectedProviderID, providerID)
+	if err := scope.SetProviderID("i-0123456789abcdef0", ""); err == nil {
+		t.Fatal("Expected an error for a missing availability zone")
+	}
+	if err := scope.SetProviderID("", "us-east-1a"); err == nil {
+		t.Fatal("Expected an error for a missing instance ID")
+	}
+	if err := scope.SetProviderID("test-id", "us-east-1a"); err == nil {
+		t.Fatal("Expected an error for a malformed instance ID")
+	}
+	if err := scope.SetProviderID("i-0123456789abcdef0", "us-east/1a"); err == nil {
+		t.Fatal("Expected an error for a malformed availability zone")
+	}
+	if providerID := *scope.AWSMachine.Spec.ProviderID; providerID != expectedProviderID {
+		t.Fatalf("Expected providerID to stay %s, got %s", expectedProviderID, providerID)
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := scope.GetInternalIP(); ok {
+		t.Fatal("Expected no internal IP")
+	}
+
+	scope.SetAddresses([]clusterv1.MachineAddress{
+		{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-1.ec2.internal"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.1.1"},
+		{Type: clusterv1.MachineExternalDNS, Address: "ec2-1-2-3-4.compute-1.amazonaws.com"},
+	})
+	if len(scope.AWSMachine.Status.Addresses) != 4 {
+		t.Fatalf("Expected duplicate addresses to be dropped, got %v", scope.AWSMachine.Status.Addresses)
+	}
+
+	if ip, ok := scope.GetInternalIP(); !ok || ip != "10.0.0.1" {
+		t.Fatalf("Expected the first internal IP, got %q", ip)
+	}
+	if dns, ok := scope.GetExternalDNS(); !ok || dns != "ec2-1-2-3-4.compute-1.amazonaws.com" {
+		t.Fatalf("Expected the external DNS name, got %q", dns)
+	}
+	if _, ok := scope.GetAddress(clusterv1.MachineExternalIP); ok {
+		t.Fatal("Expected no external IP")
+	}
+}
+
+func TestGetFailureDomain(t *testing.T) {
+	testCases := []struct {
+		name           string
+		machineFD      *string
+		awsMachineFD   *string
+		expectedDomain *string
+	}{
+		{
+			name: "nil when neither sets a failure domain",
+		},
+		{
+			name:         "nil when the failure domains are empty",
+			machineFD:    pointer.StringPtr(""),
+			awsMachineFD: pointer.StringPtr(""),
+		},
+		{
+			name:           "the Machine's failure domain",
+			machineFD:      pointer.StringPtr("us-east-1a"),
+			expectedDomain: pointer.StringPtr("us-east-1a"),
+		},
+		{
+			name:           "the AWSMachine's failure domain",
+			awsMachineFD:   pointer.StringPtr("us-east-1b"),
+			expectedDomain: pointer.StringPtr("us-east-1b"),
+		},
+		{
+			name:           "the Machine's failure domain takes precedence",
+			machineFD:      pointer.StringPtr("us-east-1a"),
+			awsMachineFD:   pointer.StringPtr("us-east-1b"),
+			expectedDomain: pointer.StringPtr("us-east-1a"),
+		},
+		{
+			name:           "the AWSMachine's failure domain when the Machine's is empty",
+			machineFD:      pointer.StringPtr(""),
+			awsMachineFD:   pointer.StringPtr("us-east-1b"),
+			expectedDomain: pointer.StringPtr("us-east-1b"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := setupMachineScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			scope.Machine.Spec.FailureDomain = tc.machineFD
+			scope.AWSMachine.Spec.FailureDomain = tc.awsMachineFD
+
+			failureDomain := scope.GetFailureDomain()
+			if tc.expectedDomain == nil {
+				if failureDomain != nil {
+					t.Fatalf("Expected no failure domain, got %q", *failureDomain)
+				}
+				return
+			}
+			if failureDomain == nil || *failureDomain != *tc.expectedDomain {
+				t.Fatalf("Expected failure domain %q, got %v", *tc.expectedDomain, failureDomain)
+			}
+		})
+	}
+}
+
+func TestSetFailureDomain(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope.SetFailureDomain("us-east-1c")
+	if zone := scope.AWSMachine.Status.AvailabilityZone; zone != "us-east-1c" {
+		t.Fatalf("Expected availability zone us-east-1c, got %q", zone)
+	}
+}
+
+func TestIAMInstanceProfile(t *testing.T) {
+	testCases := []struct {
+		name            string
+		controlPlane    bool
+		machineProfile  string
+		defaults        *infrav1.DefaultInstanceProfiles
+		expectedProfile string
+	}{
+		{
+			name: "no instance profile",
+		},
+		{
+			name:            "the cluster's default for nodes",
+			defaults:        &infrav1.DefaultInstanceProfiles{ControlPlane: "control-plane", Node: "nodes"},
+			expectedProfile: "nodes",
+		},
+		{
+			name:            "the cluster's default for control plane machines",
+			controlPlane:    true,
+			defaults:        &infrav1.DefaultInstanceProfiles{ControlPlane: "control-plane", Node: "nodes"},
+			expectedProfile: "control-plane",
+		},
+		{
+			name:            "the AWSMachine's instance profile takes precedence",
+			machineProfile:  "custom",
+			defaults:        &infrav1.DefaultInstanceProfiles{ControlPlane: "control-plane", Node: "nodes"},
+			expectedProfile: "custom",
+		},
+		{
+			name:         "no default for the machine's role",
+			controlPlane: true,
+			defaults:     &infrav1.DefaultInstanceProfiles{Node: "nodes"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := setupMachineScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.controlPlane {
+				scope.Machine.Labels[clusterv1.MachineControlPlaneLabelName] = ""
+			}
+			scope.AWSMachine.Spec.IAMInstanceProfile = tc.machineProfile
+			scope.InfraCluster.(*ClusterScope).AWSCluster.Spec.DefaultInstanceProfiles = tc.defaults
+
+			if profile := scope.GetInstanceProfile(); profile != tc.expectedProfile {
+				t.Fatalf("Expected instance profile %q, got %q", tc.expectedProfile, profile)
+			}
+		})
+	}
+}
+
+func TestAdditionalTags(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	awsCluster := scope.InfraCluster.(*ClusterScope).AWSCluster
+	awsCluster.Spec.AdditionalTags = infrav1.Tags{"cost-center": "ml", "team": "infra", "env": "prod"}
+	scope.AWSMachine.Spec.AdditionalTags = infrav1.Tags{"team": "ml-workers", "spot": "true"}
+	scope.AWSMachine.Spec.ExcludeClusterTags = []string{"cost-center", "team"}
+
+	tags := scope.AdditionalTags()
+	expected := infrav1.Tags{"env": "prod", "team": "ml-workers", "spot": "true"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("Expected tags %v, got %v", expected, tags)
+	}
+
+	tags["env"] = "dev"
+	if awsCluster.Spec.AdditionalTags["env"] != "prod" || scope.AWSMachine.Spec.AdditionalTags["env"] != "" {
+		t.Fatal("Expected the returned tags not to share the spec's tags")
+	}
+	if tags := scope.AdditionalTags(); tags["env"] != "prod" {
+		t.Fatalf("Expected changes to the returned tags not to be cached, got %v", tags)
+	}
+
+	scope.SetAdditionalTags(infrav1.Tags{"spot": "false"})
+	expected = infrav1.Tags{"env": "prod", "spot": "false"}
+	if tags := scope.AdditionalTags(); !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("Expected tags %v after setting the AWSMachine's tags, got %v", expected, tags)
+	}
+}
+
+func BenchmarkAdditionalTags(b *testing.B) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		b.Fatal(err)
+	}
+	clusterTags := infrav1.Tags{}
+	machineTags := infrav1.Tags{}
+	for i := 0; i < 50; i++ {
+		clusterTags[fmt.Sprintf("cluster-%d", i)] = "value"
+		machineTags[fmt.Sprintf("machine-%d", i)] = "value"
+	}
+	scope.InfraCluster.(*ClusterScope).AWSCluster.Spec.AdditionalTags = clusterTags
+	scope.SetAdditionalTags(machineTags)
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			scope.additionalTags = nil
+			scope.AdditionalTags()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			scope.AdditionalTags()
+		}
+	})
+}
+
+func TestEvents(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Scopes without recorder do not record events.
+	scope.RecordInstanceRunning("i-1234")
+
+	recorder := record.NewFakeRecorder(1)
+	scope.recorder = recorder
+	scope.RecordInstanceRunning("i-1234")
+	if event := <-recorder.Events; event != `Normal InstanceRunning Instance "i-1234" is running` {
+		t.Fatalf("Unexpected event %q", event)
+	}
+}
+
+func TestSetCondition(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if scope.GetCondition(infrav1.InstanceReadyCondition) != nil {
+		t.Fatal("Expected no condition")
+	}
+
+	scope.SetCondition(infrav1.SecurityGroupsReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.SecurityGroupsFailedReason, "security groups not yet attached")
+	scope.SetCondition(infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceNotReadyReason, "waiting for the instance to be running")
+
+	condition := scope.GetCondition(infrav1.InstanceReadyCondition)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Severity != clusterv1.ConditionSeverityInfo ||
+		condition.Reason != infrav1.InstanceNotReadyReason || condition.Message != "waiting for the instance to be running" {
+		t.Fatalf("Unexpected condition %+v", condition)
+	}
+	if condition := scope.GetCondition(infrav1.SecurityGroupsReadyCondition); condition == nil || condition.Severity != clusterv1.ConditionSeverityError {
+		t.Fatalf("Expected the severity to be passed through, got %+v", condition)
+	}
+
+	// Setting the same state again keeps the last transition time.
+	transitioned := metav1.NewTime(time.Now().Add(-time.Hour))
+	for i := range scope.AWSMachine.Status.Conditions {
+		if scope.AWSMachine.Status.Conditions[i].Type == infrav1.InstanceReadyCondition {
+			scope.AWSMachine.Status.Conditions[i].LastTransitionTime = transitioned
+		}
+	}
+	scope.SetCondition(infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceNotReadyReason, "waiting for the instance to be running")
+	condition = scope.GetCondition(infrav1.InstanceReadyCondition)
+	if !condition.LastTransitionTime.Equal(&transitioned) {
+		t.Fatalf("Expected the condition not to transition, got %+v", condition)
+	}
+
+	// Changing the status is a transition.
+	scope.SetCondition(infrav1.InstanceReadyCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityNone, "", "")
+	condition = scope.GetCondition(infrav1.InstanceReadyCondition)
+	if condition.Status != corev1.ConditionTrue || condition.Severity != clusterv1.ConditionSeverityNone || condition.LastTransitionTime.Equal(&transitioned) {
+		t.Fatalf("Expected the status to transition, got %+v", condition)
+	}
+
+	// Conditions are deduplicated by type.
+	if len(scope.AWSMachine.Status.Conditions) != 2 {
+		t.Fatalf("Expected one condition per type, got %+v", scope.AWSMachine.Status.Conditions)
+	}
+}
+
+func TestFinalizers(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !scope.EnsureFinalizer(infrav1.MachineFinalizer) {
+		t.Fatal("Expected the finalizer to be added")
+	}
+	if scope.EnsureFinalizer(infrav1.MachineFinalizer) {
+		t.Fatal("Expected the finalizer to be added once")
+	}
+	if len(scope.AWSMachine.Finalizers) != 1 {
+		t.Fatalf("Expected one finalizer, got %v", scope.AWSMachine.Finalizers)
+	}
+
+	scope.RemoveFinalizer(infrav1.MachineFinalizer)
+	scope.RemoveFinalizer(infrav1.MachineFinalizer)
+	if len(scope.AWSMachine.Finalizers) != 0 {
+		t.Fatalf("Expected no finalizers, got %v", scope.AWSMachine.Finalizers)
+	}
+}
+
+func TestIsBeingDeleted(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if scope.IsBeingDeleted() {
+		t.Fatal("Expected the AWSMachine not to be deleted")
+	}
+
+	now := metav1.Now()
+	scope.AWSMachine.DeletionTimestamp = &now
+	if !scope.IsBeingDeleted() {
+		t.Fatal("Expected the AWSMachine to be deleted")
+	}
+	if !scope.AWSMachineIsDeleted() {
+		t.Fatal("Expected the deprecated AWSMachineIsDeleted to agree with IsBeingDeleted")
+	}
+}
+
+func TestGetSpotMarketOptions(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := scope.GetSpotMarketOptions(); ok {
+		t.Fatal("Expected an on-demand instance")
+	}
+
+	spot := &infrav1.SpotMarketOptions{MaxPrice: pointer.StringPtr("0.05")}
+	scope.AWSMachine.Spec.SpotMarketOptions = spot
+	if got, ok := scope.GetSpotMarketOptions(); !ok || got != spot {
+		t.Fatalf("Expected the spot options of the AWSMachine, got %+v", got)
+	}
+	scope.SetInterruptible()
+	if !scope.AWSMachine.Status.Interruptible {
+		t.Fatal("Expected the AWSMachine to be interruptible")
+	}
+
+	// Control plane machines created before the webhook refused spot options keep them.
+	scope.AWSMachine.Status.Interruptible = false
+	scope.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+	if got, ok := scope.GetSpotMarketOptions(); !ok || got != spot {
+		t.Fatalf("Expected the spot options of the control plane machine, got %+v", got)
+	}
+	scope.SetInterruptible()
+	if !scope.AWSMachine.Status.Interruptible {
+		t.Fatal("Expected the control plane machine to be interruptible")
+	}
+}
+
+// conflictingClient locks patches optimistically like the API server does: patches carrying a
+// resourceVersion fail with a conflict if the AWSMachine changed since. Another controller writes to
+// the AWSMachine right before each of the first conflicts optimistically locked patches, adding
+// otherTags to its additional tags, and err fails all patches.
+type conflictingClient struct {
+	client.Client
+	conflicts int
+	otherTags infrav1.Tags
+	err       error
+	patches   int
+}
+
+func (c *conflictingClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patches++
+	if c.err != nil {
+		return c.err
+	}
+
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	locked := struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(data, &locked); err != nil {
+		return err
+	}
+	if locked.Metadata.ResourceVersion == "" {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		return err
+	}
+	current := &infrav1.AWSMachine{}
+	if err := c.Client.Get(ctx, key, current); err != nil {
+		return err
+	}
+	if c.conflicts > 0 {
+		c.conflicts--
+		if current.Annotations == nil {
+			current.Annotations = map[string]string{}
+		}
+		current.Annotations["other-controller-writes"] += "x"
+		if len(c.otherTags) > 0 {
+			current.Spec.AdditionalTags = current.Spec.AdditionalTags.DeepCopy()
+			if current.Spec.AdditionalTags == nil {
+				current.Spec.AdditionalTags = infrav1.Tags{}
+			}
+			for k, v := range c.otherTags {
+				current.Spec.AdditionalTags[k] = v
+			}
+		}
+		if err := c.Client.Update(ctx, current); err != nil {
+			return err
+		}
+	}
+	if current.ResourceVersion != locked.Metadata.ResourceVersion {
+		return apierrors.NewConflict(schema.GroupResource{Group: infrav1.GroupVersion.Group, Resource: "awsmachines"}, key.Name, errors.New("the object has been modified"))
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Status patches go through the same locking, as the fake client does not have a status subresource.
+func (c *conflictingClient) Status() client.StatusWriter {
+	return conflictingStatusWriter{c}
+}
+
+type conflictingStatusWriter struct {
+	c *conflictingClient
+}
+
+func (w conflictingStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return w.c.Client.Status().Update(ctx, obj, opts...)
+}
+
+func (w conflictingStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return w.c.Patch(ctx, obj, patch, opts...)
+}
+
+func setupConflictingMachineScope(c *conflictingClient) (*MachineScope, error) {
+	scheme, err := setupScheme()
+	if err != nil {
+		return nil, err
+	}
+	clusterName := "my-cluster"
+	cluster := newCluster(clusterName)
+	machine := newMachine(clusterName, "my-machine-0")
+	awsMachine := newAWSMachine(clusterName, "my-machine-0")
+	awsMachine.ResourceVersion = "1"
+	awsCluster := newAWSCluster(clusterName)
+
+	c.Client = fake.NewFakeClientWithScheme(scheme, cluster, machine, awsMachine.DeepCopy(), awsCluster)
+	return NewMachineScope(
+		MachineScopeParams{
+			Client:  c,
+			Machine: machine,
+			Cluster: cluster,
+			InfraCluster: &ClusterScope{
+				AWSCluster: awsCluster,
+			},
+			AWSMachine:   awsMachine,
+			PatchBackoff: &wait.Backoff{Steps: 3},
+		},
+	)
+}
+
+// The patch helper retries each conflicting condition patch 5 times before it gives up.
+const patchHelperConditionAttempts = 5
+
+func TestCloseRetriesOnConflict(t *testing.T) {
+	c := &conflictingClient{conflicts: patchHelperConditionAttempts}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scope.SetProviderID("i-0123456789abcdef0", "us-east-1a"); err != nil {
+		t.Fatal(err)
+	}
+	conditions.MarkTrue(scope.AWSMachine, infrav1.InstanceReadyCondition)
+	if err := scope.Close(context.TODO()); err != nil {
+		t.Fatalf("Expected the conflict to be retried, got %v", err)
+	}
+	if c.conflicts != 0 {
+		t.Fatalf("Expected all conflicts to be hit, %d left", c.conflicts)
+	}
+
+	key := types.NamespacedName{Namespace: "default", Name: "my-machine-0"}
+	latest := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), key, latest); err != nil {
+		t.Fatal(err)
+	}
+	if providerID := pointer.StringPtrDerefOr(latest.Spec.ProviderID, ""); providerID != "aws:///us-east-1a/i-0123456789abcdef0" {
+		t.Fatalf("Expected the provider ID to be patched, got %q", providerID)
+	}
+	if !conditions.IsTrue(latest, infrav1.InstanceReadyCondition) {
+		t.Fatalf("Expected the condition to be patched, got %v", latest.Status.Conditions)
+	}
+	if latest.Annotations["other-controller-writes"] == "" {
+		t.Fatalf("Expected the writes of the other controller to be kept, got %v", latest.Annotations)
+	}
+	if scope.AWSMachine.Annotations["other-controller-writes"] == "" {
+		t.Fatalf("Expected the scope to hold the latest AWSMachine, got %v", scope.AWSMachine.Annotations)
+	}
+}
+
+func TestCloseRefreshesAdditionalTagsOnConflict(t *testing.T) {
+	c := &conflictingClient{conflicts: patchHelperConditionAttempts, otherTags: infrav1.Tags{"team": "infra"}}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tags := scope.AdditionalTags(); tags["team"] != "" {
+		t.Fatalf("Expected no team tag yet, got %v", tags)
+	}
+	conditions.MarkTrue(scope.AWSMachine, infrav1.InstanceReadyCondition)
+	if err := scope.Close(context.TODO()); err != nil {
+		t.Fatalf("Expected the conflict to be retried, got %v", err)
+	}
+	if tags := scope.AdditionalTags(); tags["team"] != "infra" {
+		t.Fatalf("Expected the tags of the other controller, got %v", tags)
+	}
+}
+
+func TestCloseGivesUpAfterRepeatedConflicts(t *testing.T) {
+	c := &conflictingClient{conflicts: 100}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope.patchBackoff = wait.Backoff{Steps: 1}
+
+	conditions.MarkTrue(scope.AWSMachine, infrav1.InstanceReadyCondition)
+	if err := scope.Close(context.TODO()); !isConflict(err) {
+		t.Fatalf("Expected a conflict, got %v", err)
+	}
+	if hit := 100 - c.conflicts; hit != 2*patchHelperConditionAttempts {
+		t.Fatalf("Expected the patch to be tried twice, got %d conflicts", hit)
+	}
+}
+
+func TestCloseDoesNotRetryOtherErrors(t *testing.T) {
+	c := &conflictingClient{err: errors.New("boom")}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scope.SetProviderID("i-0123456789abcdef0", "us-east-1a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := scope.Close(context.TODO()); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if c.patches != 1 {
+		t.Fatalf("Expected 1 patch, got %d", c.patches)
+	}
+}
+
+func TestPatchStatusLeavesSpecUntouched(t *testing.T) {
+	c := &conflictingClient{}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another controller tags the AWSMachine while it is being reconciled.
+	key := types.NamespacedName{Namespace: "default", Name: "my-machine-0"}
+	other := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), key, other); err != nil {
+		t.Fatal(err)
+	}
+	other.Spec.AdditionalTags = infrav1.Tags{"other": "tag"}
+	if err := c.Update(context.TODO(), other); err != nil {
+		t.Fatal(err)
+	}
+
+	scope.AWSMachine.Spec.AdditionalTags = infrav1.Tags{"stale": "tag"}
+	scope.SetInstanceState(infrav1.InstanceStateRunning)
+	if err := scope.PatchStatus(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	latest := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), key, latest); err != nil {
+		t.Fatal(err)
+	}
+	if state := latest.Status.InstanceState; state == nil || *state != infrav1.InstanceStateRunning {
+		t.Fatalf("Expected the instance state to be patched, got %v", state)
+	}
+	if !reflect.DeepEqual(latest.Spec.AdditionalTags, infrav1.Tags{"other": "tag"}) {
+		t.Fatalf("Expected the spec to be left untouched, got %v", latest.Spec.AdditionalTags)
+	}
+}
+
+func TestPatchStatusRetriesOnConflict(t *testing.T) {
+	c := &conflictingClient{conflicts: patchHelperConditionAttempts, otherTags: infrav1.Tags{"team": "infra"}}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conditions.MarkTrue(scope.AWSMachine, infrav1.InstanceReadyCondition)
+	if err := scope.PatchStatus(context.TODO()); err != nil {
+		t.Fatalf("Expected the conflict to be retried, got %v", err)
+	}
+	if c.conflicts != 0 {
+		t.Fatalf("Expected all conflicts to be hit, %d left", c.conflicts)
+	}
+
+	key := types.NamespacedName{Namespace: "default", Name: "my-machine-0"}
+	latest := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), key, latest); err != nil {
+		t.Fatal(err)
+	}
+	if !conditions.IsTrue(latest, infrav1.InstanceReadyCondition) {
+		t.Fatalf("Expected the condition to be patched, got %v", latest.Status.Conditions)
+	}
+	if latest.Spec.AdditionalTags["team"] != "infra" {
+		t.Fatalf("Expected the writes of the other controller to be kept, got %v", latest.Spec.AdditionalTags)
+	}
+	if tags := scope.AdditionalTags(); tags["team"] != "infra" {
+		t.Fatalf("Expected the tags of the other controller, got %v", tags)
+	}
+}
+
+func TestPatchStatusRefreshesBase(t *testing.T) {
+	c := &conflictingClient{}
+	scope, err := setupConflictingMachineScope(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope.SetInstanceState(infrav1.InstanceStateRunning)
+	if err := scope.PatchStatus(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another reconciliation records the instance stopping after the status was patched.
+	key := types.NamespacedName{Namespace: "default", Name: "my-machine-0"}
+	other := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), key, other); err != nil {
+		t.Fatal(err)
+	}
+	other.Spec.AdditionalTags = infrav1.Tags{"other": "tag"}
+	other.Status.InstanceState = &infrav1.InstanceStateStopping
+	if err := c.Update(context.TODO(), other); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scope.SetProviderID("i-0123456789abcdef0", "us-east-1a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := scope.Close(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	latest := &infrav1.AWSMachine{}
+	if err := c.Get(context.TODO(), key, latest); err != nil {
+		t.Fatal(err)
+	}
+	if providerID := pointer.StringPtrDerefOr(latest.Spec.ProviderID, ""); providerID != "aws:///us-east-1a/i-0123456789abcdef0" {
+		t.Fatalf("Expected the provider ID to be patched, got %q", providerID)
+	}
+	if !reflect.DeepEqual(latest.Spec.AdditionalTags, infrav1.Tags{"other": "tag"}) {
+		t.Fatalf("Expected the tags of the other controller to be kept, got %v", latest.Spec.AdditionalTags)
+	}
+	if state := latest.Status.InstanceState; state == nil || *state != infrav1.InstanceStateStopping {
+		t.Fatalf("Expected the patched status not to be sent again, got %v", state)
 	}
 }
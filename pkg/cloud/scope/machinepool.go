@@ -18,7 +18,6 @@ package scope
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -223,7 +222,7 @@ type NodeStatus struct {
 func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances []infrav1.Instance) error {
 	providerIDs := make([]string, len(instances))
 	for i, instance := range instances {
-		providerIDs[i] = fmt.Sprintf("aws:////%s", instance.ID)
+		providerIDs[i] = BuildProviderID("", "", instance.ID)
 	}
 
 	nodeStatusByProviderID, err := m.getNodeStatusByProviderID(ctx, providerIDs)
@@ -239,7 +238,7 @@ func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances
 		}
 
 		instanceStatus := instanceStatuses[i]
-		if nodeStatus, ok := nodeStatusByProviderID[fmt.Sprintf("aws:////%s", instanceStatus.InstanceID)]; ok {
+		if nodeStatus, ok := nodeStatusByProviderID[BuildProviderID("", "", instanceStatus.InstanceID)]; ok {
 			instanceStatus.Version = &nodeStatus.Version
 			if nodeStatus.Ready {
 				readyReplicas++
@@ -273,7 +272,7 @@ func (m *MachinePoolScope) getNodeStatusByProviderID(ctx context.Context, provid
 
 			strList := strings.Split(node.Spec.ProviderID, "/")
 
-			if status, ok := nodeStatusMap[fmt.Sprintf("aws:////%s", strList[len(strList)-1])]; ok {
+			if status, ok := nodeStatusMap[BuildProviderID("", "", strList[len(strList)-1])]; ok {
 				status.Ready = nodeIsReady(node)
 				status.Version = node.Status.NodeInfo.KubeletVersion
 			}
@@ -156,6 +156,21 @@ func (s *ManagedControlPlaneScope) Subnets() infrav1.Subnets {
 	return s.ControlPlane.Spec.NetworkSpec.Subnets
 }
 
+// SubnetGroups returns the control plane's groups of subnets with a dedicated default route.
+func (s *ManagedControlPlaneScope) SubnetGroups() []infrav1.SubnetGroupSpec {
+	return s.ControlPlane.Spec.NetworkSpec.SubnetGroups
+}
+
+// NatGatewayMonitoring returns the control plane's NAT gateway monitoring spec.
+func (s *ManagedControlPlaneScope) NatGatewayMonitoring() *infrav1.NatGatewayMonitoringSpec {
+	return s.ControlPlane.Spec.NetworkSpec.NatGatewayMonitoring
+}
+
+// BlackholeRoutes returns the control plane's routes dropping the traffic to their destination.
+func (s *ManagedControlPlaneScope) BlackholeRoutes() []infrav1.BlackholeRoute {
+	return s.ControlPlane.Spec.NetworkSpec.BlackholeRoutes
+}
+
 // SetSubnets updates the control planes subnets.
 func (s *ManagedControlPlaneScope) SetSubnets(subnets infrav1.Subnets) {
 	s.ControlPlane.Spec.NetworkSpec.Subnets = subnets
@@ -215,6 +230,7 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1.SubnetsReadyCondition,
 			infrav1.InternetGatewayReadyCondition,
 			infrav1.NatGatewaysReadyCondition,
+			infrav1.NatGatewaysHealthyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneCreatingCondition,
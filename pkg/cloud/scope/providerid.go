@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+)
+
+// providerIDPrefix is the scheme of the provider IDs of AWS instances.
+const providerIDPrefix = "aws://"
+
+var (
+	// instanceIDPattern matches the short and long forms of EC2 instance IDs.
+	instanceIDPattern = regexp.MustCompile(`^i-[0-9a-f]{8}([0-9a-f]{9})?$`)
+	// locationPattern matches region and availability zone names, such as us-east-1 and us-east-1a.
+	locationPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)+$`)
+)
+
+// ProviderIDError is returned for provider IDs that are not of the form aws://<region>/<availability-zone>/<instance-id>,
+// where the region may be empty. The availability zone is required, as the AWS cloud provider always sets it on nodes.
+type ProviderIDError struct {
+	ProviderID string
+	Reason     string
+}
+
+// Error implements the Error interface.
+func (e *ProviderIDError) Error() string {
+	return fmt.Sprintf("invalid provider ID %q: %s", e.ProviderID, e.Reason)
+}
+
+// BuildProviderID returns the provider ID of the given instance. The region is usually left empty,
+// which matches the provider IDs the AWS cloud provider sets on nodes.
+func BuildProviderID(region, availabilityZone, instanceID string) string {
+	return fmt.Sprintf("%s%s/%s/%s", providerIDPrefix, region, availabilityZone, instanceID)
+}
+
+// ValidateProviderID returns a ProviderIDError if the provider ID is malformed, using the same parsing
+// as GetInstanceID.
+func ValidateProviderID(providerID string) error {
+	if _, err := noderefutil.NewProviderID(providerID); err != nil {
+		return &ProviderIDError{ProviderID: providerID, Reason: err.Error()}
+	}
+	if !strings.HasPrefix(providerID, providerIDPrefix) {
+		return &ProviderIDError{ProviderID: providerID, Reason: fmt.Sprintf("must start with %q", providerIDPrefix)}
+	}
+
+	segments := strings.Split(strings.TrimPrefix(providerID, providerIDPrefix), "/")
+	if len(segments) != 3 {
+		return &ProviderIDError{ProviderID: providerID, Reason: "must have a region, an availability zone and an instance ID segment"}
+	}
+	region, availabilityZone, instanceID := segments[0], segments[1], segments[2]
+	if region != "" && !locationPattern.MatchString(region) {
+		return &ProviderIDError{ProviderID: providerID, Reason: fmt.Sprintf("region %q is not a valid region name", region)}
+	}
+	if !locationPattern.MatchString(availabilityZone) {
+		return &ProviderIDError{ProviderID: providerID, Reason: fmt.Sprintf("availability zone %q is not a valid availability zone name", availabilityZone)}
+	}
+	if !instanceIDPattern.MatchString(instanceID) {
+		return &ProviderIDError{ProviderID: providerID, Reason: fmt.Sprintf("instance ID %q is not a valid EC2 instance ID", instanceID)}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+)
+
+func TestBuildProviderID(t *testing.T) {
+	if id := BuildProviderID("", "us-east-1a", "i-0123456789abcdef0"); id != "aws:///us-east-1a/i-0123456789abcdef0" {
+		t.Fatalf("Unexpected provider ID %s", id)
+	}
+	if id := BuildProviderID("us-east-1", "us-east-1a", "i-0123456789abcdef0"); id != "aws://us-east-1/us-east-1a/i-0123456789abcdef0" {
+		t.Fatalf("Unexpected provider ID %s", id)
+	}
+}
+
+func TestValidateProviderID(t *testing.T) {
+	testCases := []struct {
+		providerID string
+		valid      bool
+	}{
+		{providerID: "aws:///us-east-1a/i-0123456789abcdef0", valid: true},
+		{providerID: "aws://us-east-1/us-east-1a/i-0123456789abcdef0", valid: true},
+		{providerID: "aws:///us-east-1a/i-01234567", valid: true},
+		{providerID: "aws:////i-0123456789abcdef0"},
+		{providerID: "aws:///us-east-1a/i-0123"},
+		{providerID: "aws:///us-east-1a/myMachine"},
+		{providerID: "aws:///us-east-1a/i-0123456789ABCDEF0"},
+		{providerID: "aws:///us_east_1a/i-0123456789abcdef0"},
+		{providerID: "aws://us east 1/us-east-1a/i-0123456789abcdef0"},
+		{providerID: ""},
+		{providerID: "i-0123456789abcdef0"},
+		{providerID: "gce:///us-east-1a/i-0123456789abcdef0"},
+		{providerID: "aws:///us-east-1a/"},
+		{providerID: "aws:///i-0123456789abcdef0"},
+		{providerID: "aws:///us-east-1a/extra/i-0123456789abcdef0"},
+		{providerID: "aws:/// us-east-1a/i-0123456789abcdef0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.providerID, func(t *testing.T) {
+			err := ValidateProviderID(tc.providerID)
+			if tc.valid && err != nil {
+				t.Fatalf("Expected provider ID to be valid, got %v", err)
+			}
+			if !tc.valid {
+				if _, ok := err.(*ProviderIDError); !ok {
+					t.Fatalf("Expected a ProviderIDError, got %v", err)
+				}
+			}
+		})
+	}
+}
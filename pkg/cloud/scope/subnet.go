@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"crypto/rand"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+)
+
+// SubnetDescriber describes the subnets of the cluster's VPC that match all of the given EC2 filters.
+type SubnetDescriber interface {
+	DescribeSubnets(filters ...infrav1.Filter) (infrav1.Subnets, error)
+}
+
+// SubnetID returns the ID of the subnet the machine's instance goes into:
+// - the subnet of the AWSMachine's spec.subnet.id, which must be one of the cluster's subnets in the
+// failure domain if there is one. Without failure domain, any subnet ID is used as it is.
+// - otherwise the first of the subnets of the VPC matching the AWSMachine's spec.subnet.filters, the
+// failure domain and the Outpost, as described by the describer, or a random one with the Random
+// filter selection scheme.
+// - otherwise one of the cluster's subnets matching the AWSMachine's subnet group, subnet tags and
+// Outpost, in the failure domain if there is one. Machines asking for a public IP go into public
+// subnets, all others into private ones. Subnet tags must match exactly one subnet of the failure
+// domain. When more than one subnet matches, a hash of the machine name picks one, which spreads
+// machines across the subnets while always placing the same machine in the same subnet.
+// Subnets that cannot be found are reported as failed dependencies, as the network may still change
+// to provide them.
+func (m *MachineScope) SubnetID(describer SubnetDescriber) (string, error) {
+	if !m.HasCluster() {
+		return "", &InfraClusterMissingError{Machine: m.Name()}
+	}
+	ref := m.AWSMachine.Spec.Subnet
+	switch {
+	case ref != nil && ref.ID != nil:
+		return m.explicitSubnetID(*ref.ID)
+	case ref != nil && ref.Filters != nil:
+		return m.filteredSubnetID(describer, ref)
+	}
+
+	failureDomain := m.GetFailureDomain()
+	subnets := m.InfraCluster.Subnets()
+	if m.AWSMachine.Spec.PublicIP != nil && *m.AWSMachine.Spec.PublicIP {
+		subnets = subnets.FilterPublic()
+	} else {
+		subnets = subnets.FilterPrivate()
+	}
+	subnets = subnets.FilterByOutpost(m.AWSMachine.Spec.OutpostARN).FilterBySubnetGroup(m.AWSMachine.Spec.SubnetGroup).FilterByTags(m.AWSMachine.Spec.SubnetTags)
+
+	if failureDomain != nil {
+		subnets = subnets.FilterByZone(*failureDomain)
+		if len(subnets) == 0 {
+			return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q%s", m.Name(), *failureDomain, m.subnetSelectionSuffix()))
+		}
+		// Subnet tags are how a machine picks one of several tiers of subnets in its availability zone.
+		if len(m.AWSMachine.Spec.SubnetTags) > 0 && len(subnets) > 1 {
+			return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, %d subnets in availability zone %q match the subnet tags %v, expected exactly one",
+				m.Name(), len(subnets), *failureDomain, m.AWSMachine.Spec.SubnetTags))
+		}
+	}
+	if len(subnets) == 0 {
+		return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, no subnets available%s", m.Name(), m.subnetSelectionSuffix()))
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(m.Name()))
+	return subnets[int(hash.Sum32()%uint32(len(subnets)))].ID, nil
+}
+
+// explicitSubnetID checks the subnet the AWSMachine names against the failure domain.
+func (m *MachineScope) explicitSubnetID(id string) (string, error) {
+	failureDomain := m.GetFailureDomain()
+	if failureDomain == nil {
+		return id, nil
+	}
+
+	subnet := m.InfraCluster.Subnets().FindByID(id)
+	if subnet == nil {
+		return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, subnet with id %q not found", m.Name(), id))
+	}
+	if subnet.AvailabilityZone != *failureDomain {
+		return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, subnet's availability zone %q does not match with the failure domain %q",
+			m.Name(), subnet.AvailabilityZone, *failureDomain))
+	}
+	return subnet.ID, nil
+}
+
+// filteredSubnetID picks one of the subnets of the VPC matching the filters of the AWSMachine.
+func (m *MachineScope) filteredSubnetID(describer SubnetDescriber, ref *infrav1.AWSResourceReference) (string, error) {
+	var filters []infrav1.Filter
+	if failureDomain := m.GetFailureDomain(); failureDomain != nil {
+		filters = append(filters, infrav1.Filter{Name: "availability-zone", Values: []string{*failureDomain}})
+	}
+	if outpostARN := m.AWSMachine.Spec.OutpostARN; outpostARN != "" {
+		filters = append(filters, infrav1.Filter{Name: "outpost-arn", Values: []string{outpostARN}})
+	}
+	filters = append(filters, ref.Filters...)
+
+	subnets, err := describer.DescribeSubnets(filters...)
+	if err != nil {
+		return "", err
+	}
+	if len(subnets) == 0 {
+		return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, no subnets available matching filters %q", m.Name(), ref.Filters))
+	}
+
+	if ref.FilterSelectionScheme != nil && *ref.FilterSelectionScheme == infrav1.FilterSelectionSchemeRandom {
+		roll, err := rand.Int(rand.Reader, big.NewInt(int64(len(subnets))))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to select random subnet from list of filtered subnets")
+		}
+		return subnets[roll.Int64()].ID, nil
+	}
+	return subnets[0].ID, nil
+}
+
+// subnetSelectionSuffix returns the subnet group and tags the machine is restricted to for use in error messages.
+func (m *MachineScope) subnetSelectionSuffix() string {
+	var suffix string
+	if m.AWSMachine.Spec.SubnetGroup != "" {
+		suffix = fmt.Sprintf(" in subnet group %q", m.AWSMachine.Spec.SubnetGroup)
+	}
+	if len(m.AWSMachine.Spec.SubnetTags) > 0 {
+		suffix += fmt.Sprintf(" matching the subnet tags %v", m.AWSMachine.Spec.SubnetTags)
+	}
+	return suffix
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// vpcSubnets stands in for EC2, describing the subnets of the VPC whatever the filters.
+type vpcSubnets struct {
+	subnets infrav1.Subnets
+	filters []infrav1.Filter
+}
+
+func (v *vpcSubnets) DescribeSubnets(filters ...infrav1.Filter) (infrav1.Subnets, error) {
+	v.filters = filters
+	return v.subnets, nil
+}
+
+func TestSubnetID(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-private-a", AvailabilityZone: "us-east-1a", Tags: infrav1.Tags{"tier": "app"}},
+		{ID: "subnet-private-b", AvailabilityZone: "us-east-1b", Tags: infrav1.Tags{"tier": "app"}},
+		{ID: "subnet-private-c", AvailabilityZone: "us-east-1c", Tags: infrav1.Tags{"tier": "db"}},
+		{ID: "subnet-private-d", AvailabilityZone: "us-east-1c", Tags: infrav1.Tags{"tier": "db"}},
+		{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+	}
+	filtered := infrav1.Subnets{
+		{ID: "subnet-filtered-public", AvailabilityZone: "us-east-1b", IsPublic: true},
+		{ID: "subnet-filtered-private", AvailabilityZone: "us-east-1b"},
+	}
+
+	testCases := []struct {
+		name        string
+		setup       func(m *MachineScope)
+		want        []string
+		wantFilters []infrav1.Filter
+		wantErr     bool
+		// Random selections may pick another subnet every time.
+		random bool
+	}{
+		{
+			name: "picks the private subnet of the failure domain",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1b")
+			},
+			want: []string{"subnet-private-b"},
+		},
+		{
+			name: "picks a public subnet for machines asking for a public IP",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1a")
+				m.AWSMachine.Spec.PublicIP = pointer.BoolPtr(true)
+			},
+			want: []string{"subnet-public-a"},
+		},
+		{
+			name:  "picks one of the private subnets without failure domain",
+			setup: func(m *MachineScope) {},
+			want:  []string{"subnet-private-a", "subnet-private-b", "subnet-private-c", "subnet-private-d"},
+		},
+		{
+			name: "picks the subnet of the AWSMachine",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{ID: pointer.StringPtr("subnet-private-c")}
+			},
+			want: []string{"subnet-private-c"},
+		},
+		{
+			name: "picks the subnet of the AWSMachine outside of the cluster's network",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{ID: pointer.StringPtr("subnet-elsewhere")}
+			},
+			want: []string{"subnet-elsewhere"},
+		},
+		{
+			name: "picks the first of the subnets of the VPC matching the filters of the AWSMachine",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1b")
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{Filters: []infrav1.Filter{{Name: "cidr-block", Values: []string{"10.0.0.0/24"}}}}
+			},
+			want: []string{"subnet-filtered-public"},
+			wantFilters: []infrav1.Filter{
+				{Name: "availability-zone", Values: []string{"us-east-1b"}},
+				{Name: "cidr-block", Values: []string{"10.0.0.0/24"}},
+			},
+		},
+		{
+			name: "looks up the subnets of the Outpost of the AWSMachine",
+			setup: func(m *MachineScope) {
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{Filters: []infrav1.Filter{{Name: "tag:tier", Values: []string{"edge"}}}}
+				m.AWSMachine.Spec.OutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+			},
+			want: []string{"subnet-filtered-public"},
+			wantFilters: []infrav1.Filter{
+				{Name: "outpost-arn", Values: []string{"arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"}},
+				{Name: "tag:tier", Values: []string{"edge"}},
+			},
+		},
+		{
+			name: "picks one of the subnets of the VPC matching the filters of the AWSMachine at random",
+			setup: func(m *MachineScope) {
+				random := infrav1.FilterSelectionSchemeRandom
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{
+					Filters:               []infrav1.Filter{{Name: "tag:tier", Values: []string{"app"}}},
+					FilterSelectionScheme: &random,
+				}
+			},
+			want:   []string{"subnet-filtered-public", "subnet-filtered-private"},
+			random: true,
+		},
+		{
+			name: "picks the subnet of the failure domain matching the subnet tags",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1a")
+				m.AWSMachine.Spec.SubnetTags = infrav1.Tags{"tier": "app"}
+			},
+			want: []string{"subnet-private-a"},
+		},
+		{
+			name: "fails for subnet tags matching several subnets of the failure domain",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1c")
+				m.AWSMachine.Spec.SubnetTags = infrav1.Tags{"tier": "db"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for a subnet of the AWSMachine outside of the failure domain",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1a")
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{ID: pointer.StringPtr("subnet-private-c")}
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for a subnet of the AWSMachine outside of the cluster's network in a failure domain",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1a")
+				m.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{ID: pointer.StringPtr("subnet-elsewhere")}
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails without a subnet in the failure domain",
+			setup: func(m *MachineScope) {
+				m.Machine.Spec.FailureDomain = pointer.StringPtr("us-east-1d")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := setupMachineScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			scope.InfraCluster.(*ClusterScope).AWSCluster.Spec.NetworkSpec.Subnets = subnets
+			tc.setup(scope)
+
+			vpc := &vpcSubnets{subnets: filtered}
+			id, err := scope.SubnetID(vpc)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got subnet %q", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			found := false
+			for _, want := range tc.want {
+				found = found || id == want
+			}
+			if !found {
+				t.Fatalf("Expected one of the subnets %v, got %q", tc.want, id)
+			}
+			if tc.wantFilters != nil && !reflect.DeepEqual(vpc.filters, tc.wantFilters) {
+				t.Fatalf("Expected the subnets to be described with the filters %v, got %v", tc.wantFilters, vpc.filters)
+			}
+
+			// The same machine always goes into the same subnet.
+			if again, _ := scope.SubnetID(vpc); !tc.random && again != id {
+				t.Fatalf("Expected subnet %q again, got %q", id, again)
+			}
+		})
+	}
+}
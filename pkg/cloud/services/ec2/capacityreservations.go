@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+)
+
+// GetCapacityReservationEndDate returns when the given capacity reservation ends, or nil if the
+// capacity reservation has no end date.
+func (s *Service) GetCapacityReservationEndDate(id string) (*time.Time, error) {
+	out, err := s.EC2Client.DescribeCapacityReservations(&ec2.DescribeCapacityReservationsInput{
+		CapacityReservationIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe capacity reservation %q", id)
+	}
+	if len(out.CapacityReservations) == 0 {
+		return nil, errors.Errorf("capacity reservation %q not found", id)
+	}
+
+	reservation := out.CapacityReservations[0]
+	if aws.StringValue(reservation.EndDateType) != ec2.EndDateTypeLimited {
+		return nil, nil
+	}
+	return reservation.EndDate, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestGetCapacityReservationEndDate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	end := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		reservation *ec2.CapacityReservation
+		want        *time.Time
+		wantErr     bool
+	}{
+		{
+			name: "returns the end date of a time-bounded reservation",
+			reservation: &ec2.CapacityReservation{
+				CapacityReservationId: aws.String("cr-1"),
+				EndDateType:           aws.String(ec2.EndDateTypeLimited),
+				EndDate:               aws.Time(end),
+			},
+			want: &end,
+		},
+		{
+			name: "returns no end date for an unlimited reservation",
+			reservation: &ec2.CapacityReservation{
+				CapacityReservationId: aws.String("cr-1"),
+				EndDateType:           aws.String(ec2.EndDateTypeUnlimited),
+			},
+		},
+		{
+			name:    "fails for a reservation that does not exist",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			out := &ec2.DescribeCapacityReservationsOutput{}
+			if tc.reservation != nil {
+				out.CapacityReservations = []*ec2.CapacityReservation{tc.reservation}
+			}
+			ec2Mock.EXPECT().DescribeCapacityReservations(gomock.Eq(&ec2.DescribeCapacityReservationsInput{
+				CapacityReservationIds: []*string{aws.String("cr-1")},
+			})).Return(out, nil)
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.GetCapacityReservationEndDate("cr-1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if (got == nil) != (tc.want == nil) || (got != nil && !got.Equal(*tc.want)) {
+				t.Fatalf("expected end date %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
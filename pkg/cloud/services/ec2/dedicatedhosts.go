@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+)
+
+// selectDedicatedHost returns the ID of the available Dedicated Host with auto-placement turned on in
+// the availability zone of the subnet that has the fewest, but at least one, free slots left for the
+// instance type, so that the hosts in use are filled before empty ones. It returns nil if no host has
+// a free slot, leaving the choice to EC2.
+func (s *Service) selectDedicatedHost(instanceType, subnetID string) (*string, error) {
+	subnet := s.scope.Subnets().FindByID(subnetID)
+	if subnet == nil || subnet.AvailabilityZone == "" {
+		// The zone is unknown for subnets that are not part of the cluster's network, let EC2 choose.
+		return nil, nil
+	}
+
+	var selected *ec2.Host
+	var selectedFree int64
+	if err := s.EC2Client.DescribeHostsPages(&ec2.DescribeHostsInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.HostStates(ec2.AllocationStateAvailable),
+			filter.EC2.HostAutoPlacement(ec2.AutoPlacementOn),
+			filter.EC2.AvailabilityZone(subnet.AvailabilityZone),
+		},
+	}, func(out *ec2.DescribeHostsOutput, _ bool) bool {
+		for _, host := range out.Hosts {
+			if !hostSupportsInstanceType(host, instanceType) {
+				continue
+			}
+			free := hostFreeSlots(host, instanceType)
+			if free == 0 {
+				continue
+			}
+			if selected == nil || free < selectedFree || (free == selectedFree && aws.StringValue(host.HostId) < aws.StringValue(selected.HostId)) {
+				selected, selectedFree = host, free
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe dedicated hosts in availability zone %q", subnet.AvailabilityZone)
+	}
+
+	if selected == nil {
+		s.scope.V(2).Info("No dedicated host has a free slot, leaving the host selection to EC2", "instance-type", instanceType, "availability-zone", subnet.AvailabilityZone)
+		return nil, nil
+	}
+	return selected.HostId, nil
+}
+
+// checkDedicatedHost returns an error if the Dedicated Host does not exist, does not support the
+// instance family of the instance type, or is not in the availability zone of the subnet.
+func (s *Service) checkDedicatedHost(hostID, instanceType, subnetID string) error {
+	out, err := s.EC2Client.DescribeHosts(&ec2.DescribeHostsInput{
+		HostIds: aws.StringSlice([]string{hostID}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe dedicated host %q", hostID)
+	}
+	if len(out.Hosts) == 0 {
+		return errors.Errorf("dedicated host %q not found", hostID)
+	}
+
+	host := out.Hosts[0]
+	if !hostSupportsInstanceType(host, instanceType) {
+		return errors.Errorf("instance type %q does not match the instance family %q of dedicated host %q",
+			instanceType, hostInstanceFamily(host), hostID)
+	}
+
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil && subnet.AvailabilityZone != "" &&
+		aws.StringValue(host.AvailabilityZone) != subnet.AvailabilityZone {
+		return errors.Errorf("dedicated host %q is in availability zone %q, but subnet %q is in %q",
+			hostID, aws.StringValue(host.AvailabilityZone), subnetID, subnet.AvailabilityZone)
+	}
+
+	return nil
+}
+
+// hostSupportsInstanceType returns true if instances of the instance type can run on the host.
+// Hosts support either all the sizes of an instance family or a single instance type.
+func hostSupportsInstanceType(host *ec2.Host, instanceType string) bool {
+	if host.HostProperties == nil {
+		return false
+	}
+	if t := aws.StringValue(host.HostProperties.InstanceType); t != "" {
+		return t == instanceType
+	}
+	return hostInstanceFamily(host) == instanceFamily(instanceType)
+}
+
+func hostInstanceFamily(host *ec2.Host) string {
+	if host.HostProperties == nil {
+		return ""
+	}
+	if family := aws.StringValue(host.HostProperties.InstanceFamily); family != "" {
+		return family
+	}
+	return instanceFamily(aws.StringValue(host.HostProperties.InstanceType))
+}
+
+// instanceFamily returns the family of an instance type, e.g. m5 for m5.xlarge.
+func instanceFamily(instanceType string) string {
+	return strings.SplitN(instanceType, ".", 2)[0]
+}
+
+// hostFreeSlots returns the number of instances of the instance type the host has room for.
+func hostFreeSlots(host *ec2.Host, instanceType string) int64 {
+	if host.AvailableCapacity == nil {
+		return 0
+	}
+	for _, capacity := range host.AvailableCapacity.AvailableInstanceCapacity {
+		if aws.StringValue(capacity.InstanceType) == instanceType {
+			return aws.Int64Value(capacity.AvailableCapacity)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func dedicatedHost(id, family, zone string, free int64) *ec2.Host {
+	return &ec2.Host{
+		HostId:           aws.String(id),
+		AvailabilityZone: aws.String(zone),
+		HostProperties:   &ec2.HostProperties{InstanceFamily: aws.String(family)},
+		AvailableCapacity: &ec2.AvailableCapacity{
+			AvailableInstanceCapacity: []*ec2.InstanceCapacity{
+				{InstanceType: aws.String(family + ".xlarge"), AvailableCapacity: aws.Int64(free)},
+			},
+		},
+	}
+}
+
+func newDedicatedHostsTestService(t *testing.T, ec2Mock *mock_ec2iface.MockEC2API) *Service {
+	t.Helper()
+
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("did not expect err: %v", err)
+	}
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+	return s
+}
+
+func TestSelectDedicatedHost(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeHosts := func(m *mock_ec2iface.MockEC2APIMockRecorder, hosts ...*ec2.Host) {
+		m.DescribeHostsPages(gomock.Eq(&ec2.DescribeHostsInput{
+			Filter: []*ec2.Filter{
+				filter.EC2.HostStates(ec2.AllocationStateAvailable),
+				filter.EC2.HostAutoPlacement(ec2.AutoPlacementOn),
+				filter.EC2.AvailabilityZone("us-east-1a"),
+			},
+		}), gomock.Any()).DoAndReturn(func(_ *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool) error {
+			fn(&ec2.DescribeHostsOutput{Hosts: hosts}, true)
+			return nil
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		subnetID string
+		expect   func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		want     *string
+	}{
+		{
+			name:     "selects the host with the fewest free slots",
+			subnetID: "subnet-1",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeHosts(m,
+					dedicatedHost("h-empty", "m5", "us-east-1a", 8),
+					dedicatedHost("h-full", "m5", "us-east-1a", 0),
+					dedicatedHost("h-other-family", "r5", "us-east-1a", 1),
+					dedicatedHost("h-used", "m5", "us-east-1a", 2),
+				)
+			},
+			want: aws.String("h-used"),
+		},
+		{
+			name:     "no host has a free slot, leaves the choice to EC2",
+			subnetID: "subnet-1",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeHosts(m, dedicatedHost("h-full", "m5", "us-east-1a", 0))
+			},
+		},
+		{
+			name:     "subnet is not part of the cluster network",
+			subnetID: "subnet-other",
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			s := newDedicatedHostsTestService(t, ec2Mock)
+			got, err := s.selectDedicatedHost("m5.xlarge", tc.subnetID)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if aws.StringValue(got) != aws.StringValue(tc.want) {
+				t.Fatalf("expected host %q, got %q", aws.StringValue(tc.want), aws.StringValue(got))
+			}
+		})
+	}
+}
+
+func TestCheckDedicatedHost(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name         string
+		instanceType string
+		host         *ec2.Host
+		wantError    bool
+	}{
+		{
+			name:         "host supports the instance family",
+			instanceType: "m5.xlarge",
+			host:         dedicatedHost("h-1", "m5", "us-east-1a", 1),
+		},
+		{
+			name:         "host is of another instance family",
+			instanceType: "m5.xlarge",
+			host:         dedicatedHost("h-1", "r5", "us-east-1a", 1),
+			wantError:    true,
+		},
+		{
+			name:         "host only supports another instance type",
+			instanceType: "m5.xlarge",
+			host: &ec2.Host{
+				HostId:           aws.String("h-1"),
+				AvailabilityZone: aws.String("us-east-1a"),
+				HostProperties:   &ec2.HostProperties{InstanceType: aws.String("m5.2xlarge")},
+			},
+			wantError: true,
+		},
+		{
+			name:         "host is in another availability zone",
+			instanceType: "m5.xlarge",
+			host:         dedicatedHost("h-1", "m5", "us-east-1b", 1),
+			wantError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeHosts(gomock.Eq(&ec2.DescribeHostsInput{
+				HostIds: aws.StringSlice([]string{"h-1"}),
+			})).Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{tc.host}}, nil)
+
+			s := newDedicatedHostsTestService(t, ec2Mock)
+			err := s.checkDedicatedHost("h-1", tc.instanceType, "subnet-1")
+			if tc.wantError && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const (
+	// sourceImageTagKey records the AMI an encrypted copy was made from.
+	sourceImageTagKey = infrav1.NameAWSProviderPrefix + "source-image"
+
+	// imageEncryptionKeyTagKey records the KMS key an encrypted copy was made with.
+	imageEncryptionKeyTagKey = infrav1.NameAWSProviderPrefix + "image-encryption-key"
+
+	// encryptedImageRetention is how long encrypted copies of AMIs other than the one currently in
+	// use are kept, so that recently replaced images stay available for rollbacks.
+	encryptedImageRetention = 7 * 24 * time.Hour
+)
+
+// encryptedImage returns the ID of an AMI encrypted with the given KMS key to launch instances from
+// in place of the given AMI. If the AMI is not encrypted with the key, the ID of an encrypted copy
+// is returned, which is made first if needed. An error is returned while the copy is in progress.
+func (s *Service) encryptedImage(imageID, kmsKey string) (string, error) {
+	out, err := s.EC2Client.DescribeImages(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{imageID})})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe AMI %q", imageID)
+	}
+	if len(out.Images) == 0 {
+		return "", errors.Errorf("AMI %q not found", imageID)
+	}
+	if imageEncryptedWithKey(out.Images[0], kmsKey) {
+		return imageID, nil
+	}
+
+	copies, err := s.describeEncryptedImageCopies(kmsKey)
+	if err != nil {
+		return "", err
+	}
+
+	var current *ec2.Image
+	for _, image := range copies {
+		if converters.TagsToMap(image.Tags)[sourceImageTagKey] == imageID {
+			current = image
+			break
+		}
+	}
+
+	if err := s.cleanupEncryptedImageCopies(copies, current); err != nil {
+		// Failing to clean up stale copies must not block new instances.
+		s.scope.Info("Failed to clean up stale encrypted AMI copies", "error", err.Error())
+	}
+
+	if current == nil {
+		copyID, err := s.copyEncryptedImage(out.Images[0], kmsKey)
+		if err != nil {
+			return "", err
+		}
+		return "", errors.Errorf("waiting for encrypted copy %q of AMI %q to become available", copyID, imageID)
+	}
+
+	switch state := aws.StringValue(current.State); state {
+	case ec2.ImageStateAvailable:
+		return aws.StringValue(current.ImageId), nil
+	case ec2.ImageStatePending:
+		return "", errors.Errorf("waiting for encrypted copy %q of AMI %q to become available", aws.StringValue(current.ImageId), imageID)
+	default:
+		return "", errors.Errorf("encrypted copy %q of AMI %q is in state %q", aws.StringValue(current.ImageId), imageID, state)
+	}
+}
+
+// imageEncryptedWithKey returns true if all EBS volumes of the AMI are encrypted with the KMS key,
+// which may be given as a key ID or ARN.
+func imageEncryptedWithKey(image *ec2.Image, kmsKey string) bool {
+	encrypted := false
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		keyID := aws.StringValue(mapping.Ebs.KmsKeyId)
+		if !aws.BoolValue(mapping.Ebs.Encrypted) || (keyID != kmsKey && !strings.HasSuffix(keyID, "/"+kmsKey)) {
+			return false
+		}
+		encrypted = true
+	}
+	return encrypted
+}
+
+// describeEncryptedImageCopies returns the encrypted AMI copies made for the cluster with the KMS key.
+func (s *Service) describeEncryptedImageCopies(kmsKey string) ([]*ec2.Image, error) {
+	out, err := s.EC2Client.DescribeImages(&ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{"self"}),
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			{
+				Name:   aws.String("tag:" + imageEncryptionKeyTagKey),
+				Values: aws.StringSlice([]string{kmsKey}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe encrypted AMI copies")
+	}
+
+	return out.Images, nil
+}
+
+// copyEncryptedImage starts copying the AMI into the cluster's region encrypted with the KMS key,
+// and tags the copy so that it can be found again. It returns the ID of the copy.
+func (s *Service) copyEncryptedImage(image *ec2.Image, kmsKey string) (string, error) {
+	imageID := aws.StringValue(image.ImageId)
+
+	// The client token makes sure concurrent reconciles of machines using the same AMI do not
+	// end up making several copies of it.
+	token := fmt.Sprintf("%x", sha256.Sum256([]byte(s.scope.Name()+"/"+imageID+"/"+kmsKey)))[:32]
+	out, err := s.EC2Client.CopyImage(&ec2.CopyImageInput{
+		ClientToken:   aws.String(token),
+		Name:          aws.String(fmt.Sprintf("%s-encrypted-%s", aws.StringValue(image.Name), token[:8])),
+		Description:   aws.String(fmt.Sprintf("Copy of %s encrypted for cluster %s", imageID, s.scope.Name())),
+		SourceImageId: aws.String(imageID),
+		SourceRegion:  aws.String(s.scope.Region()),
+		Encrypted:     aws.Bool(true),
+		KmsKeyId:      aws.String(kmsKey),
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCopyImage", "Failed to make encrypted copy of AMI %q: %v", imageID, err)
+		return "", errors.Wrapf(err, "failed to make encrypted copy of AMI %q", imageID)
+	}
+	copyID := aws.StringValue(out.ImageId)
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Additional: infrav1.Tags{
+			sourceImageTagKey:        imageID,
+			imageEncryptionKeyTagKey: kmsKey,
+		},
+	})
+	if _, err := s.EC2Client.CreateTags(&ec2.CreateTagsInput{
+		Resources: aws.StringSlice([]string{copyID}),
+		Tags:      converters.MapToTags(tags),
+	}); err != nil {
+		return "", errors.Wrapf(err, "failed to tag encrypted copy %q of AMI %q", copyID, imageID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCopyImage", "Started making encrypted copy %q of AMI %q", copyID, imageID)
+	return copyID, nil
+}
+
+// cleanupEncryptedImageCopies deregisters the encrypted AMI copies, other than the current one, that
+// are older than the retention period and no longer used by any instance, and deletes their snapshots.
+func (s *Service) cleanupEncryptedImageCopies(copies []*ec2.Image, current *ec2.Image) error {
+	for _, image := range copies {
+		if image == current {
+			continue
+		}
+
+		created, err := time.Parse(time.RFC3339, aws.StringValue(image.CreationDate))
+		if err != nil || time.Since(created) < encryptedImageRetention {
+			continue
+		}
+
+		imageID := aws.StringValue(image.ImageId)
+		inUse, err := s.imageInUse(imageID)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			continue
+		}
+
+		if _, err := s.EC2Client.DeregisterImage(&ec2.DeregisterImageInput{ImageId: image.ImageId}); err != nil {
+			return errors.Wrapf(err, "failed to deregister encrypted AMI copy %q", imageID)
+		}
+		for _, mapping := range image.BlockDeviceMappings {
+			if mapping.Ebs == nil || mapping.Ebs.SnapshotId == nil {
+				continue
+			}
+			if _, err := s.EC2Client.DeleteSnapshot(&ec2.DeleteSnapshotInput{SnapshotId: mapping.Ebs.SnapshotId}); err != nil {
+				return errors.Wrapf(err, "failed to delete snapshot %q of encrypted AMI copy %q", aws.StringValue(mapping.Ebs.SnapshotId), imageID)
+			}
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeregisterImage", "Deregistered stale encrypted AMI copy %q", imageID)
+	}
+
+	return nil
+}
+
+// imageInUse returns true if any instance that is not terminated was launched from the AMI.
+func (s *Service) imageInUse(imageID string) (bool, error) {
+	out, err := s.EC2Client.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("image-id"),
+				Values: aws.StringSlice([]string{imageID}),
+			},
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped, ec2.InstanceStateNameShuttingDown),
+		},
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe instances launched from AMI %q", imageID)
+	}
+
+	for _, reservation := range out.Reservations {
+		if len(reservation.Instances) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestEncryptedImage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const kmsKey = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	unencrypted := &ec2.Image{
+		ImageId: aws.String("ami-source"),
+		Name:    aws.String("capa-ami"),
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+			{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsBlockDevice{SnapshotId: aws.String("snap-source")}},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		want      string
+		wantError bool
+	}{
+		{
+			name: "AMI already encrypted with the key",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-source"})})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{
+						ImageId: aws.String("ami-source"),
+						BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+							{Ebs: &ec2.EbsBlockDevice{Encrypted: aws.Bool(true), KmsKeyId: aws.String(kmsKey)}},
+						},
+					}}}, nil)
+			},
+			want: "ami-source",
+		},
+		{
+			name: "encrypted copy available",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-source"})})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{unencrypted}}, nil)
+				m.DescribeImages(gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{
+						ImageId: aws.String("ami-copy"),
+						State:   aws.String(ec2.ImageStateAvailable),
+						Tags:    []*ec2.Tag{{Key: aws.String(sourceImageTagKey), Value: aws.String("ami-source")}},
+					}}}, nil)
+			},
+			want: "ami-copy",
+		},
+		{
+			name: "encrypted copy pending",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-source"})})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{unencrypted}}, nil)
+				m.DescribeImages(gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{
+						ImageId: aws.String("ami-copy"),
+						State:   aws.String(ec2.ImageStatePending),
+						Tags:    []*ec2.Tag{{Key: aws.String(sourceImageTagKey), Value: aws.String("ami-source")}},
+					}}}, nil)
+			},
+			wantError: true,
+		},
+		{
+			name: "no encrypted copy yet, stale copy cleaned up",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-source"})})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{unencrypted}}, nil)
+				m.DescribeImages(gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{
+						ImageId:      aws.String("ami-stale"),
+						State:        aws.String(ec2.ImageStateAvailable),
+						CreationDate: aws.String(time.Now().Add(-2 * encryptedImageRetention).Format(time.RFC3339)),
+						Tags:         []*ec2.Tag{{Key: aws.String(sourceImageTagKey), Value: aws.String("ami-old")}},
+						BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+							{Ebs: &ec2.EbsBlockDevice{SnapshotId: aws.String("snap-stale")}},
+						},
+					}}}, nil)
+				m.DescribeInstances(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{})).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+				m.DeregisterImage(gomock.Eq(&ec2.DeregisterImageInput{ImageId: aws.String("ami-stale")})).
+					Return(&ec2.DeregisterImageOutput{}, nil)
+				m.DeleteSnapshot(gomock.Eq(&ec2.DeleteSnapshotInput{SnapshotId: aws.String("snap-stale")})).
+					Return(&ec2.DeleteSnapshotOutput{}, nil)
+				m.CopyImage(gomock.AssignableToTypeOf(&ec2.CopyImageInput{})).
+					DoAndReturn(func(input *ec2.CopyImageInput) (*ec2.CopyImageOutput, error) {
+						if aws.StringValue(input.SourceImageId) != "ami-source" || !aws.BoolValue(input.Encrypted) || aws.StringValue(input.KmsKeyId) != kmsKey {
+							t.Errorf("unexpected CopyImage input: %v", input)
+						}
+						return &ec2.CopyImageOutput{ImageId: aws.String("ami-copy")}, nil
+					})
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.encryptedImage("ami-source", kmsKey)
+			if tc.wantError && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected image %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+)
+
+// checkInstanceProfile returns an error if the instance profile does not exist.
+func (s *Service) checkInstanceProfile(name string) error {
+	_, err := s.IAMClient.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(name)})
+	if err != nil {
+		if code, ok := awserrors.Code(errors.Cause(err)); ok && code == iam.ErrCodeNoSuchEntityException {
+			return errors.Errorf("instance profile %q does not exist", name)
+		}
+		return errors.Wrapf(err, "failed to get instance profile %q", name)
+	}
+	return nil
+}
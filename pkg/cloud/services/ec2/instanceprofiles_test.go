@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_iamiface"
+)
+
+func TestCheckInstanceProfile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name    string
+		err     error
+		wantErr string
+	}{
+		{
+			name: "instance profile exists",
+		},
+		{
+			name:    "instance profile does not exist",
+			err:     awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil),
+			wantErr: `instance profile "nodes" does not exist`,
+		},
+		{
+			name:    "instance profile cannot be looked up",
+			err:     awserr.New("AccessDenied", "denied", nil),
+			wantErr: `failed to get instance profile "nodes"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			iamMock := mock_iamiface.NewMockIAMAPI(mockCtrl)
+			iamMock.EXPECT().GetInstanceProfile(gomock.Eq(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String("nodes")})).
+				Return(&iam.GetInstanceProfileOutput{}, tc.err)
+
+			s := &Service{IAMClient: iamMock}
+			err := s.checkInstanceProfile("nodes")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -18,15 +18,14 @@ package ec2
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/base64"
 	"fmt"
-	"math/big"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
@@ -110,37 +109,30 @@ func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
 func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*infrav1.Instance, error) {
 	s.scope.V(2).Info("Creating an instance for a machine")
 
-	input := &infrav1.Instance{
-		Type:              scope.AWSMachine.Spec.InstanceType,
-		IAMProfile:        scope.AWSMachine.Spec.IAMInstanceProfile,
-		RootVolume:        scope.AWSMachine.Spec.RootVolume,
-		NonRootVolumes:    scope.AWSMachine.Spec.NonRootVolumes,
-		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
-	}
-
-	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
-	additionalTags := scope.AdditionalTags()
-
-	input.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(scope.Name()),
-		Role:        aws.String(scope.Role()),
-		Additional:  additionalTags,
-	}.WithCloudProvider(s.scope.Name()).WithMachineName(scope.Machine))
-
-	var err error
-	// Pick image from the machine configuration, or use a default one.
-	if scope.AWSMachine.Spec.AMI.ID != nil { // nolint:nestif
-		input.ImageID = *scope.AWSMachine.Spec.AMI.ID
-	} else {
-		if scope.Machine.Spec.Version == nil {
-			err := errors.New("Either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
+	subnets := &vpcSubnets{service: s}
+	input, err := scope.BuildInstanceSpec(subnets)
+	if err != nil {
+		// Errors in the spec fail the machine, while a missing cluster or subnet is only a matter of time.
+		switch {
+		case awserrors.IsFailedDependency(errors.Cause(err)):
+			record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: %v", err)
+		case scope.HasCluster():
 			scope.SetFailureReason(capierrors.CreateMachineError)
 			scope.SetFailureMessage(err)
+		}
+		return nil, err
+	}
+
+	// The instance profiles set on AWSMachines are left to RunInstances to check.
+	if scope.AWSMachine.Spec.IAMInstanceProfile == "" && input.IAMProfile != "" {
+		if err := s.checkInstanceProfile(input.IAMProfile); err != nil {
+			record.Warnf(scope.AWSMachine, "FailedCheckInstanceProfile", "Failed to check the default instance profile of the cluster: %v", err)
 			return nil, err
 		}
+	}
 
+	// Use the image of the machine configuration, or look up a default one.
+	if input.ImageID == "" { // nolint:nestif
 		imageLookupFormat := scope.AWSMachine.Spec.ImageLookupFormat
 		if imageLookupFormat == "" {
 			imageLookupFormat = scope.InfraCluster.ImageLookupFormat()
@@ -169,11 +161,43 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 		}
 	}
 
-	subnetID, err := s.findSubnet(scope)
+	if key := scope.AWSMachine.Spec.AMIEncryptionKey; key != "" {
+		input.ImageID, err = s.encryptedImage(input.ImageID, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subnet, err := subnets.find(input.SubnetID)
 	if err != nil {
 		return nil, err
 	}
-	input.SubnetID = subnetID
+	subnetID := subnet.ID
+
+	outpostARN := subnet.OutpostARN
+	if scope.AWSMachine.Spec.OutpostARN != "" && outpostARN != scope.AWSMachine.Spec.OutpostARN {
+		record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: subnet %q does not reside on outpost %q", subnetID, scope.AWSMachine.Spec.OutpostARN)
+		return nil, awserrors.NewFailedDependency(
+			fmt.Sprintf("failed to run machine %q, subnet %q does not reside on outpost %q", scope.Name(), subnetID, scope.AWSMachine.Spec.OutpostARN),
+		)
+	}
+	if outpostARN != "" {
+		// Outposts only offer the instance types they were provisioned with.
+		if err := s.validateOutpostInstanceType(outpostARN, input.Type); err != nil {
+			record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: %v", err)
+			return nil, err
+		}
+	}
+
+	// Draw the primary private IP from the subnet's pool, unless the instance uses existing network interfaces.
+	if len(input.NetworkInterfaces) == 0 && subnet.PrivateIPPool != nil {
+		ip, err := s.allocatePrivateIP(subnet)
+		if err != nil {
+			record.Warnf(scope.AWSMachine, "FailedAllocatePrivateIP", "Failed to allocate private IP from subnet %q: %v", subnetID, err)
+			return nil, err
+		}
+		input.PrivateIP = aws.String(ip)
+	}
 
 	if !scope.IsEKSManaged() && s.scope.Network().APIServerELB.DNSName == "" {
 		record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", "Failed to run controlplane, APIServer ELB not available")
@@ -187,6 +211,14 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 		}
 	}
 
+	if len(userData) > userdata.MaxUserDataSize {
+		if !scope.UseSecretsManager() {
+			return nil, errors.Errorf("user data is %d bytes, more than the %d bytes EC2 accepts, store it in a secrets backend by unsetting cloudInit.insecureSkipSecretsManager",
+				len(userData), userdata.MaxUserDataSize)
+		}
+		return nil, errors.Errorf("user data is %d bytes, more than the %d bytes EC2 accepts", len(userData), userdata.MaxUserDataSize)
+	}
+
 	input.UserData = pointer.StringPtr(base64.StdEncoding.EncodeToString(userData))
 
 	// Set security groups.
@@ -220,12 +252,35 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 		input.SSHKeyName = aws.String(prioritizedSSHKeyName)
 	}
 
-	input.SpotMarketOptions = scope.AWSMachine.Spec.SpotMarketOptions
+	// Instances in a dedicated VPC always run on dedicated hardware, so explicitly asking for shared
+	// hardware cannot be honored.
+	if s.scope.Network().VPCInstanceTenancy == ec2.TenancyDedicated && scope.AWSMachine.Spec.Tenancy == ec2.TenancyDefault {
+		return nil, errors.Errorf("machine tenancy %q conflicts with the %q instance tenancy of VPC %q",
+			scope.AWSMachine.Spec.Tenancy, ec2.TenancyDedicated, s.scope.VPC().ID)
+	}
 
-	input.Tenancy = scope.AWSMachine.Spec.Tenancy
+	if placement := scope.HostPlacement(); placement != nil {
+		input.HostAffinity = placement.Affinity
+		switch {
+		case placement.HostID != nil:
+			if err := s.checkDedicatedHost(*placement.HostID, input.Type, input.SubnetID); err != nil {
+				return nil, err
+			}
+			input.HostID = placement.HostID
+		case placement.Strategy == infrav1.HostPlacementStrategyPack:
+			hostID, err := s.selectDedicatedHost(input.Type, input.SubnetID)
+			if err != nil {
+				return nil, err
+			}
+			input.HostID = hostID
+		}
+	}
 
 	s.scope.V(2).Info("Running instance", "machine-role", scope.Role())
 	out, err := s.runInstance(scope.Role(), input)
+	if err != nil && awserrors.IsInsufficientCapacity(errors.Cause(err)) {
+		out, subnet, err = s.runInstanceWithCapacityFallback(scope, input, subnet, err)
+	}
 	if err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
@@ -244,119 +299,129 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 		}
 	}
 
-	record.Eventf(scope.AWSMachine, "SuccessfulCreate", "Created new %s instance with id %q", scope.Role(), out.ID)
-	return out, nil
-}
-
-// findSubnet attempts to retrieve a subnet ID in the following order:
-// - subnetID specified in machine configuration,
-// - subnet based on filters in machine configuration, returning a random result if
-//   `FilterSelectionScheme` is set to "Random" in the subnet spec and the first result otherwise.
-// - subnet based on the availability zone specified,
-// - default to the private subnets available, returning the first result.
-func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
-	// Check Machine.Spec.FailureDomain first as it's used by KubeadmControlPlane to spread machines across failure domains.
-	failureDomain := scope.Machine.Spec.FailureDomain
-	if failureDomain == nil {
-		failureDomain = scope.AWSMachine.Spec.FailureDomain
+	if input.PrivateIP != nil {
+		scope.SetAssignedPrivateIP(*input.PrivateIP)
 	}
+	scope.SetOutpostARN(outpostARN)
+	scope.SetImageID(input.ImageID)
+	scope.SetInstanceType(input.Type)
+	scope.SetFailureDomain(subnet.AvailabilityZone)
 
-	switch {
-	case scope.AWSMachine.Spec.Subnet != nil && scope.AWSMachine.Spec.Subnet.ID != nil:
-		if failureDomain != nil {
-			subnet := s.scope.Subnets().FindByID(*scope.AWSMachine.Spec.Subnet.ID)
-			if subnet == nil {
-				record.Warnf(scope.AWSMachine, "FailedCreate",
-					"Failed to create instance: subnet with id %q not found", aws.StringValue(scope.AWSMachine.Spec.Subnet.ID))
-				return "", awserrors.NewFailedDependency(
-					fmt.Sprintf("failed to run machine %q, subnet with id %q not found",
-						scope.Name(),
-						aws.StringValue(scope.AWSMachine.Spec.Subnet.ID),
-					),
-				)
-			}
+	scope.RecordInstanceCreated(out.ID)
+	return out, nil
+}
 
-			if subnet.AvailabilityZone != *failureDomain {
-				record.Warnf(scope.AWSMachine, "FailedCreate",
-					"Failed to create instance: subnet's availability zone %q does not match with the failure domain %q",
-					subnet.AvailabilityZone,
-					*failureDomain)
-				return "", awserrors.NewFailedDependency(
-					fmt.Sprintf("failed to run machine %q, subnet's availability zone %q does not match with the failure domain %q",
-						scope.Name(),
-						subnet.AvailabilityZone,
-						*failureDomain,
-					),
-				)
+// runInstanceWithCapacityFallback retries the launch of the instance, after the first attempt failed
+// for lack of capacity, in the availability zones configured for capacity fallback. It returns the
+// instance and the subnet it was launched into, or the error of the last attempt.
+func (s *Service) runInstanceWithCapacityFallback(scope *scope.MachineScope, input *infrav1.Instance, subnet *infrav1.SubnetSpec, err error) (*infrav1.Instance, *infrav1.SubnetSpec, error) {
+	fallback := scope.AWSMachine.Spec.CapacityFallback
+	// An explicit subnet or failure domain is never overridden, and a Dedicated Host pins the instance
+	// to the host's availability zone.
+	if fallback == nil || scope.AWSMachine.Spec.Subnet != nil || scope.GetFailureDomain() != nil || len(input.NetworkInterfaces) > 0 || input.HostID != nil {
+		return nil, subnet, err
+	}
+
+	candidates := s.capacityFallbackSubnets(scope, subnet)
+	for _, sn := range candidates {
+		record.Warnf(scope.AWSMachine, "InsufficientCapacity", "Insufficient capacity for instance type %q in availability zone %q, retrying in %q",
+			input.Type, subnet.AvailabilityZone, sn.AvailabilityZone)
+
+		input.SubnetID = sn.ID
+		input.PrivateIP = nil
+		if sn.PrivateIPPool != nil {
+			ip, err := s.allocatePrivateIP(sn)
+			if err != nil {
+				record.Warnf(scope.AWSMachine, "FailedAllocatePrivateIP", "Failed to allocate private IP from subnet %q: %v", sn.ID, err)
+				return nil, sn, err
 			}
+			input.PrivateIP = aws.String(ip)
 		}
-		return *scope.AWSMachine.Spec.Subnet.ID, nil
-	case scope.AWSMachine.Spec.Subnet != nil && scope.AWSMachine.Spec.Subnet.Filters != nil:
-		criteria := []*ec2.Filter{
-			filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-			filter.EC2.VPC(s.scope.VPC().ID),
+
+		subnet = sn
+		var out *infrav1.Instance
+		out, err = s.runInstance(scope.Role(), input)
+		if err == nil || !awserrors.IsInsufficientCapacity(errors.Cause(err)) {
+			return out, subnet, err
 		}
-		if failureDomain != nil {
-			criteria = append(criteria, filter.EC2.AvailabilityZone(*failureDomain))
+	}
+
+	return nil, subnet, err
+}
+
+// capacityFallbackSubnets returns a private subnet for every availability zone, other than the one
+// of the first launch attempt, that the launch may be retried in, in order of preference.
+func (s *Service) capacityFallbackSubnets(scope *scope.MachineScope, first *infrav1.SubnetSpec) []*infrav1.SubnetSpec {
+	fallback := scope.AWSMachine.Spec.CapacityFallback
+	subnets := s.scope.Subnets().FilterPrivate().FilterByOutpost(first.OutpostARN).FilterBySubnetGroup(scope.AWSMachine.Spec.SubnetGroup)
+
+	zones := fallback.AvailabilityZones
+	if len(zones) == 0 {
+		for _, sn := range subnets {
+			zones = append(zones, sn.AvailabilityZone)
 		}
-		for _, f := range scope.AWSMachine.Spec.Subnet.Filters {
-			criteria = append(criteria, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+	}
+
+	maxAttempts := len(zones) + 1
+	if fallback.MaxAttempts != nil {
+		maxAttempts = int(*fallback.MaxAttempts)
+	}
+
+	var candidates []*infrav1.SubnetSpec
+	tried := map[string]bool{first.AvailabilityZone: true}
+	for _, zone := range zones {
+		if len(candidates)+1 >= maxAttempts {
+			break
 		}
-		subnets, err := s.getFilteredSubnets(criteria...)
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to filter subnets for criteria %q", criteria)
-		}
-		if len(subnets) == 0 {
-			record.Warnf(scope.AWSMachine, "FailedCreate",
-				"Failed to create instance: no subnets available matching filters %q", scope.AWSMachine.Spec.Subnet.Filters)
-			return "", awserrors.NewFailedDependency(
-				fmt.Sprintf("failed to run machine %q, no subnets available matching filters %q",
-					scope.Name(),
-					scope.AWSMachine.Spec.Subnet.Filters,
-				),
-			)
+		if tried[zone] {
+			continue
 		}
+		tried[zone] = true
 
-		// If random subnet selection is expected, roll a random number and return
-		if scope.AWSMachine.Spec.Subnet.FilterSelectionScheme != nil &&
-			*scope.AWSMachine.Spec.Subnet.FilterSelectionScheme == infrav1.FilterSelectionSchemeRandom {
-			rollRandom, err := rand.Int(rand.Reader, big.NewInt(int64(len(subnets))))
-			if err != nil {
-				return "", errors.Wrapf(err, "failed to select random subnet from list of filtered subnets")
-			}
-
-			return *subnets[rollRandom.Int64()].SubnetId, nil
+		if zoneSubnets := subnets.FilterByZone(zone); len(zoneSubnets) > 0 {
+			candidates = append(candidates, zoneSubnets[0])
 		}
+	}
 
-		// Simply return first result if random selection is not expected
-		return *subnets[0].SubnetId, nil
+	return candidates
+}
 
-	case failureDomain != nil:
-		subnets := s.scope.Subnets().FilterPrivate().FilterByZone(*failureDomain)
-		if len(subnets) == 0 {
-			record.Warnf(scope.AWSMachine, "FailedCreate",
-				"Failed to create instance: no subnets available in availability zone %q", *failureDomain)
+// vpcSubnets describes the subnets of the cluster's VPC for the machine scope to pick from, and keeps
+// the subnets it described so the instance can be launched into the one picked.
+type vpcSubnets struct {
+	service   *Service
+	described infrav1.Subnets
+}
 
-			return "", awserrors.NewFailedDependency(
-				fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q",
-					scope.Name(),
-					*failureDomain,
-				),
-			)
-		}
-		return subnets[0].ID, nil
+// DescribeSubnets implements scope.SubnetDescriber.
+func (v *vpcSubnets) DescribeSubnets(filters ...infrav1.Filter) (infrav1.Subnets, error) {
+	criteria := []*ec2.Filter{
+		filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+		filter.EC2.VPC(v.service.scope.VPC().ID),
+	}
+	for _, f := range filters {
+		criteria = append(criteria, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+	}
+	out, err := v.service.getFilteredSubnets(criteria...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to filter subnets for criteria %q", criteria)
+	}
 
-		// TODO(vincepri): Define a tag that would allow to pick a preferred subnet in an AZ when working
-		// with control plane machines.
+	var subnets infrav1.Subnets
+	for _, sn := range out {
+		subnets = append(subnets, sdkToSubnet(sn))
+	}
+	v.described = append(v.described, subnets...)
+	return subnets, nil
+}
 
-	default:
-		sns := s.scope.Subnets().FilterPrivate()
-		if len(sns) == 0 {
-			record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", "Failed to run machine %q, no subnets available", scope.Name())
-			return "", awserrors.NewFailedDependency(fmt.Sprintf("failed to run machine %q, no subnets available", scope.Name()))
-		}
-		return sns[0].ID, nil
+// find returns the subnet with the given ID, out of the described subnets or the subnets of the cluster,
+// or looked up otherwise.
+func (v *vpcSubnets) find(subnetID string) (*infrav1.SubnetSpec, error) {
+	if subnet := v.described.FindByID(subnetID); subnet != nil {
+		return subnet, nil
 	}
+	return v.service.getSubnet(subnetID)
 }
 
 // getFilteredSubnets fetches subnets filtered based on the criteria passed
@@ -368,6 +433,33 @@ func (s *Service) getFilteredSubnets(criteria ...*ec2.Filter) ([]*ec2.Subnet, er
 	return out.Subnets, nil
 }
 
+// getSubnet returns the subnet with the given ID, looking it up if it is not one of the cluster's subnets.
+func (s *Service) getSubnet(subnetID string) (*infrav1.SubnetSpec, error) {
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil {
+		return subnet, nil
+	}
+
+	out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{subnetID})})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe subnet %q", subnetID)
+	}
+	if len(out.Subnets) == 0 {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("subnet %q not found", subnetID))
+	}
+
+	return sdkToSubnet(out.Subnets[0]), nil
+}
+
+// sdkToSubnet converts the details of an EC2 subnet needed to launch instances into it.
+func sdkToSubnet(sn *ec2.Subnet) *infrav1.SubnetSpec {
+	return &infrav1.SubnetSpec{
+		ID:               aws.StringValue(sn.SubnetId),
+		CidrBlock:        aws.StringValue(sn.CidrBlock),
+		AvailabilityZone: aws.StringValue(sn.AvailabilityZone),
+		OutpostARN:       aws.StringValue(sn.OutpostArn),
+	}
+}
+
 // GetCoreSecurityGroups looks up the security group IDs managed by this actuator
 // They are considered "core" to its proper functioning
 func (s *Service) GetCoreSecurityGroups(scope *scope.MachineScope) ([]string, error) {
@@ -490,13 +582,19 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		input.NetworkInterfaces = netInterfaces
 	} else {
 		input.SubnetId = aws.String(i.SubnetID)
+		input.PrivateIpAddress = i.PrivateIP
 
 		if len(i.SecurityGroupIDs) > 0 {
 			input.SecurityGroupIds = aws.StringSlice(i.SecurityGroupIDs)
 		}
 	}
 
-	if i.IAMProfile != "" {
+	switch {
+	case arn.IsARN(i.IAMProfile):
+		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{
+			Arn: aws.String(i.IAMProfile),
+		}
+	case i.IAMProfile != "":
 		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{
 			Name: aws.String(i.IAMProfile),
 		}
@@ -572,24 +670,21 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	}
 
 	if len(i.Tags) > 0 {
-		spec := &ec2.TagSpecification{ResourceType: aws.String(ec2.ResourceTypeInstance)}
-		// We need to sort keys for tests to work
-		keys := make([]string, 0, len(i.Tags))
-		for k := range i.Tags {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, key := range keys {
-			spec.Tags = append(spec.Tags, &ec2.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(i.Tags[key]),
-			})
-		}
+		input.TagSpecifications = append(input.TagSpecifications, &ec2.TagSpecification{
+			ResourceType: aws.String(ec2.ResourceTypeInstance),
+			Tags:         sortedTags(i.Tags),
+		})
+	}
 
-		input.TagSpecifications = append(input.TagSpecifications, spec)
+	if len(i.VolumeTags) > 0 {
+		input.TagSpecifications = append(input.TagSpecifications, &ec2.TagSpecification{
+			ResourceType: aws.String(ec2.ResourceTypeVolume),
+			Tags:         sortedTags(i.VolumeTags),
+		})
 	}
 
 	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.SpotMarketOptions)
+	input.CapacityReservationSpecification = getCapacityReservationSpecification(i.CapacityReservationPreference, i.CapacityReservationID)
 
 	if i.Tenancy != "" {
 		input.Placement = &ec2.Placement{
@@ -597,6 +692,29 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
+	if i.HostID != nil || i.HostAffinity != "" {
+		if input.Placement == nil {
+			input.Placement = &ec2.Placement{}
+		}
+		input.Placement.HostId = i.HostID
+		if i.HostAffinity != "" {
+			input.Placement.Affinity = aws.String(i.HostAffinity)
+		}
+	}
+
+	if i.PlacementGroupName != "" {
+		// The subnet decides the availability zone, which may differ between capacity fallback attempts.
+		if err := s.checkSpreadPlacementGroupCapacity(i.PlacementGroupName, i.SubnetID); err != nil {
+			return nil, err
+		}
+		if input.Placement == nil {
+			input.Placement = &ec2.Placement{}
+		}
+		input.Placement.GroupName = aws.String(i.PlacementGroupName)
+	}
+
+	input.MetadataOptions = getInstanceMetadataOptionsRequest(i.InstanceMetadataOptions)
+
 	out, err := s.EC2Client.RunInstances(input)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to run instance")
@@ -762,6 +880,24 @@ func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
 	return output.Images[0].BlockDeviceMappings[0].Ebs.VolumeSize, nil
 }
 
+// sortedTags converts the tags into EC2 tags sorted by key, we need to sort keys for tests to work.
+func sortedTags(tags map[string]string) []*ec2.Tag {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]*ec2.Tag, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, &ec2.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tags[key]),
+		})
+	}
+	return res
+}
+
 // SDKToInstance converts an AWS EC2 SDK instance to the CAPA instance type.
 // SDKToInstance populates all instance fields except for rootVolumeSize,
 // because EC2.DescribeInstances does not return the size of storage devices. An
@@ -794,6 +930,12 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		i.SecurityGroupIDs = append(i.SecurityGroupIDs, *sg.GroupId)
 	}
 
+	for _, mapping := range v.BlockDeviceMappings {
+		if mapping.Ebs != nil && mapping.Ebs.VolumeId != nil {
+			i.VolumeIDs = append(i.VolumeIDs, *mapping.Ebs.VolumeId)
+		}
+	}
+
 	if len(v.Tags) > 0 {
 		i.Tags = converters.TagsToMap(v.Tags)
 	}
@@ -801,6 +943,25 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i.Addresses = s.getInstanceAddresses(v)
 
 	i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+	i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+	i.CapacityReservationID = v.CapacityReservationId
+	i.HostID = v.Placement.HostId
+	i.HostAffinity = aws.StringValue(v.Placement.Affinity)
+
+	if spec := v.CapacityReservationSpecification; spec != nil {
+		i.CapacityReservationPreference = infrav1.CapacityReservationPreference(aws.StringValue(spec.CapacityReservationPreference))
+		if spec.CapacityReservationTarget != nil && spec.CapacityReservationTarget.CapacityReservationId != nil {
+			i.CapacityReservationPreference = infrav1.CapacityReservationPreferenceTargeted
+		}
+	}
+
+	if v.MetadataOptions != nil {
+		i.InstanceMetadataOptions = &infrav1.InstanceMetadataOptions{
+			HTTPEndpoint:            infrav1.InstanceMetadataState(aws.StringValue(v.MetadataOptions.HttpEndpoint)),
+			HTTPTokens:              infrav1.HTTPTokensState(aws.StringValue(v.MetadataOptions.HttpTokens)),
+			HTTPPutResponseHopLimit: aws.Int64Value(v.MetadataOptions.HttpPutResponseHopLimit),
+		}
+	}
 
 	return i, nil
 }
@@ -980,6 +1141,44 @@ func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOption
 	return instanceMarketOptionsRequest
 }
 
+func getCapacityReservationSpecification(preference infrav1.CapacityReservationPreference, reservationID *string) *ec2.CapacityReservationSpecification {
+	switch preference {
+	case "":
+		// Leave it to EC2, which consumes open capacity reservations.
+		return nil
+	case infrav1.CapacityReservationPreferenceTargeted:
+		return &ec2.CapacityReservationSpecification{
+			CapacityReservationTarget: &ec2.CapacityReservationTarget{
+				CapacityReservationId: reservationID,
+			},
+		}
+	}
+
+	return &ec2.CapacityReservationSpecification{
+		CapacityReservationPreference: aws.String(string(preference)),
+	}
+}
+
+func getInstanceMetadataOptionsRequest(options *infrav1.InstanceMetadataOptions) *ec2.InstanceMetadataOptionsRequest {
+	if options == nil {
+		// Keep the AWS defaults
+		return nil
+	}
+
+	request := &ec2.InstanceMetadataOptionsRequest{}
+	if options.HTTPEndpoint != "" {
+		request.SetHttpEndpoint(string(options.HTTPEndpoint))
+	}
+	if options.HTTPTokens != "" {
+		request.SetHttpTokens(string(options.HTTPTokens))
+	}
+	if options.HTTPPutResponseHopLimit != 0 {
+		request.SetHttpPutResponseHopLimit(options.HTTPPutResponseHopLimit)
+	}
+
+	return request
+}
+
 // GetFilteredSecurityGroupID get security group ID using filters
 func (s *Service) GetFilteredSecurityGroupID(securityGroup infrav1.AWSResourceReference) (string, error) {
 	if securityGroup.Filters == nil {
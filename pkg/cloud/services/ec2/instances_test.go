@@ -1077,11 +1077,477 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "subnet tags match several subnets of the failure domain",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:  "m5.large",
+				FailureDomain: aws.String("us-east-1b"),
+				SubnetTags:    infrav1.Tags{"tier": "app"},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{
+							{ID: "subnet-1", AvailabilityZone: "us-east-1b", Tags: infrav1.Tags{"tier": "app"}},
+							{ID: "subnet-2", AvailabilityZone: "us-east-1b", Tags: infrav1.Tags{"tier": "app", "team": "ml"}},
+							{ID: "subnet-3", AvailabilityZone: "us-east-1b", Tags: infrav1.Tags{"tier": "data"}},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "2 subnets in availability zone \"us-east-1b\" match the subnet tags map[tier:app], expected exactly one"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				}
+			},
+		},
 		{
 			name: "with multriple block device mappings",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				NonRootVolumes: []*infrav1.Volume{{
+					DeviceName: "device-2",
+					Size:       8,
+				}},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							&infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+									{
+										DeviceName: aws.String("device-2"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-2"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with a container runtime volume",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				NonRootVolumes: []*infrav1.Volume{{
+					DeviceName: "/dev/sdg",
+					Size:       8,
+				}},
+				ContainerRuntimeVolume: &infrav1.ContainerRuntimeVolume{
+					Volume: infrav1.Volume{DeviceName: "/dev/sdf", Size: 100, Type: "gp3"},
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						var devices []string
+						for _, mapping := range input.BlockDeviceMappings {
+							devices = append(devices, aws.StringValue(mapping.DeviceName))
+							if aws.StringValue(mapping.DeviceName) == "/dev/sdf" &&
+								(aws.Int64Value(mapping.Ebs.VolumeSize) != 100 || aws.StringValue(mapping.Ebs.VolumeType) != "gp3") {
+								t.Fatalf("unexpected container runtime volume mapping: %v", mapping)
+							}
+						}
+						if !reflect.DeepEqual(devices[len(devices)-2:], []string{"/dev/sdg", "/dev/sdf"}) {
+							t.Fatalf("expected the non root and container runtime volumes to be mapped, got %v", devices)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with volume tags",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				VolumeTags: infrav1.Tags{
+					"backup": "daily",
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					AdditionalTags: infrav1.Tags{
+						"team": "infra",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						tags := map[string]map[string]string{}
+						for _, spec := range input.TagSpecifications {
+							tags[aws.StringValue(spec.ResourceType)] = map[string]string{}
+							for _, tag := range spec.Tags {
+								tags[aws.StringValue(spec.ResourceType)][aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+							}
+						}
+						if want := map[string]string{"backup": "daily", "team": "infra"}; !reflect.DeepEqual(tags[ec2.ResourceTypeVolume], want) {
+							t.Fatalf("expected volume tags %v, got %v", want, tags[ec2.ResourceTypeVolume])
+						}
+						if _, ok := tags[ec2.ResourceTypeInstance]["backup"]; ok {
+							t.Fatalf("expected volume tags not to be applied to the instance, got %v", tags[ec2.ResourceTypeInstance])
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("/dev/xvda"),
+											Ebs:        &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-1")},
+										},
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if !reflect.DeepEqual(instance.VolumeIDs, []string{"vol-1"}) {
+					t.Fatalf("expected volume IDs [vol-1], got %v", instance.VolumeIDs)
+				}
+			},
+		},
+		{
+			name: "with default tenancy in a dedicated VPC",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Tenancy:      "default",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+						VPCInstanceTenancy: "dedicated",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil).AnyTimes()
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err == nil {
+					t.Fatalf("expected the tenancy conflict to be reported")
+				}
+			},
+		},
+		{
+			name: "with dedicated tenancy",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
@@ -1094,10 +1560,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				NonRootVolumes: []*infrav1.Volume{{
-					DeviceName: "device-2",
-					Size:       8,
-				}},
+				Tenancy:      "dedicated",
 			},
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
@@ -1143,7 +1606,46 @@ func TestCreateInstance(t *testing.T) {
 						},
 					}, nil)
 				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstances(gomock.Any()).
+					RunInstances(gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						Placement: &ec2.Placement{
+							Tenancy: &tenancy,
+						},
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
+							{
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
+									},
+								},
+							},
+						},
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userData)),
+					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -1165,15 +1667,10 @@ func TestCreateInstance(t *testing.T) {
 											VolumeId: aws.String("volume-1"),
 										},
 									},
-									{
-										DeviceName: aws.String("device-2"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-2"),
-										},
-									},
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
+									Tenancy:          &tenancy,
 								},
 							},
 						},
@@ -1188,7 +1685,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with dedicated tenancy",
+			name: "with the instance metadata service disabled",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:    map[string]string{"set": "node"},
@@ -1206,7 +1703,9 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Tenancy:      "dedicated",
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint: infrav1.InstanceMetadataEndpointStateDisabled,
+				},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
@@ -1258,8 +1757,8 @@ func TestCreateInstance(t *testing.T) {
 						KeyName:      aws.String("default"),
 						MaxCount:     aws.Int64(1),
 						MinCount:     aws.Int64(1),
-						Placement: &ec2.Placement{
-							Tenancy: &tenancy,
+						MetadataOptions: &ec2.InstanceMetadataOptionsRequest{
+							HttpEndpoint: aws.String(ec2.InstanceMetadataEndpointStateDisabled),
 						},
 						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
 						SubnetId:         aws.String("subnet-1"),
@@ -1316,7 +1815,11 @@ func TestCreateInstance(t *testing.T) {
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
-									Tenancy:          &tenancy,
+								},
+								MetadataOptions: &ec2.InstanceMetadataOptionsResponse{
+									HttpEndpoint:            aws.String(ec2.InstanceMetadataEndpointStateDisabled),
+									HttpTokens:              aws.String(ec2.HttpTokensStateOptional),
+									HttpPutResponseHopLimit: aws.Int64(1),
 								},
 							},
 						},
@@ -1328,6 +1831,79 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
+				if instance.InstanceMetadataOptions == nil || instance.InstanceMetadataOptions.HTTPEndpoint != infrav1.InstanceMetadataEndpointStateDisabled {
+					t.Fatalf("expected the instance metadata service to be reported as disabled, got %+v", instance.InstanceMetadataOptions)
+				}
+			},
+		},
+		{
+			name: "with the instance metadata service disabled on a control plane machine",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:    map[string]string{"set": "node", clusterv1.MachineControlPlaneLabelName: ""},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint: infrav1.InstanceMetadataEndpointStateDisabled,
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil).AnyTimes()
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err == nil {
+					t.Fatalf("expected disabling the instance metadata service on a control plane machine to be rejected")
+				}
 			},
 		},
 		{
@@ -1984,6 +2560,115 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with capacity fallback, retries in the next availability zone",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:     "m5.large",
+				CapacityFallback: &infrav1.CapacityFallbackOptions{},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+								IsPublic:         false,
+							},
+							&infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+								IsPublic:         false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				first := m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-1" {
+							t.Fatalf("expected first attempt in subnet %q, got %q", "subnet-1", aws.StringValue(input.SubnetId))
+						}
+						return nil, awserr.New(awserrors.InsufficientCapacity, "insufficient capacity", nil)
+					})
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-2" {
+							t.Fatalf("expected retry in subnet %q, got %q", "subnet-2", aws.StringValue(input.SubnetId))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-2"),
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: aws.String("us-east-1b"),
+									},
+								},
+							},
+						}, nil
+					}).
+					After(first)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.SubnetID != "subnet-2" {
+					t.Fatalf("expected instance in subnet %q, got %q", "subnet-2", instance.SubnetID)
+				}
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -2122,6 +2807,53 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	}
 }
 
+func TestGetCapacityReservationSpecification(t *testing.T) {
+	testCases := []struct {
+		name            string
+		preference      infrav1.CapacityReservationPreference
+		reservationID   *string
+		expectedRequest *ec2.CapacityReservationSpecification
+	}{
+		{
+			name:            "with no preference specified",
+			expectedRequest: nil,
+		},
+		{
+			name:       "with the open preference",
+			preference: infrav1.CapacityReservationPreferenceOpen,
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceOpen),
+			},
+		},
+		{
+			name:       "with the none preference",
+			preference: infrav1.CapacityReservationPreferenceNone,
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceNone),
+			},
+		},
+		{
+			name:          "with the targeted preference",
+			preference:    infrav1.CapacityReservationPreferenceTargeted,
+			reservationID: aws.String("cr-0123456789abcdef0"),
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationId: aws.String("cr-0123456789abcdef0"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getCapacityReservationSpecification(tc.preference, tc.reservationID)
+			if !reflect.DeepEqual(request, tc.expectedRequest) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, request, tc.expectedRequest)
+			}
+		})
+	}
+}
+
 func TestGetFilteredSecurityGroupID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+)
+
+// InstanceStatusChecksImpaired returns true if either the system or the instance status check
+// of the given instance reports it as impaired.
+func (s *Service) InstanceStatusChecksImpaired(instanceID string) (bool, error) {
+	input := &ec2.DescribeInstanceStatusInput{
+		InstanceIds:         []*string{aws.String(instanceID)},
+		IncludeAllInstances: aws.Bool(true),
+	}
+
+	out, err := s.EC2Client.DescribeInstanceStatus(input)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe status of instance %q", instanceID)
+	}
+
+	for _, status := range out.InstanceStatuses {
+		if status.SystemStatus != nil && aws.StringValue(status.SystemStatus.Status) == ec2.SummaryStatusImpaired {
+			return true, nil
+		}
+		if status.InstanceStatus != nil && aws.StringValue(status.InstanceStatus.Status) == ec2.SummaryStatusImpaired {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+)
+
+const (
+	// nvidiaGPUManufacturer is the manufacturer reported by DescribeInstanceTypes for NVIDIA GPUs.
+	nvidiaGPUManufacturer = "nvidia"
+
+	// defaultInstanceRequirementsArchitecture is the architecture of the instance types matching
+	// instance requirements that do not set one.
+	defaultInstanceRequirementsArchitecture = "x86_64"
+)
+
+// InstanceTypeHasNVIDIAGPUs returns true if the given instance type has at least one NVIDIA GPU.
+func (s *Service) InstanceTypeHasNVIDIAGPUs(instanceType string) (bool, error) {
+	input := &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	}
+
+	out, err := s.EC2Client.DescribeInstanceTypes(input)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+
+	if len(out.InstanceTypes) == 0 {
+		return false, errors.Errorf("no instance types returned when looking up %q", instanceType)
+	}
+
+	gpuInfo := out.InstanceTypes[0].GpuInfo
+	if gpuInfo == nil {
+		return false, nil
+	}
+
+	for _, gpu := range gpuInfo.Gpus {
+		if strings.EqualFold(aws.StringValue(gpu.Manufacturer), nvidiaGPUManufacturer) && aws.Int64Value(gpu.Count) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetInstanceTypesFromInstanceRequirements returns the current generation instance types matching the
+// machine's instance requirements that are offered in at least one of the availability zones the
+// machine can be launched in. Instance types offered in more of these zones come first, then the
+// smaller, and thus cheaper, ones.
+func (s *Service) GetInstanceTypesFromInstanceRequirements(scope *scope.MachineScope) ([]string, error) {
+	requirements := scope.InstanceRequirements()
+	if requirements == nil {
+		return nil, errors.Errorf("machine %q has no instance requirements", scope.Name())
+	}
+
+	architecture := requirements.Architecture
+	if architecture == "" {
+		architecture = defaultInstanceRequirementsArchitecture
+	}
+	usageClass := ec2.UsageClassTypeOnDemand
+	if _, spot := scope.GetSpotMarketOptions(); spot {
+		usageClass = ec2.UsageClassTypeSpot
+	}
+
+	matches := map[string]*ec2.InstanceTypeInfo{}
+	typesInput := &ec2.DescribeInstanceTypesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("current-generation"), Values: aws.StringSlice([]string{"true"})},
+			{Name: aws.String("processor-info.supported-architecture"), Values: aws.StringSlice([]string{architecture})},
+			{Name: aws.String("supported-usage-class"), Values: aws.StringSlice([]string{usageClass})},
+		},
+	}
+	if err := s.EC2Client.DescribeInstanceTypesPages(typesInput, func(out *ec2.DescribeInstanceTypesOutput, _ bool) bool {
+		for _, info := range out.InstanceTypes {
+			if instanceTypeMatchesRequirements(info, requirements) {
+				matches[aws.StringValue(info.InstanceType)] = info
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe instance types")
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	offeringsInput := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeRegion),
+	}
+	if zones := s.machineAvailabilityZones(scope); len(zones) > 0 {
+		offeringsInput.LocationType = aws.String(ec2.LocationTypeAvailabilityZone)
+		offeringsInput.Filters = []*ec2.Filter{
+			{Name: aws.String("location"), Values: aws.StringSlice(zones)},
+		}
+	}
+
+	offeredZones := map[string]int{}
+	if err := s.EC2Client.DescribeInstanceTypeOfferingsPages(offeringsInput, func(out *ec2.DescribeInstanceTypeOfferingsOutput, _ bool) bool {
+		for _, offering := range out.InstanceTypeOfferings {
+			if instanceType := aws.StringValue(offering.InstanceType); matches[instanceType] != nil {
+				offeredZones[instanceType]++
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe instance type offerings")
+	}
+
+	instanceTypes := make([]string, 0, len(offeredZones))
+	for instanceType := range offeredZones {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	sort.Slice(instanceTypes, func(i, j int) bool {
+		a, b := instanceTypes[i], instanceTypes[j]
+		if offeredZones[a] != offeredZones[b] {
+			return offeredZones[a] > offeredZones[b]
+		}
+		if vcpus, otherVCPUs := aws.Int64Value(matches[a].VCpuInfo.DefaultVCpus), aws.Int64Value(matches[b].VCpuInfo.DefaultVCpus); vcpus != otherVCPUs {
+			return vcpus < otherVCPUs
+		}
+		if memory, otherMemory := aws.Int64Value(matches[a].MemoryInfo.SizeInMiB), aws.Int64Value(matches[b].MemoryInfo.SizeInMiB); memory != otherMemory {
+			return memory < otherMemory
+		}
+		return a < b
+	})
+
+	return instanceTypes, nil
+}
+
+// machineAvailabilityZones returns the availability zones the machine can be launched in: the zone
+// of its failure domain or subnet if it has one, the zones of the cluster's private subnets otherwise.
+func (s *Service) machineAvailabilityZones(scope *scope.MachineScope) []string {
+	if failureDomain := scope.GetFailureDomain(); failureDomain != nil {
+		return []string{*failureDomain}
+	}
+
+	if scope.AWSMachine.Spec.Subnet != nil && scope.AWSMachine.Spec.Subnet.ID != nil {
+		if subnet := s.scope.Subnets().FindByID(*scope.AWSMachine.Spec.Subnet.ID); subnet != nil {
+			return []string{subnet.AvailabilityZone}
+		}
+	}
+
+	return s.scope.Subnets().FilterPrivate().GetUniqueZones()
+}
+
+// instanceTypeMatchesRequirements returns true if the instance type has the attributes described by the
+// instance requirements.
+func instanceTypeMatchesRequirements(info *ec2.InstanceTypeInfo, requirements *infrav1.InstanceRequirements) bool {
+	if info.VCpuInfo == nil || !requirements.VCPUCount.Contains(aws.Int64Value(info.VCpuInfo.DefaultVCpus)) {
+		return false
+	}
+	if info.MemoryInfo == nil || !requirements.MemoryMiB.Contains(aws.Int64Value(info.MemoryInfo.SizeInMiB)) {
+		return false
+	}
+
+	var gpus int64
+	if info.GpuInfo != nil {
+		for _, gpu := range info.GpuInfo.Gpus {
+			gpus += aws.Int64Value(gpu.Count)
+		}
+	}
+	if requirements.GPUCount == nil {
+		if gpus > 0 {
+			return false
+		}
+	} else if !requirements.GPUCount.Contains(gpus) {
+		return false
+	}
+
+	if requirements.NetworkBandwidthGbps != nil && !requirements.NetworkBandwidthGbps.Contains(networkBandwidthGbps(info.NetworkInfo)) {
+		return false
+	}
+
+	return true
+}
+
+// networkBandwidthGbps returns the network bandwidth of an instance type from the network performance
+// reported by DescribeInstanceTypes, e.g. "10 Gigabit" or "Up to 25 Gigabit". Instance types reporting a
+// relative performance, e.g. "Moderate", have a bandwidth of less than 1 Gbps.
+func networkBandwidthGbps(info *ec2.NetworkInfo) int64 {
+	if info == nil {
+		return 0
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(aws.StringValue(info.NetworkPerformance), "Up to "))
+	if len(fields) != 2 || fields[1] != "Gigabit" {
+		return 0
+	}
+	bandwidth, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+
+	return int64(bandwidth)
+}
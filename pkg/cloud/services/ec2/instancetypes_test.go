@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestInstanceTypeHasNVIDIAGPUs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		want      bool
+		wantError bool
+	}{
+		{
+			name: "instance type with NVIDIA GPUs",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{InstanceTypes: []*string{aws.String("p3.2xlarge")}}).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								GpuInfo: &ec2.GpuInfo{
+									Gpus: []*ec2.GpuDeviceInfo{
+										{Manufacturer: aws.String("NVIDIA"), Count: aws.Int64(1)},
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			want: true,
+		},
+		{
+			name: "instance type without GPUs",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.AssignableToTypeOf(&ec2.DescribeInstanceTypesInput{})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{{}},
+					}, nil)
+			},
+			want: false,
+		},
+		{
+			name: "instance type with GPUs from another manufacturer",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.AssignableToTypeOf(&ec2.DescribeInstanceTypesInput{})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								GpuInfo: &ec2.GpuInfo{
+									Gpus: []*ec2.GpuDeviceInfo{
+										{Manufacturer: aws.String("AMD"), Count: aws.Int64(4)},
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			want: false,
+		},
+		{
+			name: "describe error",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.AssignableToTypeOf(&ec2.DescribeInstanceTypesInput{})).
+					Return(nil, awserr.New("InvalidInstanceType", "unknown instance type", nil))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.InstanceTypeHasNVIDIAGPUs("p3.2xlarge")
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %t, expected %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetInstanceTypesFromInstanceRequirements(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	instanceTypes := []*ec2.InstanceTypeInfo{
+		{
+			InstanceType: aws.String("m5.xlarge"),
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(4)},
+			MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(16384)},
+			NetworkInfo:  &ec2.NetworkInfo{NetworkPerformance: aws.String("Up to 10 Gigabit")},
+		},
+		{
+			InstanceType: aws.String("m5.large"),
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)},
+			MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(8192)},
+			NetworkInfo:  &ec2.NetworkInfo{NetworkPerformance: aws.String("Up to 10 Gigabit")},
+		},
+		{
+			InstanceType: aws.String("c5.large"),
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)},
+			MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(4096)},
+			NetworkInfo:  &ec2.NetworkInfo{NetworkPerformance: aws.String("Up to 10 Gigabit")},
+		},
+		{
+			InstanceType: aws.String("t3.medium"),
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)},
+			MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(4096)},
+			NetworkInfo:  &ec2.NetworkInfo{NetworkPerformance: aws.String("Up to 5 Gigabit")},
+		},
+		{
+			InstanceType: aws.String("p3.2xlarge"),
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(8)},
+			MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(62464)},
+			GpuInfo: &ec2.GpuInfo{
+				Gpus: []*ec2.GpuDeviceInfo{{Manufacturer: aws.String("NVIDIA"), Count: aws.Int64(1)}},
+			},
+			NetworkInfo: &ec2.NetworkInfo{NetworkPerformance: aws.String("Up to 10 Gigabit")},
+		},
+	}
+	offerings := []*ec2.InstanceTypeOffering{
+		{InstanceType: aws.String("m5.xlarge"), Location: aws.String("us-east-1a")},
+		{InstanceType: aws.String("m5.xlarge"), Location: aws.String("us-east-1b")},
+		{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1a")},
+		{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1b")},
+		{InstanceType: aws.String("c5.large"), Location: aws.String("us-east-1a")},
+		{InstanceType: aws.String("p3.2xlarge"), Location: aws.String("us-east-1a")},
+	}
+
+	describeInstanceTypes := func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+		m.DescribeInstanceTypesPages(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("current-generation"), Values: aws.StringSlice([]string{"true"})},
+				{Name: aws.String("processor-info.supported-architecture"), Values: aws.StringSlice([]string{"x86_64"})},
+				{Name: aws.String("supported-usage-class"), Values: aws.StringSlice([]string{ec2.UsageClassTypeOnDemand})},
+			},
+		}), gomock.Any()).DoAndReturn(func(_ *ec2.DescribeInstanceTypesInput, fn func(*ec2.DescribeInstanceTypesOutput, bool) bool) error {
+			fn(&ec2.DescribeInstanceTypesOutput{InstanceTypes: instanceTypes[:3]}, false)
+			fn(&ec2.DescribeInstanceTypesOutput{InstanceTypes: instanceTypes[3:]}, true)
+			return nil
+		})
+	}
+	describeInstanceTypeOfferings := func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+		m.DescribeInstanceTypeOfferingsPages(gomock.Eq(&ec2.DescribeInstanceTypeOfferingsInput{
+			LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+			Filters: []*ec2.Filter{
+				{Name: aws.String("location"), Values: aws.StringSlice([]string{"us-east-1a", "us-east-1b"})},
+			},
+		}), gomock.Any()).DoAndReturn(func(_ *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool) error {
+			fn(&ec2.DescribeInstanceTypeOfferingsOutput{InstanceTypeOfferings: offerings}, true)
+			return nil
+		})
+	}
+
+	testCases := []struct {
+		name         string
+		requirements *infrav1.InstanceRequirements
+		expect       func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		want         []string
+	}{
+		{
+			name: "instance types offered in more zones come first, then smaller ones",
+			requirements: &infrav1.InstanceRequirements{
+				VCPUCount:            infrav1.IntegerRange{Min: 2},
+				MemoryMiB:            infrav1.IntegerRange{Min: 4096},
+				NetworkBandwidthGbps: &infrav1.IntegerRange{Min: 10},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstanceTypes(m)
+				describeInstanceTypeOfferings(m)
+			},
+			want: []string{"m5.large", "m5.xlarge", "c5.large"},
+		},
+		{
+			name: "instance types with GPUs are only picked when GPUs are required",
+			requirements: &infrav1.InstanceRequirements{
+				VCPUCount: infrav1.IntegerRange{Min: 2},
+				MemoryMiB: infrav1.IntegerRange{Min: 4096},
+				GPUCount:  &infrav1.IntegerRange{Min: 1},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstanceTypes(m)
+				describeInstanceTypeOfferings(m)
+			},
+			want: []string{"p3.2xlarge"},
+		},
+		{
+			name: "no instance type matches",
+			requirements: &infrav1.InstanceRequirements{
+				VCPUCount: infrav1.IntegerRange{Min: 2, Max: aws.Int64(2)},
+				MemoryMiB: infrav1.IntegerRange{Min: 32768},
+			},
+			expect: describeInstanceTypes,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewFakeClientWithScheme(scheme)
+
+			cluster := &clusterv1.Cluster{}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: cluster,
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+								{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+								{ID: "subnet-3", AvailabilityZone: "us-east-1c", IsPublic: true},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      &clusterv1.Machine{},
+				InfraCluster: clusterScope,
+				AWSMachine: &infrav1.AWSMachine{
+					Spec: infrav1.AWSMachineSpec{InstanceRequirements: tc.requirements},
+				},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.GetInstanceTypesFromInstanceRequirements(machineScope)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, expected %v", got, tc.want)
+			}
+		})
+	}
+}
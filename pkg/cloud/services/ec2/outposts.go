@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/pkg/errors"
+)
+
+// validateOutpostInstanceType returns an error if the instance type is not available on the Outpost.
+func (s *Service) validateOutpostInstanceType(outpostARN, instanceType string) error {
+	id, err := outpostID(outpostARN)
+	if err != nil {
+		return err
+	}
+
+	input := &outposts.GetOutpostInstanceTypesInput{OutpostId: aws.String(id)}
+	for {
+		out, err := s.OutpostsClient.GetOutpostInstanceTypes(input)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get instance types of outpost %q", outpostARN)
+		}
+
+		for _, item := range out.InstanceTypes {
+			if aws.StringValue(item.InstanceType) == instanceType {
+				return nil
+			}
+		}
+
+		if aws.StringValue(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	return errors.Errorf("instance type %q is not available on outpost %q", instanceType, outpostARN)
+}
+
+// outpostID returns the ID of the Outpost with the given ARN, which is formatted as
+// arn:aws:outposts:<region>:<account>:outpost/<id>.
+func outpostID(outpostARN string) (string, error) {
+	parsed, err := arn.Parse(outpostARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse outpost ARN %q", outpostARN)
+	}
+
+	id := strings.TrimPrefix(parsed.Resource, "outpost/")
+	if parsed.Service != "outposts" || id == parsed.Resource || id == "" {
+		return "", errors.Errorf("%q is not an outpost ARN", outpostARN)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/golang/mock/gomock"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_outpostsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+const testOutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+
+func TestValidateOutpostInstanceType(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name         string
+		outpostARN   string
+		instanceType string
+		expect       func(m *mock_outpostsiface.MockOutpostsAPIMockRecorder)
+		wantError    bool
+	}{
+		{
+			name:         "instance type available on a later page",
+			outpostARN:   testOutpostARN,
+			instanceType: "m5.xlarge",
+			expect: func(m *mock_outpostsiface.MockOutpostsAPIMockRecorder) {
+				m.GetOutpostInstanceTypes(&outposts.GetOutpostInstanceTypesInput{OutpostId: aws.String("op-0123456789abcdef0")}).
+					Return(&outposts.GetOutpostInstanceTypesOutput{
+						InstanceTypes: []*outposts.InstanceTypeItem{{InstanceType: aws.String("c5.large")}},
+						NextToken:     aws.String("next"),
+					}, nil)
+				m.GetOutpostInstanceTypes(&outposts.GetOutpostInstanceTypesInput{OutpostId: aws.String("op-0123456789abcdef0"), NextToken: aws.String("next")}).
+					Return(&outposts.GetOutpostInstanceTypesOutput{
+						InstanceTypes: []*outposts.InstanceTypeItem{{InstanceType: aws.String("m5.xlarge")}},
+					}, nil)
+			},
+		},
+		{
+			name:         "instance type not available",
+			outpostARN:   testOutpostARN,
+			instanceType: "p3.2xlarge",
+			expect: func(m *mock_outpostsiface.MockOutpostsAPIMockRecorder) {
+				m.GetOutpostInstanceTypes(gomock.AssignableToTypeOf(&outposts.GetOutpostInstanceTypesInput{})).
+					Return(&outposts.GetOutpostInstanceTypesOutput{
+						InstanceTypes: []*outposts.InstanceTypeItem{{InstanceType: aws.String("m5.xlarge")}},
+					}, nil)
+			},
+			wantError: true,
+		},
+		{
+			name:         "not an outpost ARN",
+			outpostARN:   "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1",
+			instanceType: "m5.xlarge",
+			expect:       func(m *mock_outpostsiface.MockOutpostsAPIMockRecorder) {},
+			wantError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outpostsMock := mock_outpostsiface.NewMockOutpostsAPI(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			tc.expect(outpostsMock.EXPECT())
+
+			s := NewService(scope)
+			s.OutpostsClient = outpostsMock
+
+			err = s.validateOutpostInstanceType(tc.outpostARN, tc.instanceType)
+			if tc.wantError && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+)
+
+// spreadPlacementGroupInstancesPerZone is the maximum number of running instances a spread placement
+// group holds in a single availability zone.
+const spreadPlacementGroupInstancesPerZone = 7
+
+// checkSpreadPlacementGroupCapacity returns an error if the placement group is not a spread placement
+// group, or if it already holds as many running instances as it can in the availability zone of the
+// subnet.
+func (s *Service) checkSpreadPlacementGroupCapacity(name, subnetID string) error {
+	groups, err := s.EC2Client.DescribePlacementGroups(&ec2.DescribePlacementGroupsInput{
+		GroupNames: aws.StringSlice([]string{name}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe placement group %q", name)
+	}
+	if len(groups.PlacementGroups) == 0 {
+		return errors.Errorf("placement group %q not found", name)
+	}
+	if strategy := aws.StringValue(groups.PlacementGroups[0].Strategy); strategy != ec2.PlacementStrategySpread {
+		return errors.Errorf("placement group %q has strategy %q, only %q placement groups are supported", name, strategy, ec2.PlacementStrategySpread)
+	}
+
+	subnet := s.scope.Subnets().FindByID(subnetID)
+	if subnet == nil || subnet.AvailabilityZone == "" {
+		// The zone is unknown for subnets that are not part of the cluster's network, let EC2 enforce the limit.
+		return nil
+	}
+
+	var running int
+	if err := s.EC2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.PlacementGroupName(name),
+			filter.EC2.AvailabilityZone(subnet.AvailabilityZone),
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
+		},
+	}, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			running += len(reservation.Instances)
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to describe instances in placement group %q", name)
+	}
+
+	if running >= spreadPlacementGroupInstancesPerZone {
+		return errors.Errorf("spread placement group %q already has %d running instances in availability zone %q, the maximum is %d",
+			name, running, subnet.AvailabilityZone, spreadPlacementGroupInstancesPerZone)
+	}
+
+	return nil
+}
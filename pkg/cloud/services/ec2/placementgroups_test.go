@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestCheckSpreadPlacementGroupCapacity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeGroup := func(m *mock_ec2iface.MockEC2APIMockRecorder, strategy string) {
+		m.DescribePlacementGroups(gomock.Eq(&ec2.DescribePlacementGroupsInput{
+			GroupNames: aws.StringSlice([]string{"critical"}),
+		})).Return(&ec2.DescribePlacementGroupsOutput{
+			PlacementGroups: []*ec2.PlacementGroup{
+				{GroupName: aws.String("critical"), Strategy: aws.String(strategy)},
+			},
+		}, nil)
+	}
+	describeInstances := func(m *mock_ec2iface.MockEC2APIMockRecorder, running int) {
+		m.DescribeInstancesPages(gomock.Eq(&ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				filter.EC2.PlacementGroupName("critical"),
+				filter.EC2.AvailabilityZone("us-east-1a"),
+				filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
+			},
+		}), gomock.Any()).DoAndReturn(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+			reservation := &ec2.Reservation{}
+			for i := 0; i < running; i++ {
+				reservation.Instances = append(reservation.Instances, &ec2.Instance{})
+			}
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{reservation}}, true)
+			return nil
+		})
+	}
+
+	testCases := []struct {
+		name      string
+		subnetID  string
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		wantError bool
+	}{
+		{
+			name:     "availability zone has room",
+			subnetID: "subnet-1",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeGroup(m, ec2.PlacementStrategySpread)
+				describeInstances(m, 6)
+			},
+		},
+		{
+			name:     "availability zone is full",
+			subnetID: "subnet-1",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeGroup(m, ec2.PlacementStrategySpread)
+				describeInstances(m, 7)
+			},
+			wantError: true,
+		},
+		{
+			name:     "placement group is not a spread placement group",
+			subnetID: "subnet-1",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeGroup(m, ec2.PlacementStrategyCluster)
+			},
+			wantError: true,
+		},
+		{
+			name:     "subnet is not part of the cluster network",
+			subnetID: "subnet-other",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeGroup(m, ec2.PlacementStrategySpread)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.checkSpreadPlacementGroupCapacity("critical", tc.subnetID)
+			if tc.wantError && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
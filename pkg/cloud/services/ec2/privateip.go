@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// privateIPReservationTTL is how long an address handed out from a private IP pool is held back
+// from other machines, covering the time until the instance's network interface shows up in EC2.
+const privateIPReservationTTL = 5 * time.Minute
+
+// privateIPReservations tracks the addresses recently handed out from private IP pools, keyed by
+// subnet ID and address, so that concurrent reconciles never pick the same address.
+var privateIPReservations = struct {
+	sync.Mutex
+	until map[string]time.Time
+}{until: map[string]time.Time{}}
+
+// allocatePrivateIP returns the first address of the subnet's private IP pool that is neither in
+// use by a network interface in the subnet nor recently handed out to another machine.
+func (s *Service) allocatePrivateIP(subnet *infrav1.SubnetSpec) (string, error) {
+	pool := subnet.PrivateIPPool
+	if errs := pool.Validate(subnet.CidrBlock, field.NewPath("privateIPPool")); len(errs) > 0 {
+		return "", errors.Wrapf(errs.ToAggregate(), "invalid private IP pool for subnet %q", subnet.ID)
+	}
+
+	used, err := s.getSubnetPrivateIPs(subnet.ID)
+	if err != nil {
+		return "", err
+	}
+
+	privateIPReservations.Lock()
+	defer privateIPReservations.Unlock()
+
+	now := time.Now()
+	for key, until := range privateIPReservations.until {
+		if now.After(until) {
+			delete(privateIPReservations.until, key)
+		}
+	}
+
+	start := binary.BigEndian.Uint32(net.ParseIP(pool.Start).To4())
+	end := binary.BigEndian.Uint32(net.ParseIP(pool.End).To4())
+	for n := start; n <= end && n >= start; n++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, n)
+
+		key := subnet.ID + "/" + ip.String()
+		if _, ok := used[ip.String()]; ok {
+			continue
+		}
+		if _, ok := privateIPReservations.until[key]; ok {
+			continue
+		}
+
+		privateIPReservations.until[key] = now.Add(privateIPReservationTTL)
+		return ip.String(), nil
+	}
+
+	return "", errors.Errorf("no free address left in private IP pool %s-%s of subnet %q", pool.Start, pool.End, subnet.ID)
+}
+
+// getSubnetPrivateIPs returns the private IP addresses of all network interfaces in the subnet.
+func (s *Service) getSubnetPrivateIPs(subnetID string) (map[string]struct{}, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("subnet-id"),
+				Values: aws.StringSlice([]string{subnetID}),
+			},
+		},
+	}
+
+	used := map[string]struct{}{}
+	err := s.EC2Client.DescribeNetworkInterfacesPages(input, func(out *ec2.DescribeNetworkInterfacesOutput, _ bool) bool {
+		for _, eni := range out.NetworkInterfaces {
+			for _, addr := range eni.PrivateIpAddresses {
+				used[aws.StringValue(addr.PrivateIpAddress)] = struct{}{}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe network interfaces in subnet %q", subnetID)
+	}
+
+	return used, nil
+}
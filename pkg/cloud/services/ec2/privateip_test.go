@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestAllocatePrivateIP(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name      string
+		subnetID  string
+		pool      *infrav1.IPAddressRange
+		usedIPs   []string
+		want      []string
+		wantError bool
+	}{
+		{
+			name:     "skips addresses in use",
+			subnetID: "subnet-used",
+			pool:     &infrav1.IPAddressRange{Start: "10.0.0.10", End: "10.0.0.20"},
+			usedIPs:  []string{"10.0.0.10", "10.0.0.11"},
+			want:     []string{"10.0.0.12"},
+		},
+		{
+			name:     "does not hand out the same address twice",
+			subnetID: "subnet-reserved",
+			pool:     &infrav1.IPAddressRange{Start: "10.0.0.10", End: "10.0.0.20"},
+			want:     []string{"10.0.0.10", "10.0.0.11", "10.0.0.12"},
+		},
+		{
+			name:      "pool exhausted",
+			subnetID:  "subnet-full",
+			pool:      &infrav1.IPAddressRange{Start: "10.0.0.10", End: "10.0.0.11"},
+			usedIPs:   []string{"10.0.0.10", "10.0.0.11"},
+			want:      []string{},
+			wantError: true,
+		},
+		{
+			name:      "pool outside the subnet",
+			subnetID:  "subnet-invalid",
+			pool:      &infrav1.IPAddressRange{Start: "10.0.1.10", End: "10.0.1.20"},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			var addresses []*ec2.NetworkInterfacePrivateIpAddress
+			for _, ip := range tc.usedIPs {
+				addresses = append(addresses, &ec2.NetworkInterfacePrivateIpAddress{PrivateIpAddress: aws.String(ip)})
+			}
+			ec2Mock.EXPECT().
+				DescribeNetworkInterfacesPages(gomock.AssignableToTypeOf(&ec2.DescribeNetworkInterfacesInput{}), gomock.Any()).
+				Do(func(_ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) {
+					fn(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{{PrivateIpAddresses: addresses}},
+					}, true)
+				}).
+				Return(nil).
+				AnyTimes()
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			subnet := &infrav1.SubnetSpec{ID: tc.subnetID, CidrBlock: "10.0.0.0/24", PrivateIPPool: tc.pool}
+			for _, want := range tc.want {
+				got, err := s.allocatePrivateIP(subnet)
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if got != want {
+					t.Fatalf("got %q, expected %q", got, want)
+				}
+			}
+
+			_, err = s.allocatePrivateIP(subnet)
+			if tc.wantError && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package ec2
 
 import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/outposts/outpostsiface"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
@@ -32,6 +34,12 @@ type Service struct {
 
 	// SSMClient is used to look up the official EKS AMI ID
 	SSMClient ssmiface.SSMAPI
+
+	// OutpostsClient is used to look up the instance types available on an Outpost
+	OutpostsClient outpostsiface.OutpostsAPI
+
+	// IAMClient is used to check that the default instance profiles of the cluster exist
+	IAMClient iamiface.IAMAPI
 }
 
 // NewService returns a new service given the ec2 api client.
@@ -40,5 +48,8 @@ func NewService(clusterScope scope.EC2Scope) *Service {
 		scope:     clusterScope,
 		EC2Client: scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		SSMClient: scope.NewSSMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+
+		OutpostsClient: scope.NewOutpostsClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		IAMClient:      scope.NewIAMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 	}
 }
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// GetRootVolume returns the EBS root volume of the given instance along with the state of its latest
+// modification, or nil if the instance has no EBS root volume.
+func (s *Service) GetRootVolume(instanceID string) (*infrav1.RootVolumeStatus, error) {
+	out, err := s.EC2Client.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(instanceID)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance %q", instanceID)
+	}
+	if len(out.Reservations) == 0 || len(out.Reservations[0].Instances) == 0 {
+		return nil, errors.Errorf("instance %q not found", instanceID)
+	}
+
+	instance := out.Reservations[0].Instances[0]
+	var volumeID string
+	for _, mapping := range instance.BlockDeviceMappings {
+		if aws.StringValue(mapping.DeviceName) == aws.StringValue(instance.RootDeviceName) && mapping.Ebs != nil {
+			volumeID = aws.StringValue(mapping.Ebs.VolumeId)
+		}
+	}
+	if volumeID == "" {
+		return nil, nil
+	}
+
+	volumes, err := s.EC2Client.DescribeVolumes(&ec2.DescribeVolumesInput{
+		VolumeIds: []*string{aws.String(volumeID)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe volume %q", volumeID)
+	}
+	if len(volumes.Volumes) == 0 {
+		return nil, errors.Errorf("volume %q not found", volumeID)
+	}
+	volume := &infrav1.RootVolumeStatus{
+		ID:   volumeID,
+		Type: aws.StringValue(volumes.Volumes[0].VolumeType),
+	}
+
+	modifications, err := s.EC2Client.DescribeVolumesModifications(&ec2.DescribeVolumesModificationsInput{
+		VolumeIds: []*string{aws.String(volumeID)},
+	})
+	switch {
+	case awserrors.IsInvalidNotFoundError(err):
+		// The volume was never modified.
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to describe modifications of volume %q", volumeID)
+	default:
+		for _, modification := range modifications.VolumesModifications {
+			volume.ModificationState = aws.StringValue(modification.ModificationState)
+		}
+	}
+	return volume, nil
+}
+
+// ModifyVolumeType changes the type of the given volume in place. The volume stays usable while it is
+// being modified.
+func (s *Service) ModifyVolumeType(volumeID, volumeType string) error {
+	if _, err := s.EC2Client.ModifyVolume(&ec2.ModifyVolumeInput{
+		VolumeId:   aws.String(volumeID),
+		VolumeType: aws.String(volumeType),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedModifyVolume", "Failed to change the type of volume %q to %s: %v", volumeID, volumeType, err)
+		return errors.Wrapf(err, "failed to change the type of volume %q to %s", volumeID, volumeType)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulModifyVolume", "Changing the type of volume %q to %s", volumeID, volumeType)
+	return nil
+}
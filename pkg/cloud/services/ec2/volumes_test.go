@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestGetRootVolume(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInstance := func(m *mock_ec2iface.MockEC2APIMockRecorder, rootVolumeID string) {
+		instance := &ec2.Instance{
+			InstanceId:     aws.String("i-1"),
+			RootDeviceName: aws.String("/dev/xvda"),
+			BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+				{DeviceName: aws.String("/dev/sdf"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-data")}},
+			},
+		}
+		if rootVolumeID != "" {
+			instance.BlockDeviceMappings = append(instance.BlockDeviceMappings,
+				&ec2.InstanceBlockDeviceMapping{DeviceName: aws.String("/dev/xvda"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String(rootVolumeID)}})
+		}
+		m.DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+			InstanceIds: []*string{aws.String("i-1")},
+		})).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{instance}}},
+		}, nil)
+	}
+	describeVolume := func(m *mock_ec2iface.MockEC2APIMockRecorder, volumeType string) {
+		m.DescribeVolumes(gomock.Eq(&ec2.DescribeVolumesInput{
+			VolumeIds: []*string{aws.String("vol-root")},
+		})).Return(&ec2.DescribeVolumesOutput{
+			Volumes: []*ec2.Volume{{VolumeId: aws.String("vol-root"), VolumeType: aws.String(volumeType)}},
+		}, nil)
+	}
+
+	testCases := []struct {
+		name   string
+		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		want   *infrav1.RootVolumeStatus
+	}{
+		{
+			name: "returns the root volume of a volume that was never modified",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstance(m, "vol-root")
+				describeVolume(m, "gp2")
+				m.DescribeVolumesModifications(gomock.Eq(&ec2.DescribeVolumesModificationsInput{
+					VolumeIds: []*string{aws.String("vol-root")},
+				})).Return(nil, awserr.New(awserrors.VolumeModificationNotFound, "not found", nil))
+			},
+			want: &infrav1.RootVolumeStatus{ID: "vol-root", Type: "gp2"},
+		},
+		{
+			name: "returns the state of the modification of the root volume",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstance(m, "vol-root")
+				describeVolume(m, "gp3")
+				m.DescribeVolumesModifications(gomock.Eq(&ec2.DescribeVolumesModificationsInput{
+					VolumeIds: []*string{aws.String("vol-root")},
+				})).Return(&ec2.DescribeVolumesModificationsOutput{
+					VolumesModifications: []*ec2.VolumeModification{
+						{VolumeId: aws.String("vol-root"), ModificationState: aws.String(ec2.VolumeModificationStateOptimizing)},
+					},
+				}, nil)
+			},
+			want: &infrav1.RootVolumeStatus{ID: "vol-root", Type: "gp3", ModificationState: "optimizing"},
+		},
+		{
+			name: "returns no root volume for instances without an EBS root volume",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstance(m, "")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.GetRootVolume("i-1")
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected root volume %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
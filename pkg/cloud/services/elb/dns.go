@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// ReconcileDNSRecord points the Route53 alias record configured for the control plane load balancer,
+// if any, at the API server load balancer, recreating or updating the record when it is missing or
+// points at a load balancer that no longer exists.
+func (s *Service) ReconcileDNSRecord() error {
+	record := s.dnsRecord()
+	if record == nil {
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling load balancer DNS record", "name", record.Name)
+
+	apiELB := &s.scope.Network().APIServerELB
+	if apiELB.CanonicalHostedZoneID == "" {
+		// The hosted zone of a load balancer is only returned when describing it, not when creating it.
+		described, err := s.describeClassicELB(apiELB.Name)
+		if err != nil {
+			return err
+		}
+		apiELB.CanonicalHostedZoneID = described.CanonicalHostedZoneID
+	}
+
+	existing, err := s.describeDNSRecord(record)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.AliasTarget != nil &&
+		dnsNameEqual(aws.StringValue(existing.AliasTarget.DNSName), apiELB.DNSName) &&
+		aws.StringValue(existing.AliasTarget.HostedZoneId) == apiELB.CanonicalHostedZoneID {
+		return nil
+	}
+
+	if _, err := s.Route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(record.HostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("Managed by Cluster API Provider AWS for cluster " + s.scope.Name()),
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(route53.ChangeActionUpsert),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name: aws.String(record.Name),
+						Type: aws.String(route53.RRTypeA),
+						AliasTarget: &route53.AliasTarget{
+							DNSName:              aws.String(apiELB.DNSName),
+							HostedZoneId:         aws.String(apiELB.CanonicalHostedZoneID),
+							EvaluateTargetHealth: aws.Bool(false),
+						},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to point DNS record %q at load balancer %q", record.Name, apiELB.Name)
+	}
+
+	s.scope.V(2).Info("Pointed DNS record at load balancer", "name", record.Name, "dns-name", apiELB.DNSName)
+	return nil
+}
+
+// deleteDNSRecord deletes the Route53 alias record configured for the control plane load balancer,
+// if any.
+func (s *Service) deleteDNSRecord() error {
+	record := s.dnsRecord()
+	if record == nil {
+		return nil
+	}
+
+	existing, err := s.describeDNSRecord(record)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	if _, err := s.Route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(record.HostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action:            aws.String(route53.ChangeActionDelete),
+					ResourceRecordSet: existing,
+				},
+			},
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete DNS record %q", record.Name)
+	}
+
+	s.scope.V(2).Info("Deleted load balancer DNS record", "name", record.Name)
+	return nil
+}
+
+func (s *Service) dnsRecord() *infrav1.LoadBalancerDNSRecord {
+	if s.scope.ControlPlaneLoadBalancer() == nil {
+		return nil
+	}
+	return s.scope.ControlPlaneLoadBalancer().DNSRecord
+}
+
+// describeDNSRecord returns the A record set with the record's name, or nil if there is none.
+func (s *Service) describeDNSRecord(record *infrav1.LoadBalancerDNSRecord) (*route53.ResourceRecordSet, error) {
+	out, err := s.Route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(record.HostedZoneID),
+		StartRecordName: aws.String(record.Name),
+		StartRecordType: aws.String(route53.RRTypeA),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe DNS record %q", record.Name)
+	}
+
+	// Record sets are listed starting at the given name, so the first one may belong to another name.
+	for _, set := range out.ResourceRecordSets {
+		if dnsNameEqual(aws.StringValue(set.Name), record.Name) && aws.StringValue(set.Type) == route53.RRTypeA {
+			return set, nil
+		}
+	}
+	return nil, nil
+}
+
+// dnsNameEqual compares DNS names the way Route53 does, ignoring case and the trailing dot of
+// fully qualified names.
+func dnsNameEqual(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
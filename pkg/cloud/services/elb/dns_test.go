@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_route53iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestReconcileDNSRecord(t *testing.T) {
+	const (
+		zoneID     = "Z0123456789"
+		recordName = "api.bar.example.com"
+		elbDNSName = "bar-apiserver-123.us-east-1.elb.amazonaws.com"
+		elbZoneID  = "Z35SXDOTRQ7X7K"
+	)
+
+	listInput := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(recordName),
+		StartRecordType: aws.String(route53.RRTypeA),
+		MaxItems:        aws.String("1"),
+	}
+
+	tests := []struct {
+		name        string
+		route53Mock func(m *mock_route53iface.MockRoute53APIMockRecorder)
+	}{
+		{
+			name: "record already points at the load balancer",
+			route53Mock: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Eq(listInput)).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{{
+						Name: aws.String(recordName + "."),
+						Type: aws.String(route53.RRTypeA),
+						AliasTarget: &route53.AliasTarget{
+							DNSName:      aws.String(elbDNSName + "."),
+							HostedZoneId: aws.String(elbZoneID),
+						},
+					}},
+				}, nil)
+			},
+		},
+		{
+			name: "record points at a recreated load balancer",
+			route53Mock: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Eq(listInput)).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{{
+						Name: aws.String(recordName + "."),
+						Type: aws.String(route53.RRTypeA),
+						AliasTarget: &route53.AliasTarget{
+							DNSName:      aws.String("bar-apiserver-old.us-east-1.elb.amazonaws.com."),
+							HostedZoneId: aws.String(elbZoneID),
+						},
+					}},
+				}, nil)
+				m.ChangeResourceRecordSets(gomock.AssignableToTypeOf(&route53.ChangeResourceRecordSetsInput{})).
+					DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						change := input.ChangeBatch.Changes[0]
+						if aws.StringValue(input.HostedZoneId) != zoneID ||
+							aws.StringValue(change.Action) != route53.ChangeActionUpsert ||
+							aws.StringValue(change.ResourceRecordSet.Name) != recordName ||
+							aws.StringValue(change.ResourceRecordSet.AliasTarget.DNSName) != elbDNSName ||
+							aws.StringValue(change.ResourceRecordSet.AliasTarget.HostedZoneId) != elbZoneID {
+							t.Errorf("unexpected ChangeResourceRecordSets input: %v", input)
+						}
+						return &route53.ChangeResourceRecordSetsOutput{}, nil
+					})
+			},
+		},
+		{
+			name: "record does not exist",
+			route53Mock: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Eq(listInput)).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{{
+						Name: aws.String("other.bar.example.com."),
+						Type: aws.String(route53.RRTypeA),
+					}},
+				}, nil)
+				m.ChangeResourceRecordSets(gomock.AssignableToTypeOf(&route53.ChangeResourceRecordSetsInput{})).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+							DNSRecord: &infrav1.LoadBalancerDNSRecord{HostedZoneID: zoneID, Name: recordName},
+						},
+					},
+					Status: infrav1.AWSClusterStatus{
+						Network: infrav1.Network{
+							APIServerELB: infrav1.ClassicELB{
+								Name:                  "bar-apiserver",
+								DNSName:               elbDNSName,
+								CanonicalHostedZoneID: elbZoneID,
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.route53Mock(route53Mock.EXPECT())
+
+			s := &Service{
+				scope:         clusterScope,
+				Route53Client: route53Mock,
+			}
+
+			if err := s.ReconcileDNSRecord(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
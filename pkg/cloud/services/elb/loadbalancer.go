@@ -115,6 +115,10 @@ func (s *Service) ReconcileLoadbalancers() error {
 func (s *Service) DeleteLoadbalancers() error {
 	s.scope.V(2).Info("Deleting load balancers")
 
+	if err := s.deleteDNSRecord(); err != nil {
+		return err
+	}
+
 	elbs, err := s.listOwnedELBs()
 	if err != nil {
 		return err
@@ -360,6 +364,10 @@ func (s *Service) getAPIServerClassicELBSpec() (*infrav1.ClassicELB, error) {
 			return nil, err
 		}
 		for _, sn := range out.Subnets {
+			// The subnets of a shared VPC can only be used if their owner shared them with the cluster's account.
+			if owner := s.scope.VPC().OwnerAccountID; owner != "" && aws.StringValue(sn.OwnerId) != owner {
+				return nil, errors.Errorf("load balancer subnet %q is owned by account %q, expected it to be shared by account %q", aws.StringValue(sn.SubnetId), aws.StringValue(sn.OwnerId), owner)
+			}
 			res.AvailabilityZones = append(res.AvailabilityZones, *sn.AvailabilityZone)
 			res.SubnetIDs = append(res.SubnetIDs, *sn.SubnetId)
 		}
@@ -371,6 +379,13 @@ func (s *Service) getAPIServerClassicELBSpec() (*infrav1.ClassicELB, error) {
 			subnets = s.scope.Subnets().FilterPublic()
 		}
 
+		// Classic load balancers cannot be placed on Outposts.
+		subnets = subnets.FilterByOutpost("")
+
+		// Subnet groups route through their own default route and are reserved for the machines
+		// placed into them.
+		subnets = subnets.FilterBySubnetGroup("")
+
 	subnetLoop:
 		for _, sn := range subnets {
 			for _, az := range res.AvailabilityZones {
@@ -662,11 +677,12 @@ func (s *Service) reconcileELBTags(name string, desiredTags map[string]string) e
 
 func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBalancerAttributes) *infrav1.ClassicELB {
 	res := &infrav1.ClassicELB{
-		Name:             aws.StringValue(v.LoadBalancerName),
-		Scheme:           infrav1.ClassicELBScheme(*v.Scheme),
-		SubnetIDs:        aws.StringValueSlice(v.Subnets),
-		SecurityGroupIDs: aws.StringValueSlice(v.SecurityGroups),
-		DNSName:          aws.StringValue(v.DNSName),
+		Name:                  aws.StringValue(v.LoadBalancerName),
+		Scheme:                infrav1.ClassicELBScheme(*v.Scheme),
+		SubnetIDs:             aws.StringValueSlice(v.Subnets),
+		SecurityGroupIDs:      aws.StringValueSlice(v.SecurityGroups),
+		DNSName:               aws.StringValue(v.DNSName),
+		CanonicalHostedZoneID: aws.StringValue(v.CanonicalHostedZoneNameID),
 	}
 
 	if attrs.ConnectionSettings != nil && attrs.ConnectionSettings.IdleTimeout != nil {
@@ -84,10 +84,12 @@ func TestGenerateELBName(t *testing.T) {
 
 func TestGetAPIServerClassicELBSpec_ControlPlaneLoadBalancer(t *testing.T) {
 	tests := []struct {
-		name   string
-		lb     *infrav1.AWSLoadBalancerSpec
-		mocks  func(m *mock_ec2iface.MockEC2APIMockRecorder)
-		expect func(t *testing.T, res *infrav1.ClassicELB)
+		name           string
+		lb             *infrav1.AWSLoadBalancerSpec
+		ownerAccountID string
+		mocks          func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expect         func(t *testing.T, res *infrav1.ClassicELB)
+		wantErr        bool
 	}{
 		{
 			name:  "nil load balancer config",
@@ -145,6 +147,50 @@ func TestGetAPIServerClassicELBSpec_ControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "load balancer config with subnets shared by the owner of the VPC",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				Subnets: []string{"subnet-1"},
+			},
+			ownerAccountID: "111111111111",
+			mocks: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								OwnerId:          aws.String("111111111111"),
+							},
+						},
+					}, nil)
+			},
+			expect: func(t *testing.T, res *infrav1.ClassicELB) {
+				if len(res.SubnetIDs) != 1 {
+					t.Errorf("Expected load balancer to be configured for 1 subnet, got %v", len(res.SubnetIDs))
+				}
+			},
+		},
+		{
+			name: "load balancer config with subnets not shared by the owner of the VPC",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				Subnets: []string{"subnet-1"},
+			},
+			ownerAccountID: "111111111111",
+			mocks: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								OwnerId:          aws.String("222222222222"),
+							},
+						},
+					}, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "load balancer config with additional security groups specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -175,6 +221,9 @@ func TestGetAPIServerClassicELBSpec_ControlPlaneLoadBalancer(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					Spec: infrav1.AWSClusterSpec{
 						ControlPlaneLoadBalancer: tc.lb,
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{OwnerAccountID: tc.ownerAccountID},
+						},
 					},
 				},
 			})
@@ -190,6 +239,12 @@ func TestGetAPIServerClassicELBSpec_ControlPlaneLoadBalancer(t *testing.T) {
 			}
 
 			spec, err := s.getAPIServerClassicELBSpec()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
 			if err != nil {
 				t.Fatal(err)
 			}
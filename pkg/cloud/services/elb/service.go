@@ -19,7 +19,9 @@ package elb
 import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 )
@@ -31,7 +33,9 @@ type Service struct {
 	scope                 scope.ELBScope
 	EC2Client             ec2iface.EC2API
 	ELBClient             elbiface.ELBAPI
+	ELBV2Client           elbv2iface.ELBV2API
 	ResourceTaggingClient resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	Route53Client         route53iface.Route53API
 }
 
 // NewService returns a new service given the api clients.
@@ -40,6 +44,8 @@ func NewService(elbScope scope.ELBScope) *Service {
 		scope:                 elbScope,
 		EC2Client:             scope.NewEC2Client(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
 		ELBClient:             scope.NewELBClient(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
+		ELBV2Client:           scope.NewELBv2Client(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
 		ResourceTaggingClient: scope.NewResourgeTaggingClient(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
+		Route53Client:         scope.NewRoute53Client(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
 	}
 }
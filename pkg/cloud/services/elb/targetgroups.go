@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+)
+
+// InstanceIsRegisteredWithTargetGroup returns true if the instance is registered with the target group
+// on the target group's port, and is not being drained from it.
+func (s *Service) InstanceIsRegisteredWithTargetGroup(i *infrav1.Instance, tg *infrav1.MonitoringTargetGroup) (bool, error) {
+	out, err := s.ELBV2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(tg.ARN),
+		Targets:        []*elbv2.TargetDescription{targetDescription(i, tg)},
+	})
+	if err != nil {
+		if code, ok := awserrors.Code(err); ok && code == elbv2.ErrCodeTargetGroupNotFoundException {
+			return false, NewNotFound(fmt.Sprintf("no target group found with ARN %q", tg.ARN))
+		}
+		return false, errors.Wrapf(err, "failed to describe targets of target group %q", tg.ARN)
+	}
+
+	for _, target := range out.TargetHealthDescriptions {
+		if target.Target == nil || aws.StringValue(target.Target.Id) != i.ID || aws.Int64Value(target.Target.Port) != int64(tg.Port) {
+			continue
+		}
+		if target.TargetHealth == nil {
+			return true, nil
+		}
+		switch aws.StringValue(target.TargetHealth.State) {
+		case elbv2.TargetHealthStateEnumUnused, elbv2.TargetHealthStateEnumDraining:
+			// Targets that are not registered are reported as unused.
+			return false, nil
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// RegisterInstanceWithTargetGroup registers the instance with the target group on the target group's
+// port, after making sure the target group can route to the instance.
+func (s *Service) RegisterInstanceWithTargetGroup(i *infrav1.Instance, tg *infrav1.MonitoringTargetGroup) error {
+	if err := s.validateTargetGroup(tg); err != nil {
+		return err
+	}
+
+	if _, err := s.ELBV2Client.RegisterTargets(&elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(tg.ARN),
+		Targets:        []*elbv2.TargetDescription{targetDescription(i, tg)},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to register instance %q with target group %q", i.ID, tg.ARN)
+	}
+
+	return nil
+}
+
+// DeregisterInstanceFromTargetGroup deregisters the instance from the target group.
+func (s *Service) DeregisterInstanceFromTargetGroup(i *infrav1.Instance, tg *infrav1.MonitoringTargetGroup) error {
+	if _, err := s.ELBV2Client.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(tg.ARN),
+		Targets:        []*elbv2.TargetDescription{targetDescription(i, tg)},
+	}); err != nil {
+		if code, ok := awserrors.Code(err); ok && code == elbv2.ErrCodeTargetGroupNotFoundException {
+			return NewNotFound(fmt.Sprintf("no target group found with ARN %q", tg.ARN))
+		}
+		return errors.Wrapf(err, "failed to deregister instance %q from target group %q", i.ID, tg.ARN)
+	}
+
+	return nil
+}
+
+// validateTargetGroup makes sure the target group exists, routes to instances in the cluster's VPC
+// and, if the protocol is set, uses it.
+func (s *Service) validateTargetGroup(tg *infrav1.MonitoringTargetGroup) error {
+	out, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: aws.StringSlice([]string{tg.ARN}),
+	})
+	if err != nil {
+		if code, ok := awserrors.Code(err); ok && code == elbv2.ErrCodeTargetGroupNotFoundException {
+			return NewNotFound(fmt.Sprintf("no target group found with ARN %q", tg.ARN))
+		}
+		return errors.Wrapf(err, "failed to describe target group %q", tg.ARN)
+	}
+	if len(out.TargetGroups) == 0 {
+		return NewNotFound(fmt.Sprintf("no target group found with ARN %q", tg.ARN))
+	}
+
+	group := out.TargetGroups[0]
+	if targetType := aws.StringValue(group.TargetType); targetType != elbv2.TargetTypeEnumInstance {
+		return errors.Errorf("target group %q has target type %q, only the %q target type is supported", tg.ARN, targetType, elbv2.TargetTypeEnumInstance)
+	}
+	if vpcID := aws.StringValue(group.VpcId); s.scope.VPC().ID != "" && vpcID != s.scope.VPC().ID {
+		return errors.Errorf("target group %q is in VPC %q, not in the cluster's VPC %q", tg.ARN, vpcID, s.scope.VPC().ID)
+	}
+	if protocol := aws.StringValue(group.Protocol); tg.Protocol != "" && protocol != tg.Protocol {
+		return errors.Errorf("target group %q uses protocol %q, expected %q", tg.ARN, protocol, tg.Protocol)
+	}
+
+	return nil
+}
+
+func targetDescription(i *infrav1.Instance, tg *infrav1.MonitoringTargetGroup) *elbv2.TargetDescription {
+	return &elbv2.TargetDescription{
+		Id:   aws.String(i.ID),
+		Port: aws.Int64(int64(tg.Port)),
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbv2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+const targetGroupARN = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/metrics/0123456789abcdef"
+
+func TestInstanceIsRegisteredWithTargetGroup(t *testing.T) {
+	tg := &infrav1.MonitoringTargetGroup{ARN: targetGroupARN, Port: 9100}
+	describeInput := &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+		Targets: []*elbv2.TargetDescription{
+			{Id: aws.String("i-1234"), Port: aws.Int64(9100)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		elbv2Mock      func(m *mock_elbv2iface.MockELBV2APIMockRecorder)
+		wantRegistered bool
+		wantNotFound   bool
+	}{
+		{
+			name: "instance is healthy",
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealth(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetHealthOutput{
+					TargetHealthDescriptions: []*elbv2.TargetHealthDescription{{
+						Target:       &elbv2.TargetDescription{Id: aws.String("i-1234"), Port: aws.Int64(9100)},
+						TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+					}},
+				}, nil)
+			},
+			wantRegistered: true,
+		},
+		{
+			name: "instance is not registered",
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealth(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetHealthOutput{
+					TargetHealthDescriptions: []*elbv2.TargetHealthDescription{{
+						Target:       &elbv2.TargetDescription{Id: aws.String("i-1234"), Port: aws.Int64(9100)},
+						TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumUnused)},
+					}},
+				}, nil)
+			},
+		},
+		{
+			name: "instance is draining",
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealth(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetHealthOutput{
+					TargetHealthDescriptions: []*elbv2.TargetHealthDescription{{
+						Target:       &elbv2.TargetDescription{Id: aws.String("i-1234"), Port: aws.Int64(9100)},
+						TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumDraining)},
+					}},
+				}, nil)
+			},
+		},
+		{
+			name: "target group does not exist",
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealth(gomock.Eq(describeInput)).
+					Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+			},
+			wantNotFound: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+			tc.elbv2Mock(elbv2Mock.EXPECT())
+
+			s := &Service{
+				scope:       newTargetGroupTestScope(t),
+				ELBV2Client: elbv2Mock,
+			}
+
+			registered, err := s.InstanceIsRegisteredWithTargetGroup(&infrav1.Instance{ID: "i-1234"}, tg)
+			if tc.wantNotFound {
+				if !IsNotFound(err) {
+					t.Fatalf("expected a not found error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if registered != tc.wantRegistered {
+				t.Fatalf("expected registered to be %t, got %t", tc.wantRegistered, registered)
+			}
+		})
+	}
+}
+
+func TestRegisterInstanceWithTargetGroup(t *testing.T) {
+	describeInput := &elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: aws.StringSlice([]string{targetGroupARN}),
+	}
+
+	tests := []struct {
+		name        string
+		targetGroup *infrav1.MonitoringTargetGroup
+		elbv2Mock   func(m *mock_elbv2iface.MockELBV2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:        "registers the instance on the target group port",
+			targetGroup: &infrav1.MonitoringTargetGroup{ARN: targetGroupARN, Port: 9100, Protocol: "HTTP"},
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{{
+						TargetGroupArn: aws.String(targetGroupARN),
+						TargetType:     aws.String(elbv2.TargetTypeEnumInstance),
+						Protocol:       aws.String(elbv2.ProtocolEnumHttp),
+						VpcId:          aws.String("vpc-1234"),
+					}},
+				}, nil)
+				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+					TargetGroupArn: aws.String(targetGroupARN),
+					Targets: []*elbv2.TargetDescription{
+						{Id: aws.String("i-1234"), Port: aws.Int64(9100)},
+					},
+				})).Return(&elbv2.RegisterTargetsOutput{}, nil)
+			},
+		},
+		{
+			name:        "target group is in another VPC",
+			targetGroup: &infrav1.MonitoringTargetGroup{ARN: targetGroupARN, Port: 9100},
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{{
+						TargetGroupArn: aws.String(targetGroupARN),
+						TargetType:     aws.String(elbv2.TargetTypeEnumInstance),
+						VpcId:          aws.String("vpc-5678"),
+					}},
+				}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:        "target group routes to IP addresses",
+			targetGroup: &infrav1.MonitoringTargetGroup{ARN: targetGroupARN, Port: 9100},
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{{
+						TargetGroupArn: aws.String(targetGroupARN),
+						TargetType:     aws.String(elbv2.TargetTypeEnumIp),
+						VpcId:          aws.String("vpc-1234"),
+					}},
+				}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:        "target group uses another protocol",
+			targetGroup: &infrav1.MonitoringTargetGroup{ARN: targetGroupARN, Port: 9100, Protocol: "HTTPS"},
+			elbv2Mock: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(describeInput)).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{{
+						TargetGroupArn: aws.String(targetGroupARN),
+						TargetType:     aws.String(elbv2.TargetTypeEnumInstance),
+						Protocol:       aws.String(elbv2.ProtocolEnumHttp),
+						VpcId:          aws.String("vpc-1234"),
+					}},
+				}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+			tc.elbv2Mock(elbv2Mock.EXPECT())
+
+			s := &Service{
+				scope:       newTargetGroupTestScope(t),
+				ELBV2Client: elbv2Mock,
+			}
+
+			err := s.RegisterInstanceWithTargetGroup(&infrav1.Instance{ID: "i-1234"}, tc.targetGroup)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func newTargetGroupTestScope(t *testing.T) *scope.ClusterScope {
+	t.Helper()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: "vpc-1234"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clusterScope
+}
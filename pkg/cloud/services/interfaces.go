@@ -17,6 +17,8 @@ limitations under the License.
 package services
 
 import (
+	"time"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
@@ -58,6 +60,12 @@ type EC2MachineInterface interface {
 
 	TerminateInstanceAndWait(instanceID string) error
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
+	InstanceTypeHasNVIDIAGPUs(instanceType string) (bool, error)
+	GetInstanceTypesFromInstanceRequirements(scope *scope.MachineScope) ([]string, error)
+	InstanceStatusChecksImpaired(instanceID string) (bool, error)
+	GetCapacityReservationEndDate(id string) (*time.Time, error)
+	GetRootVolume(instanceID string) (*infrav1.RootVolumeStatus, error)
+	ModifyVolumeType(volumeID, volumeType string) error
 
 	DiscoverLaunchTemplateAMI(scope *scope.MachinePoolScope) (*string, error)
 	GetLaunchTemplate(id string) (*expinfrav1.AWSLaunchTemplate, error)
@@ -74,3 +82,9 @@ type SecretInterface interface {
 	Create(m *scope.MachineScope, data []byte) (string, int32, error)
 	UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
 }
+
+// InstanceLogsInterface encapsulates the methods exposed to the machine
+// actuator to collect the logs of instances
+type InstanceLogsInterface interface {
+	UploadInstanceLogs(instanceID, bucket, keyPrefix string, timeout time.Duration) (string, error)
+}
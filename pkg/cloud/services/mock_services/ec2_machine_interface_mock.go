@@ -26,6 +26,7 @@ import (
 	v1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	v1alpha30 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
 	scope "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	time "time"
 )
 
 // MockEC2MachineInterface is a mock of EC2MachineInterface interface
@@ -138,6 +139,21 @@ func (mr *MockEC2MachineInterfaceMockRecorder) DiscoverLaunchTemplateAMI(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverLaunchTemplateAMI", reflect.TypeOf((*MockEC2MachineInterface)(nil).DiscoverLaunchTemplateAMI), arg0)
 }
 
+// GetCapacityReservationEndDate mocks base method
+func (m *MockEC2MachineInterface) GetCapacityReservationEndDate(arg0 string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapacityReservationEndDate", arg0)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapacityReservationEndDate indicates an expected call of GetCapacityReservationEndDate
+func (mr *MockEC2MachineInterfaceMockRecorder) GetCapacityReservationEndDate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacityReservationEndDate", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetCapacityReservationEndDate), arg0)
+}
+
 // GetCoreSecurityGroups mocks base method
 func (m *MockEC2MachineInterface) GetCoreSecurityGroups(arg0 *scope.MachineScope) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -183,6 +199,21 @@ func (mr *MockEC2MachineInterfaceMockRecorder) GetInstanceSecurityGroups(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceSecurityGroups", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetInstanceSecurityGroups), arg0)
 }
 
+// GetInstanceTypesFromInstanceRequirements mocks base method
+func (m *MockEC2MachineInterface) GetInstanceTypesFromInstanceRequirements(arg0 *scope.MachineScope) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceTypesFromInstanceRequirements", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceTypesFromInstanceRequirements indicates an expected call of GetInstanceTypesFromInstanceRequirements
+func (mr *MockEC2MachineInterfaceMockRecorder) GetInstanceTypesFromInstanceRequirements(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceTypesFromInstanceRequirements", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetInstanceTypesFromInstanceRequirements), arg0)
+}
+
 // GetLaunchTemplate mocks base method
 func (m *MockEC2MachineInterface) GetLaunchTemplate(arg0 string) (*v1alpha30.AWSLaunchTemplate, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +229,21 @@ func (mr *MockEC2MachineInterfaceMockRecorder) GetLaunchTemplate(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLaunchTemplate", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetLaunchTemplate), arg0)
 }
 
+// GetRootVolume mocks base method
+func (m *MockEC2MachineInterface) GetRootVolume(arg0 string) (*v1alpha3.RootVolumeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootVolume", arg0)
+	ret0, _ := ret[0].(*v1alpha3.RootVolumeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootVolume indicates an expected call of GetRootVolume
+func (mr *MockEC2MachineInterfaceMockRecorder) GetRootVolume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootVolume", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetRootVolume), arg0)
+}
+
 // GetRunningInstanceByTags mocks base method
 func (m *MockEC2MachineInterface) GetRunningInstanceByTags(arg0 *scope.MachineScope) (*v1alpha3.Instance, error) {
 	m.ctrl.T.Helper()
@@ -228,6 +274,36 @@ func (mr *MockEC2MachineInterfaceMockRecorder) InstanceIfExists(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceIfExists", reflect.TypeOf((*MockEC2MachineInterface)(nil).InstanceIfExists), arg0)
 }
 
+// InstanceStatusChecksImpaired mocks base method
+func (m *MockEC2MachineInterface) InstanceStatusChecksImpaired(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceStatusChecksImpaired", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceStatusChecksImpaired indicates an expected call of InstanceStatusChecksImpaired
+func (mr *MockEC2MachineInterfaceMockRecorder) InstanceStatusChecksImpaired(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceStatusChecksImpaired", reflect.TypeOf((*MockEC2MachineInterface)(nil).InstanceStatusChecksImpaired), arg0)
+}
+
+// InstanceTypeHasNVIDIAGPUs mocks base method
+func (m *MockEC2MachineInterface) InstanceTypeHasNVIDIAGPUs(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceTypeHasNVIDIAGPUs", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceTypeHasNVIDIAGPUs indicates an expected call of InstanceTypeHasNVIDIAGPUs
+func (mr *MockEC2MachineInterfaceMockRecorder) InstanceTypeHasNVIDIAGPUs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceTypeHasNVIDIAGPUs", reflect.TypeOf((*MockEC2MachineInterface)(nil).InstanceTypeHasNVIDIAGPUs), arg0)
+}
+
 // LaunchTemplateNeedsUpdate mocks base method
 func (m *MockEC2MachineInterface) LaunchTemplateNeedsUpdate(arg0 *scope.MachinePoolScope, arg1, arg2 *v1alpha30.AWSLaunchTemplate) (bool, error) {
 	m.ctrl.T.Helper()
@@ -243,6 +319,20 @@ func (mr *MockEC2MachineInterfaceMockRecorder) LaunchTemplateNeedsUpdate(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateNeedsUpdate", reflect.TypeOf((*MockEC2MachineInterface)(nil).LaunchTemplateNeedsUpdate), arg0, arg1, arg2)
 }
 
+// ModifyVolumeType mocks base method
+func (m *MockEC2MachineInterface) ModifyVolumeType(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyVolumeType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyVolumeType indicates an expected call of ModifyVolumeType
+func (mr *MockEC2MachineInterfaceMockRecorder) ModifyVolumeType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyVolumeType", reflect.TypeOf((*MockEC2MachineInterface)(nil).ModifyVolumeType), arg0, arg1)
+}
+
 // TerminateInstance mocks base method
 func (m *MockEC2MachineInterface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// VPC route tables have no blackhole target, but AWS drops the traffic of routes whose target
+// network interface is not attached to an instance. Blackhole routes therefore all target a
+// network interface of the cluster that is never attached.
+
+func (s *Service) reconcileBlackholeNetworkInterface() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping blackhole network interface reconcile in unmanaged mode")
+		return nil
+	}
+	if len(s.scope.BlackholeRoutes()) == 0 {
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling blackhole network interface")
+
+	eni, err := s.describeBlackholeNetworkInterface()
+	if awserrors.IsNotFound(err) {
+		eni, err = s.createBlackholeNetworkInterface()
+	}
+	if err != nil {
+		return err
+	}
+
+	s.scope.Network().BlackholeNetworkInterfaceID = aws.StringValue(eni.NetworkInterfaceId)
+	return nil
+}
+
+func (s *Service) deleteBlackholeNetworkInterface() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping blackhole network interface deletion in unmanaged mode")
+		return nil
+	}
+	if len(s.scope.BlackholeRoutes()) == 0 && s.scope.Network().BlackholeNetworkInterfaceID == "" {
+		return nil
+	}
+
+	eni, err := s.describeBlackholeNetworkInterface()
+	if awserrors.IsNotFound(err) {
+		s.scope.Network().BlackholeNetworkInterfaceID = ""
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := s.EC2Client.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{
+		NetworkInterfaceId: eni.NetworkInterfaceId,
+	}); err != nil && !awserrors.IsNotFound(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteNetworkInterface", "Failed to delete blackhole Network Interface %q: %v", *eni.NetworkInterfaceId, err)
+		return errors.Wrapf(err, "failed to delete blackhole network interface %q", *eni.NetworkInterfaceId)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteNetworkInterface", "Deleted blackhole Network Interface %q", *eni.NetworkInterfaceId)
+	s.scope.Info("Deleted blackhole network interface", "network-interface-id", *eni.NetworkInterfaceId)
+	s.scope.Network().BlackholeNetworkInterfaceID = ""
+	return nil
+}
+
+func (s *Service) createBlackholeNetworkInterface() (*ec2.NetworkInterface, error) {
+	subnets := s.scope.Subnets().FilterPrivate()
+	if len(subnets) == 0 {
+		subnets = s.scope.Subnets()
+	}
+	if len(subnets) == 0 || subnets[0].ID == "" {
+		return nil, errors.New("failed to create blackhole network interface: no subnet available")
+	}
+
+	out, err := s.EC2Client.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId:    aws.String(subnets[0].ID),
+		Description: aws.String(fmt.Sprintf("Blackhole route target of cluster %s, must not be attached", s.scope.Name())),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeNetworkInterface, s.getBlackholeTagParams(services.TemporaryResourceID)),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateNetworkInterface", "Failed to create blackhole Network Interface: %v", err)
+		return nil, errors.Wrap(err, "failed to create blackhole network interface")
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNetworkInterface", "Created blackhole Network Interface %q", *out.NetworkInterface.NetworkInterfaceId)
+	s.scope.Info("Created blackhole network interface", "network-interface-id", *out.NetworkInterface.NetworkInterfaceId, "subnet-id", subnets[0].ID)
+	return out.NetworkInterface, nil
+}
+
+func (s *Service) describeBlackholeNetworkInterface() (*ec2.NetworkInterface, error) {
+	out, err := s.EC2Client.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ProviderRole(infrav1.BlackholeRoleTagValue),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeNetworkInterfaces", "Failed to describe blackhole network interface in vpc %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe blackhole network interface in vpc %q", s.scope.VPC().ID)
+	}
+
+	if len(out.NetworkInterfaces) == 0 {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("no blackhole network interface found in vpc %q", s.scope.VPC().ID))
+	}
+
+	return out.NetworkInterfaces[0], nil
+}
+
+// getBlackholeRoutes returns the blackhole routes every route table of the cluster must have.
+func (s *Service) getBlackholeRoutes() []*ec2.Route {
+	eniID := s.scope.Network().BlackholeNetworkInterfaceID
+	if eniID == "" {
+		return nil
+	}
+
+	routes := make([]*ec2.Route, 0, len(s.scope.BlackholeRoutes()))
+	for _, route := range s.scope.BlackholeRoutes() {
+		routes = append(routes, &ec2.Route{
+			DestinationCidrBlock: aws.String(route.DestinationCIDRBlock),
+			NetworkInterfaceId:   aws.String(eniID),
+		})
+	}
+	return routes
+}
+
+func (s *Service) getBlackholeTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-blackhole", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.BlackholeRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
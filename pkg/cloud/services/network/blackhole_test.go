@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestReconcileBlackholeNetworkInterface(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	networkSpec := func(routes ...infrav1.BlackholeRoute) *infrav1.NetworkSpec {
+		return &infrav1.NetworkSpec{
+			VPC: infrav1.VPCSpec{
+				ID: "vpc-blackhole",
+				Tags: infrav1.Tags{
+					infrav1.ClusterTagKey("test-cluster"): "owned",
+				},
+			},
+			Subnets: infrav1.Subnets{
+				&infrav1.SubnetSpec{ID: "subnet-public", IsPublic: true},
+				&infrav1.SubnetSpec{ID: "subnet-private", IsPublic: false},
+			},
+			BlackholeRoutes: routes,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		input         *infrav1.NetworkSpec
+		expect        func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectedENIID string
+	}{
+		{
+			name:   "no blackhole routes, does nothing",
+			input:  networkSpec(),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:  "has network interface",
+			input: networkSpec(infrav1.BlackholeRoute{DestinationCIDRBlock: "192.0.2.0/24"}),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNetworkInterfaces(gomock.AssignableToTypeOf(&ec2.DescribeNetworkInterfacesInput{})).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{{NetworkInterfaceId: aws.String("eni-0")}},
+					}, nil)
+			},
+			expectedENIID: "eni-0",
+		},
+		{
+			name:  "no network interface, creates one in a private subnet",
+			input: networkSpec(infrav1.BlackholeRoute{DestinationCIDRBlock: "192.0.2.0/24"}),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNetworkInterfaces(gomock.AssignableToTypeOf(&ec2.DescribeNetworkInterfacesInput{})).
+					Return(&ec2.DescribeNetworkInterfacesOutput{}, nil)
+
+				m.CreateNetworkInterface(gomock.AssignableToTypeOf(&ec2.CreateNetworkInterfaceInput{})).
+					DoAndReturn(func(input *ec2.CreateNetworkInterfaceInput) (*ec2.CreateNetworkInterfaceOutput, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-private" {
+							t.Errorf("expected the network interface to be created in subnet-private, got %q", aws.StringValue(input.SubnetId))
+						}
+						return &ec2.CreateNetworkInterfaceOutput{
+							NetworkInterface: &ec2.NetworkInterface{NetworkInterfaceId: aws.String("eni-1")},
+						}, nil
+					})
+			},
+			expectedENIID: "eni-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: *tc.input,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			if err := s.reconcileBlackholeNetworkInterface(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if id := scope.Network().BlackholeNetworkInterfaceID; id != tc.expectedENIID {
+				t.Fatalf("expected blackhole network interface %q, got %q", tc.expectedENIID, id)
+			}
+		})
+	}
+}
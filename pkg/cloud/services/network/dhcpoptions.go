@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// defaultDHCPOptionsSetID associates a VPC with the default DHCP options of the region.
+const defaultDHCPOptionsSetID = "default"
+
+// reconcileDHCPOptionsSet associates the DHCP options set referenced by the network spec with the
+// managed VPC. The DHCP options set is owned outside of the cluster: it is never created or deleted,
+// and once the reference is removed the VPC goes back to the default DHCP options.
+func (s *Service) reconcileDHCPOptionsSet() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping DHCP options set reconcile in unmanaged mode")
+		return nil
+	}
+
+	id := aws.StringValue(s.scope.VPC().DHCPOptionsSetID)
+	if id == "" {
+		if s.scope.Network().DHCPOptionsSet == nil {
+			return nil
+		}
+		if err := s.associateDHCPOptionsSet(defaultDHCPOptionsSetID); err != nil {
+			return err
+		}
+		s.scope.Network().DHCPOptionsSet = nil
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling DHCP options set", "dhcp-options-id", id)
+
+	options, err := s.describeDHCPOptionsSet(id)
+	if err != nil {
+		return err
+	}
+	if err := validateDHCPOptionsSet(options); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "InvalidDHCPOptionsSet", "DHCP options set %q cannot be used: %v", id, err)
+		return err
+	}
+
+	current, err := s.vpcDHCPOptionsSetID()
+	if err != nil {
+		return err
+	}
+	if current != id {
+		if err := s.associateDHCPOptionsSet(id); err != nil {
+			return err
+		}
+	}
+
+	s.scope.Network().DHCPOptionsSet = &infrav1.DHCPOptionsSetStatus{
+		ID:              id,
+		ExternallyOwned: true,
+	}
+	return nil
+}
+
+func (s *Service) describeDHCPOptionsSet(id string) (*ec2.DhcpOptions, error) {
+	out, err := s.EC2Client.DescribeDhcpOptions(&ec2.DescribeDhcpOptionsInput{
+		DhcpOptionsIds: aws.StringSlice([]string{id}),
+	})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil, awserrors.NewNotFound(fmt.Sprintf("could not find DHCP options set %q", id))
+		}
+		return nil, errors.Wrapf(err, "failed to describe DHCP options set %q", id)
+	}
+	if len(out.DhcpOptions) == 0 {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("could not find DHCP options set %q", id))
+	}
+	return out.DhcpOptions[0], nil
+}
+
+// validateDHCPOptionsSet makes sure nodes in a VPC using the DHCP options set can resolve names and
+// get a hostname the kubelet and the AWS cloud provider agree on.
+func validateDHCPOptionsSet(options *ec2.DhcpOptions) error {
+	values := map[string][]string{}
+	for _, c := range options.DhcpConfigurations {
+		for _, v := range c.Values {
+			values[aws.StringValue(c.Key)] = append(values[aws.StringValue(c.Key)], aws.StringValue(v.Value))
+		}
+	}
+
+	if len(values["domain-name-servers"]) == 0 {
+		return errors.New("no domain name servers are set")
+	}
+	domainNames := values["domain-name"]
+	if len(domainNames) > 1 || (len(domainNames) == 1 && strings.ContainsAny(domainNames[0], " \t")) {
+		return errors.Errorf("only a single domain name is supported, got %q", strings.Join(domainNames, " "))
+	}
+	return nil
+}
+
+// vpcDHCPOptionsSetID returns the ID of the DHCP options set currently associated with the VPC.
+func (s *Service) vpcDHCPOptionsSetID() (string, error) {
+	out, err := s.EC2Client.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: aws.StringSlice([]string{s.scope.VPC().ID}),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe vpc %q", s.scope.VPC().ID)
+	}
+	if len(out.Vpcs) == 0 {
+		return "", awserrors.NewNotFound(fmt.Sprintf("could not find vpc %q", s.scope.VPC().ID))
+	}
+	return aws.StringValue(out.Vpcs[0].DhcpOptionsId), nil
+}
+
+func (s *Service) associateDHCPOptionsSet(id string) error {
+	if _, err := s.EC2Client.AssociateDhcpOptions(&ec2.AssociateDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+		VpcId:         aws.String(s.scope.VPC().ID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateDHCPOptionsSet", "Failed to associate DHCP options set %q with vpc %q: %v", id, s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to associate DHCP options set %q with vpc %q", id, s.scope.VPC().ID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateDHCPOptionsSet", "Associated DHCP options set %q with vpc %q", id, s.scope.VPC().ID)
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestReconcileDHCPOptionsSet(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const id = "dopt-0123456789abcdef0"
+
+	describeDHCPOptions := func(m *mock_ec2iface.MockEC2APIMockRecorder, configurations ...*ec2.DhcpConfiguration) {
+		m.DescribeDhcpOptions(gomock.Eq(&ec2.DescribeDhcpOptionsInput{
+			DhcpOptionsIds: aws.StringSlice([]string{id}),
+		})).Return(&ec2.DescribeDhcpOptionsOutput{
+			DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String(id), DhcpConfigurations: configurations}},
+		}, nil)
+	}
+	configuration := func(key string, values ...string) *ec2.DhcpConfiguration {
+		c := &ec2.DhcpConfiguration{Key: aws.String(key)}
+		for _, v := range values {
+			c.Values = append(c.Values, &ec2.AttributeValue{Value: aws.String(v)})
+		}
+		return c
+	}
+	describeVPC := func(m *mock_ec2iface.MockEC2APIMockRecorder, current string) {
+		m.DescribeVpcs(gomock.Eq(&ec2.DescribeVpcsInput{
+			VpcIds: aws.StringSlice([]string{"vpc-managed"}),
+		})).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-managed"), DhcpOptionsId: aws.String(current)}},
+		}, nil)
+	}
+	associate := func(m *mock_ec2iface.MockEC2APIMockRecorder, id string) {
+		m.AssociateDhcpOptions(gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+			DhcpOptionsId: aws.String(id),
+			VpcId:         aws.String("vpc-managed"),
+		})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+	}
+
+	testCases := []struct {
+		name       string
+		id         *string
+		status     *infrav1.DHCPOptionsSetStatus
+		expect     func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		wantStatus *infrav1.DHCPOptionsSetStatus
+		wantErr    bool
+	}{
+		{
+			name: "associates the DHCP options set with the vpc",
+			id:   aws.String(id),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m,
+					configuration("domain-name", "corp.example.com"),
+					configuration("domain-name-servers", "10.0.0.2", "10.0.0.3"),
+				)
+				describeVPC(m, "dopt-default")
+				associate(m, id)
+			},
+			wantStatus: &infrav1.DHCPOptionsSetStatus{ID: id, ExternallyOwned: true},
+		},
+		{
+			name:   "does not associate a DHCP options set that is already associated",
+			id:     aws.String(id),
+			status: &infrav1.DHCPOptionsSetStatus{ID: id, ExternallyOwned: true},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m, configuration("domain-name-servers", "AmazonProvidedDNS"))
+				describeVPC(m, id)
+			},
+			wantStatus: &infrav1.DHCPOptionsSetStatus{ID: id, ExternallyOwned: true},
+		},
+		{
+			name: "fails for a DHCP options set that does not exist",
+			id:   aws.String(id),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeDhcpOptions(gomock.Any()).Return(nil, awserr.New(awserrors.DHCPOptionsNotFound, "not found", nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for a DHCP options set without domain name servers",
+			id:   aws.String(id),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m, configuration("domain-name", "corp.example.com"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for a DHCP options set with several domain names",
+			id:   aws.String(id),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m,
+					configuration("domain-name", "corp.example.com example.com"),
+					configuration("domain-name-servers", "AmazonProvidedDNS"),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "goes back to the default DHCP options once the reference is removed",
+			status: &infrav1.DHCPOptionsSetStatus{ID: id, ExternallyOwned: true},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				associate(m, defaultDHCPOptionsSetID)
+			},
+		},
+		{
+			name:   "does not call EC2 for clusters that never referenced a DHCP options set",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-managed",
+							Tags: infrav1.Tags{
+								infrav1.ClusterTagKey("test-cluster"): "owned",
+							},
+							DHCPOptionsSetID: tc.id,
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{DHCPOptionsSet: tc.status},
+				},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.reconcileDHCPOptionsSet()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if got := awsCluster.Status.Network.DHCPOptionsSet; !reflect.DeepEqual(got, tc.wantStatus) {
+				t.Fatalf("expected DHCP options set status %v, got %v", tc.wantStatus, got)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 )
 
-func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, err error) {
+func (s *Service) getOrAllocateAddresses(num int, role, networkBorderGroup string) (eips []*ec2.Address, err error) {
 	out, err := s.describeAddresses(role)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query addresses for role %q: %v", role, err)
@@ -38,13 +38,13 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 	}
 
 	for _, address := range out.Addresses {
-		if address.AssociationId == nil {
-			eips = append(eips, aws.StringValue(address.AllocationId))
+		if address.AssociationId == nil && s.addressNetworkBorderGroup(address) == networkBorderGroup {
+			eips = append(eips, address)
 		}
 	}
 
 	for len(eips) < num {
-		ip, err := s.allocateAddress(role)
+		ip, err := s.allocateAddress(role, networkBorderGroup)
 		if err != nil {
 			return nil, err
 		}
@@ -54,13 +54,29 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 	return eips, nil
 }
 
-func (s *Service) allocateAddress(role string) (string, error) {
-	out, err := s.EC2Client.AllocateAddress(&ec2.AllocateAddressInput{
+// addressNetworkBorderGroup returns the network border group of the address, or an empty string
+// if it is the region's.
+func (s *Service) addressNetworkBorderGroup(address *ec2.Address) string {
+	if group := aws.StringValue(address.NetworkBorderGroup); group != s.scope.Region() {
+		return group
+	}
+	return ""
+}
+
+// allocateAddress allocates an Elastic IP from the given network border group, or from the
+// region's if it is empty.
+func (s *Service) allocateAddress(role, networkBorderGroup string) (*ec2.Address, error) {
+	input := &ec2.AllocateAddressInput{
 		Domain: aws.String("vpc"),
-	})
+	}
+	if networkBorderGroup != "" {
+		input.NetworkBorderGroup = aws.String(networkBorderGroup)
+	}
+
+	out, err := s.EC2Client.AllocateAddress(input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedAllocateEIP", "Failed to allocate Elastic IP for %q: %v", role, err)
-		return "", errors.Wrap(err, "failed to allocate Elastic IP")
+		return nil, errors.Wrap(err, "failed to allocate Elastic IP")
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
@@ -72,10 +88,14 @@ func (s *Service) allocateAddress(role string) (string, error) {
 		return true, nil
 	}, awserrors.EIPNotFound); err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedAllocateAddress", "Failed to tag elastic IP %q: %v", aws.StringValue(out.AllocationId), err)
-		return "", errors.Wrapf(err, "failed to tag Elastic IP %q", aws.StringValue(out.AllocationId))
+		return nil, errors.Wrapf(err, "failed to tag Elastic IP %q", aws.StringValue(out.AllocationId))
 	}
 
-	return aws.StringValue(out.AllocationId), nil
+	return &ec2.Address{
+		AllocationId:       out.AllocationId,
+		PublicIp:           out.PublicIp,
+		NetworkBorderGroup: out.NetworkBorderGroup,
+	}, nil
 }
 
 func (s *Service) describeAddresses(role string) (*ec2.DescribeAddressesOutput, error) {
@@ -47,6 +47,14 @@ func (s *Service) reconcileInternetGateways() error {
 			return errors.Errorf("failed to validate network: no internet gateways found in VPC %q", s.scope.VPC().ID)
 		}
 
+		if len(s.scope.Subnets().FilterPublic()) == 0 {
+			// Private-only clusters reach out through the routes of their subnet groups instead.
+			s.scope.V(2).Info("No public subnets found, skipping internet gateway creation")
+			s.scope.Network().InternetGatewayNotRequired = true
+			conditions.Delete(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition)
+			return nil
+		}
+
 		ig, err := s.createInternetGateway()
 		if err != nil {
 			return err
@@ -58,6 +66,7 @@ func (s *Service) reconcileInternetGateways() error {
 
 	gateway := igs[0]
 	s.scope.VPC().InternetGatewayID = gateway.InternetGatewayId
+	s.scope.Network().InternetGatewayNotRequired = false
 
 	// Make sure tags are up to date.
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
@@ -75,6 +84,28 @@ func (s *Service) reconcileInternetGateways() error {
 	return nil
 }
 
+// validatePrivateEgress makes sure the private subnets of a managed network without public subnets
+// have a way out: without public subnets there is no internet gateway and there are no NAT gateways,
+// so every private subnet has to join a subnet group with a default route.
+func (s *Service) validatePrivateEgress() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		return nil
+	}
+
+	subnets := s.scope.Subnets()
+	if len(subnets.FilterPublic()) > 0 {
+		return nil
+	}
+
+	for _, sn := range subnets.FilterPrivate() {
+		if sn.SubnetGroup == "" {
+			return errors.Errorf("failed to validate network: private subnet %q has no egress, the cluster has no public subnets for NAT gateways and the subnet does not belong to a subnet group", sn.String())
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) deleteInternetGateways() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.V(4).Info("Skipping internet gateway deletion in unmanaged mode")
@@ -34,9 +34,10 @@ func TestReconcileInternetGateways(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  *infrav1.NetworkSpec
-		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		name        string
+		input       *infrav1.NetworkSpec
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		notRequired bool
 	}{
 		{
 			name: "has igw",
@@ -77,6 +78,9 @@ func TestReconcileInternetGateways(t *testing.T) {
 						infrav1.ClusterTagKey("test-cluster"): "owned",
 					},
 				},
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-public", IsPublic: true},
+				},
 			},
 			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
 				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
@@ -110,6 +114,25 @@ func TestReconcileInternetGateways(t *testing.T) {
 					Return(&ec2.AttachInternetGatewayOutput{}, nil)
 			},
 		},
+		{
+			name: "no igw attached and no public subnets, skips creation",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-gateways",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-private", SubnetGroup: "isolated"},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{}, nil)
+			},
+			notRequired: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -138,6 +161,90 @@ func TestReconcileInternetGateways(t *testing.T) {
 			if err := s.reconcileInternetGateways(); err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
+			if got := scope.Network().InternetGatewayNotRequired; got != tc.notRequired {
+				t.Fatalf("got internetGatewayNotRequired %v, expected %v", got, tc.notRequired)
+			}
+		})
+	}
+}
+
+func TestValidatePrivateEgress(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     *infrav1.NetworkSpec
+		wantError bool
+	}{
+		{
+			name: "public subnets provide egress through NAT gateways",
+			input: &infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-public", IsPublic: true},
+					{ID: "subnet-private"},
+				},
+			},
+		},
+		{
+			name: "private-only subnets in subnet groups",
+			input: &infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-private", SubnetGroup: "isolated"},
+				},
+			},
+		},
+		{
+			name: "private-only subnet without a subnet group",
+			input: &infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-private-1", SubnetGroup: "isolated"},
+					{ID: "subnet-private-2"},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "unmanaged VPC is not validated",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{ID: "vpc-unmanaged"},
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-private"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.input.VPC.ID == "" {
+				tc.input.VPC = infrav1.VPCSpec{
+					ID: "vpc-managed",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				}
+			}
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: *tc.input,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(scope)
+			err = s.validatePrivateEgress()
+			if tc.wantError && err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
 		})
 	}
 }
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const (
+	natGatewayMetricsNamespace = "AWS/NATGateway"
+
+	// natGatewayAlarmPeriod is the period in seconds the NAT gateway metrics are summed up over.
+	natGatewayAlarmPeriod = 60
+
+	defaultNatGatewayPacketsDropThreshold = 100
+	defaultNatGatewayEvaluationPeriods    = 3
+
+	// maxAlarmsPerDeleteRequest is the number of alarms CloudWatch deletes in a single request.
+	maxAlarmsPerDeleteRequest = 100
+)
+
+// reconcileNatGatewayAlarms makes sure every managed NAT gateway has its CloudWatch alarms when NAT
+// gateway monitoring is enabled, and reports whether any of them went off. Alarms of NAT gateways
+// that are gone, or of all NAT gateways once monitoring is turned off, are deleted.
+func (s *Service) reconcileNatGatewayAlarms() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping NAT gateway alarms reconcile in unmanaged mode")
+		return nil
+	}
+
+	monitoring := s.scope.NatGatewayMonitoring()
+	if monitoring == nil {
+		// Only clusters that had monitoring turned on have alarms to clean up, which spares the others
+		// from calling CloudWatch.
+		if !conditions.Has(s.scope.InfraCluster(), infrav1.NatGatewaysHealthyCondition) {
+			return nil
+		}
+		if err := s.deleteNatGatewayAlarms(); err != nil {
+			return err
+		}
+		conditions.Delete(s.scope.InfraCluster(), infrav1.NatGatewaysHealthyCondition)
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling NAT gateway alarms")
+
+	existing, err := s.describeNatGatewayAlarms()
+	if err != nil {
+		return err
+	}
+
+	desired := s.natGatewayAlarms(monitoring)
+	var firing []string
+	for _, alarm := range desired {
+		name := aws.StringValue(alarm.AlarmName)
+		current, ok := existing[name]
+		delete(existing, name)
+
+		if ok && natGatewayAlarmUpToDate(current, alarm) {
+			if aws.StringValue(current.StateValue) == cloudwatch.StateValueAlarm {
+				firing = append(firing, name)
+			}
+			continue
+		}
+
+		if _, err := s.CloudWatchClient.PutMetricAlarm(alarm); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedPutNATGatewayAlarm", "Failed to put CloudWatch alarm %q: %v", name, err)
+			return errors.Wrapf(err, "failed to put NAT gateway alarm %q", name)
+		}
+		if !ok {
+			record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNATGatewayAlarm", "Created CloudWatch alarm %q", name)
+		}
+	}
+
+	// Whatever is left belongs to NAT gateways that no longer exist.
+	stale := make([]string, 0, len(existing))
+	for name := range existing {
+		stale = append(stale, name)
+	}
+	if err := s.deleteAlarms(stale); err != nil {
+		return err
+	}
+
+	if len(firing) > 0 {
+		sort.Strings(firing)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysHealthyCondition, infrav1.NatGatewayAlarmReason,
+			clusterv1.ConditionSeverityWarning, "NAT gateway alarms went off: %s", strings.Join(firing, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.NatGatewaysHealthyCondition)
+	return nil
+}
+
+// deleteNatGatewayAlarms deletes the CloudWatch alarms of all of the cluster's NAT gateways.
+func (s *Service) deleteNatGatewayAlarms() error {
+	existing, err := s.describeNatGatewayAlarms()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		names = append(names, name)
+	}
+	return s.deleteAlarms(names)
+}
+
+func (s *Service) deleteAlarms(names []string) error {
+	sort.Strings(names)
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > maxAlarmsPerDeleteRequest {
+			batch = batch[:maxAlarmsPerDeleteRequest]
+		}
+		names = names[len(batch):]
+
+		if _, err := s.CloudWatchClient.DeleteAlarms(&cloudwatch.DeleteAlarmsInput{AlarmNames: aws.StringSlice(batch)}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteNATGatewayAlarms", "Failed to delete CloudWatch alarms %v: %v", batch, err)
+			return errors.Wrapf(err, "failed to delete NAT gateway alarms %v", batch)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteNATGatewayAlarms", "Deleted CloudWatch alarms %v", batch)
+	}
+	return nil
+}
+
+// describeNatGatewayAlarms returns the CloudWatch alarms of the cluster's NAT gateways by name.
+func (s *Service) describeNatGatewayAlarms() (map[string]*cloudwatch.MetricAlarm, error) {
+	alarms := map[string]*cloudwatch.MetricAlarm{}
+	if err := s.CloudWatchClient.DescribeAlarmsPages(&cloudwatch.DescribeAlarmsInput{
+		AlarmNamePrefix: aws.String(s.natGatewayAlarmNamePrefix()),
+		AlarmTypes:      aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm}),
+	}, func(out *cloudwatch.DescribeAlarmsOutput, _ bool) bool {
+		for _, alarm := range out.MetricAlarms {
+			alarms[aws.StringValue(alarm.AlarmName)] = alarm
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe NAT gateway alarms")
+	}
+	return alarms, nil
+}
+
+// natGatewayAlarms returns the CloudWatch alarms for the NAT gateways of the cluster's public subnets.
+func (s *Service) natGatewayAlarms(monitoring *infrav1.NatGatewayMonitoringSpec) []*cloudwatch.PutMetricAlarmInput {
+	evaluationPeriods := int64(defaultNatGatewayEvaluationPeriods)
+	if monitoring.EvaluationPeriods != nil {
+		evaluationPeriods = *monitoring.EvaluationPeriods
+	}
+
+	packetsDropThreshold := int64(defaultNatGatewayPacketsDropThreshold)
+	if monitoring.PacketsDropThreshold != nil {
+		packetsDropThreshold = *monitoring.PacketsDropThreshold
+	}
+
+	thresholds := []struct {
+		metric    string
+		threshold int64
+	}{
+		// Any error allocating a source port means connections through the NAT gateway are failing.
+		{metric: "ErrorPortAllocation", threshold: 0},
+		{metric: "PacketsDropCount", threshold: packetsDropThreshold},
+	}
+
+	var alarms []*cloudwatch.PutMetricAlarmInput
+	for _, sn := range s.scope.Subnets().FilterPublic() {
+		if sn.NatGatewayID == nil {
+			continue
+		}
+		natGatewayID := aws.StringValue(sn.NatGatewayID)
+
+		for _, t := range thresholds {
+			alarms = append(alarms, &cloudwatch.PutMetricAlarmInput{
+				AlarmName:          aws.String(s.natGatewayAlarmNamePrefix() + natGatewayID + "-" + t.metric),
+				AlarmDescription:   aws.String(fmt.Sprintf("%s of NAT gateway %s of cluster %s", t.metric, natGatewayID, s.scope.Name())),
+				Namespace:          aws.String(natGatewayMetricsNamespace),
+				MetricName:         aws.String(t.metric),
+				Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("NatGatewayId"), Value: aws.String(natGatewayID)}},
+				Statistic:          aws.String(cloudwatch.StatisticSum),
+				Period:             aws.Int64(natGatewayAlarmPeriod),
+				EvaluationPeriods:  aws.Int64(evaluationPeriods),
+				Threshold:          aws.Float64(float64(t.threshold)),
+				ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanThreshold),
+				// NAT gateways without traffic report no data, which is not a failure.
+				TreatMissingData: aws.String("notBreaching"),
+				AlarmActions:     aws.StringSlice(monitoring.AlarmActions),
+				Tags: []*cloudwatch.Tag{
+					{Key: aws.String(infrav1.ClusterTagKey(s.scope.Name())), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+				},
+			})
+		}
+	}
+	return alarms
+}
+
+func (s *Service) natGatewayAlarmNamePrefix() string {
+	return s.scope.Name() + "-natgw-"
+}
+
+// natGatewayAlarmUpToDate returns true if the existing alarm matches the settings of the desired one.
+func natGatewayAlarmUpToDate(current *cloudwatch.MetricAlarm, desired *cloudwatch.PutMetricAlarmInput) bool {
+	currentActions := aws.StringValueSlice(current.AlarmActions)
+	desiredActions := aws.StringValueSlice(desired.AlarmActions)
+	sort.Strings(currentActions)
+	sort.Strings(desiredActions)
+
+	return aws.Float64Value(current.Threshold) == aws.Float64Value(desired.Threshold) &&
+		aws.Int64Value(current.EvaluationPeriods) == aws.Int64Value(desired.EvaluationPeriods) &&
+		strings.Join(currentActions, ",") == strings.Join(desiredActions, ",")
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/network/mock_cloudwatchiface"
+)
+
+func TestReconcileNatGatewayAlarms(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const prefix = "test-cluster-natgw-"
+
+	describeAlarms := func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder, alarms ...*cloudwatch.MetricAlarm) {
+		m.DescribeAlarmsPages(gomock.Eq(&cloudwatch.DescribeAlarmsInput{
+			AlarmNamePrefix: aws.String(prefix),
+			AlarmTypes:      aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm}),
+		}), gomock.Any()).DoAndReturn(func(_ *cloudwatch.DescribeAlarmsInput, fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool) error {
+			fn(&cloudwatch.DescribeAlarmsOutput{MetricAlarms: alarms}, true)
+			return nil
+		})
+	}
+	alarm := func(name string, threshold float64, state string) *cloudwatch.MetricAlarm {
+		return &cloudwatch.MetricAlarm{
+			AlarmName:         aws.String(name),
+			Threshold:         aws.Float64(threshold),
+			EvaluationPeriods: aws.Int64(defaultNatGatewayEvaluationPeriods),
+			StateValue:        aws.String(state),
+		}
+	}
+
+	subnets := infrav1.Subnets{
+		{ID: "subnet-public", IsPublic: true, NatGatewayID: aws.String("nat-1")},
+		{ID: "subnet-private", IsPublic: false},
+	}
+
+	testCases := []struct {
+		name          string
+		monitoring    *infrav1.NatGatewayMonitoringSpec
+		conditions    clusterv1.Conditions
+		expect        func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder)
+		wantCondition *corev1.ConditionStatus
+	}{
+		{
+			name:       "creates the alarms of each NAT gateway",
+			monitoring: &infrav1.NatGatewayMonitoringSpec{},
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {
+				describeAlarms(m)
+				m.PutMetricAlarm(gomock.AssignableToTypeOf(&cloudwatch.PutMetricAlarmInput{})).
+					DoAndReturn(func(in *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+						if aws.StringValue(in.AlarmName) != prefix+"nat-1-ErrorPortAllocation" {
+							t.Errorf("unexpected alarm %q", aws.StringValue(in.AlarmName))
+						}
+						return &cloudwatch.PutMetricAlarmOutput{}, nil
+					})
+				m.PutMetricAlarm(gomock.AssignableToTypeOf(&cloudwatch.PutMetricAlarmInput{})).
+					DoAndReturn(func(in *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+						if aws.StringValue(in.AlarmName) != prefix+"nat-1-PacketsDropCount" {
+							t.Errorf("unexpected alarm %q", aws.StringValue(in.AlarmName))
+						}
+						if aws.Float64Value(in.Threshold) != defaultNatGatewayPacketsDropThreshold {
+							t.Errorf("unexpected threshold %v", aws.Float64Value(in.Threshold))
+						}
+						return &cloudwatch.PutMetricAlarmOutput{}, nil
+					})
+			},
+			wantCondition: conditionStatus(corev1.ConditionTrue),
+		},
+		{
+			name:       "reports alarms that went off",
+			monitoring: &infrav1.NatGatewayMonitoringSpec{},
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {
+				describeAlarms(m,
+					alarm(prefix+"nat-1-ErrorPortAllocation", 0, cloudwatch.StateValueAlarm),
+					alarm(prefix+"nat-1-PacketsDropCount", defaultNatGatewayPacketsDropThreshold, cloudwatch.StateValueOk),
+				)
+			},
+			wantCondition: conditionStatus(corev1.ConditionFalse),
+		},
+		{
+			name:       "updates outdated alarms and deletes the alarms of removed NAT gateways",
+			monitoring: &infrav1.NatGatewayMonitoringSpec{PacketsDropThreshold: aws.Int64(500)},
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {
+				describeAlarms(m,
+					alarm(prefix+"nat-1-ErrorPortAllocation", 0, cloudwatch.StateValueOk),
+					alarm(prefix+"nat-1-PacketsDropCount", defaultNatGatewayPacketsDropThreshold, cloudwatch.StateValueOk),
+					alarm(prefix+"nat-gone-ErrorPortAllocation", 0, cloudwatch.StateValueOk),
+				)
+				m.PutMetricAlarm(gomock.AssignableToTypeOf(&cloudwatch.PutMetricAlarmInput{})).
+					DoAndReturn(func(in *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+						if aws.Float64Value(in.Threshold) != 500 {
+							t.Errorf("unexpected threshold %v", aws.Float64Value(in.Threshold))
+						}
+						return &cloudwatch.PutMetricAlarmOutput{}, nil
+					})
+				m.DeleteAlarms(gomock.Eq(&cloudwatch.DeleteAlarmsInput{
+					AlarmNames: aws.StringSlice([]string{prefix + "nat-gone-ErrorPortAllocation"}),
+				})).Return(&cloudwatch.DeleteAlarmsOutput{}, nil)
+			},
+			wantCondition: conditionStatus(corev1.ConditionTrue),
+		},
+		{
+			name: "deletes the alarms once monitoring is turned off",
+			conditions: clusterv1.Conditions{
+				{Type: infrav1.NatGatewaysHealthyCondition, Status: corev1.ConditionTrue},
+			},
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {
+				describeAlarms(m,
+					alarm(prefix+"nat-1-ErrorPortAllocation", 0, cloudwatch.StateValueOk),
+					alarm(prefix+"nat-1-PacketsDropCount", defaultNatGatewayPacketsDropThreshold, cloudwatch.StateValueOk),
+				)
+				m.DeleteAlarms(gomock.Eq(&cloudwatch.DeleteAlarmsInput{
+					AlarmNames: aws.StringSlice([]string{prefix + "nat-1-ErrorPortAllocation", prefix + "nat-1-PacketsDropCount"}),
+				})).Return(&cloudwatch.DeleteAlarmsOutput{}, nil)
+			},
+		},
+		{
+			name:   "does not call CloudWatch for clusters that never had monitoring",
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cloudWatchMock := mock_cloudwatchiface.NewMockCloudWatchAPI(mockCtrl)
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-managed",
+							Tags: infrav1.Tags{
+								infrav1.ClusterTagKey("test-cluster"): "owned",
+							},
+						},
+						Subnets:              subnets,
+						NatGatewayMonitoring: tc.monitoring,
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Conditions: tc.conditions,
+				},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(cloudWatchMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.CloudWatchClient = cloudWatchMock
+
+			if err := s.reconcileNatGatewayAlarms(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			condition := conditions.Get(awsCluster, infrav1.NatGatewaysHealthyCondition)
+			if tc.wantCondition == nil {
+				if condition != nil {
+					t.Fatalf("expected no %s condition, got %v", infrav1.NatGatewaysHealthyCondition, condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != *tc.wantCondition {
+				t.Fatalf("expected %s condition with status %s, got %v", infrav1.NatGatewaysHealthyCondition, *tc.wantCondition, condition)
+			}
+		})
+	}
+}
+
+func conditionStatus(status corev1.ConditionStatus) *corev1.ConditionStatus {
+	return &status
+}
@@ -70,7 +70,8 @@ func (s *Service) reconcileNatGateways() error {
 
 	subnetIDs := []string{}
 
-	for _, sn := range s.scope.Subnets().FilterPublic() {
+	// NAT gateways are not available on Outposts, so they are only created in the region's public subnets.
+	for _, sn := range s.scope.Subnets().FilterPublic().FilterByOutpost("") {
 		if sn.ID == "" {
 			continue
 		}
@@ -92,6 +93,10 @@ func (s *Service) reconcileNatGateways() error {
 			continue
 		}
 
+		if err := s.validateNetworkBorderGroup(sn); err != nil {
+			return err
+		}
+
 		subnetIDs = append(subnetIDs, sn.ID)
 	}
 
@@ -193,22 +198,75 @@ func (s *Service) getNatGatewayTagParams(id string) infrav1.BuildParams {
 	}
 }
 
-func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
-	eips, err := s.getOrAllocateAddresses(len(subnetIDs), infrav1.APIServerRoleTagValue)
+// networkBorderGroup returns the network border group the Elastic IP of the subnet's NAT gateway
+// is allocated from, or an empty string for the region's.
+func (s *Service) networkBorderGroup(sn *infrav1.SubnetSpec) string {
+	if group := aws.StringValue(sn.NetworkBorderGroup); group != s.scope.Region() {
+		return group
+	}
+	return ""
+}
+
+// validateNetworkBorderGroup makes sure the network border group of the subnet, if any, is the one
+// of its availability zone, as Elastic IPs from other border groups cannot be used in the zone.
+func (s *Service) validateNetworkBorderGroup(sn *infrav1.SubnetSpec) error {
+	if sn.NetworkBorderGroup == nil {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            aws.StringSlice([]string{sn.AvailabilityZone}),
+	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create one or more IP addresses for NAT gateways")
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAvailableZone", "Failed getting availability zone %q: %v", sn.AvailabilityZone, err)
+		return errors.Wrapf(err, "failed to describe availability zone %q", sn.AvailabilityZone)
+	}
+	if len(out.AvailabilityZones) == 0 {
+		return errors.Errorf("availability zone %q of subnet %q not found", sn.AvailabilityZone, sn.ID)
+	}
+
+	if zoneGroup := aws.StringValue(out.AvailabilityZones[0].NetworkBorderGroup); zoneGroup != *sn.NetworkBorderGroup {
+		record.Warnf(s.scope.InfraCluster(), "InvalidNetworkBorderGroup", "Network border group %q of subnet %q is not valid for availability zone %q, expected %q",
+			*sn.NetworkBorderGroup, sn.ID, sn.AvailabilityZone, zoneGroup)
+		return errors.Errorf("network border group %q of subnet %q is not valid for availability zone %q, expected %q",
+			*sn.NetworkBorderGroup, sn.ID, sn.AvailabilityZone, zoneGroup)
 	}
+
+	return nil
+}
+
+func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
+	// Elastic IPs are allocated per network border group, as they can only be used in its zones.
+	var groups []string
+	groupSubnetIDs := map[string][]string{}
+	for _, id := range subnetIDs {
+		group := s.networkBorderGroup(s.scope.Subnets().FindByID(id))
+		if _, ok := groupSubnetIDs[group]; !ok {
+			groups = append(groups, group)
+		}
+		groupSubnetIDs[group] = append(groupSubnetIDs[group], id)
+	}
+
 	type ngwCreation struct {
 		natGateway *ec2.NatGateway
+		address    *ec2.Address
 		error      error
 	}
 	c := make(chan ngwCreation, len(subnetIDs))
 
-	for i, sn := range subnetIDs {
-		go func(c chan ngwCreation, subnetID, ip string) {
-			ngw, err := s.createNatGateway(subnetID, ip)
-			c <- ngwCreation{natGateway: ngw, error: err}
-		}(c, sn, eips[i])
+	for _, group := range groups {
+		eips, err := s.getOrAllocateAddresses(len(groupSubnetIDs[group]), infrav1.APIServerRoleTagValue, group)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create one or more IP addresses for NAT gateways")
+		}
+
+		for i, sn := range groupSubnetIDs[group] {
+			go func(c chan ngwCreation, subnetID string, ip *ec2.Address) {
+				ngw, err := s.createNatGateway(subnetID, aws.StringValue(ip.AllocationId))
+				c <- ngwCreation{natGateway: ngw, address: ip, error: err}
+			}(c, sn, eips[i])
+		}
 	}
 
 	for i := 0; i < len(subnetIDs); i++ {
@@ -217,6 +275,12 @@ func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatG
 			return nil, err
 		}
 		natgateways = append(natgateways, ngwResult.natGateway)
+
+		borderGroup := ngwResult.address.NetworkBorderGroup
+		if borderGroup == nil {
+			borderGroup = aws.String(s.scope.Region())
+		}
+		s.scope.Subnets().FindByID(*ngwResult.natGateway.SubnetId).NatGatewayNetworkBorderGroup = borderGroup
 	}
 	return natgateways, nil
 }
@@ -152,6 +152,79 @@ func TestReconcileNatGateways(t *testing.T) {
 					Return(nil, nil)
 			},
 		},
+		{
+			name: "public subnet in a Local Zone, should allocate the Elastic IP from its network border group",
+			input: []*infrav1.SubnetSpec{
+				{
+					ID:                 "subnet-1",
+					AvailabilityZone:   "us-west-2-lax-1a",
+					CidrBlock:          "10.0.10.0/24",
+					IsPublic:           true,
+					NetworkBorderGroup: aws.String("us-west-2-lax-1"),
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-west-2-lax-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).Return(nil)
+
+				m.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+					AllAvailabilityZones: aws.Bool(true),
+					ZoneNames:            aws.StringSlice([]string{"us-west-2-lax-1a"}),
+				}).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName:           aws.String("us-west-2-lax-1a"),
+								NetworkBorderGroup: aws.String("us-west-2-lax-1"),
+							},
+						},
+					}, nil)
+
+				// An unassociated address from the region cannot be used in the Local Zone.
+				m.DescribeAddresses(gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{
+						Addresses: []*ec2.Address{
+							{
+								AllocationId: aws.String("elastic-ip-in-region"),
+							},
+						},
+					}, nil)
+
+				m.AllocateAddress(&ec2.AllocateAddressInput{
+					Domain:             aws.String("vpc"),
+					NetworkBorderGroup: aws.String("us-west-2-lax-1"),
+				}).
+					Return(&ec2.AllocateAddressOutput{
+						AllocationId:       aws.String(ElasticIPAllocationID),
+						NetworkBorderGroup: aws.String("us-west-2-lax-1"),
+					}, nil)
+
+				m.CreateNatGateway(gomock.AssignableToTypeOf(&ec2.CreateNatGatewayInput{})).
+					DoAndReturn(func(input *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
+						if aws.StringValue(input.AllocationId) != ElasticIPAllocationID {
+							t.Errorf("expected NAT gateway with Elastic IP %q, got %q", ElasticIPAllocationID, aws.StringValue(input.AllocationId))
+						}
+						return &ec2.CreateNatGatewayOutput{
+							NatGateway: &ec2.NatGateway{
+								NatGatewayId: aws.String("natgateway"),
+								SubnetId:     aws.String("subnet-1"),
+							},
+						}, nil
+					})
+
+				m.WaitUntilNatGatewayAvailable(&ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway")},
+				}).Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+			},
+		},
 		{
 			name: "two public & 1 private subnet, and one NAT gateway exists",
 			input: []*infrav1.SubnetSpec{
@@ -32,6 +32,12 @@ func (s *Service) ReconcileNetwork() (err error) {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.VpcReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return err
 	}
+
+	// DHCP options set.
+	if err := s.reconcileDHCPOptionsSet(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.DHCPOptionsSetReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcReadyCondition)
 
 	// Secondary CIDR
@@ -46,6 +52,18 @@ func (s *Service) ReconcileNetwork() (err error) {
 		return err
 	}
 
+	// Subnets shared by the owner of the VPC.
+	if err := s.reconcileSharedVPC(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.SubnetsNotSharedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	// Egress of private-only networks.
+	if err := s.validatePrivateEgress(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.SubnetsReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
 	// Internet Gateways.
 	if err := s.reconcileInternetGateways(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, infrav1.InternetGatewayFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -58,12 +76,27 @@ func (s *Service) ReconcileNetwork() (err error) {
 		return err
 	}
 
+	// Blackhole route target.
+	if err := s.reconcileBlackholeNetworkInterface(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
 	// Routing tables.
 	if err := s.reconcileRouteTables(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return err
 	}
 
+	// Subnet groups.
+	s.updateSubnetGroupStatus()
+
+	// NAT gateway alarms.
+	if err := s.reconcileNatGatewayAlarms(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysHealthyCondition, infrav1.NatGatewayMonitoringFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
 	s.scope.V(2).Info("Reconcile network completed successfully")
 	return nil
 }
@@ -102,6 +135,18 @@ func (s *Service) DeleteNetwork() (err error) {
 	}
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 
+	// Blackhole route target, which keeps its subnet from being deleted.
+	if err := s.deleteBlackholeNetworkInterface(); err != nil {
+		return err
+	}
+
+	// NAT gateway alarms.
+	if s.scope.NatGatewayMonitoring() != nil || conditions.Has(s.scope.InfraCluster(), infrav1.NatGatewaysHealthyCondition) {
+		if err := s.deleteNatGatewayAlarms(); err != nil {
+			return err
+		}
+	}
+
 	// NAT Gateways.
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
@@ -54,18 +54,26 @@ func (s *Service) reconcileRouteTables() error {
 		// We need to compile the minimum routes for this subnet first, so we can compare it or create them.
 		var routes []*ec2.Route
 		sn := s.scope.Subnets()[i]
-		if sn.IsPublic {
+		switch {
+		case sn.IsPublic:
 			if s.scope.VPC().InternetGatewayID == nil {
 				return errors.Errorf("failed to create routing tables: internet gateway for %q is nil", s.scope.VPC().ID)
 			}
 			routes = append(routes, s.getGatewayPublicRoute())
-		} else {
+		case sn.SubnetGroup != "":
+			route, err := s.getSubnetGroupRoute(sn.SubnetGroup)
+			if err != nil {
+				return err
+			}
+			routes = append(routes, route)
+		default:
 			natGatewayID, err := s.getNatGatewayForSubnet(sn)
 			if err != nil {
 				return err
 			}
 			routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
 		}
+		routes = append(routes, s.getBlackholeRoutes()...)
 
 		if rt, ok := subnetRouteMap[sn.ID]; ok {
 			s.scope.V(2).Info("Subnet is already associated with route table", "subnet-id", sn.ID, "route-table-id", *rt.RouteTableId)
@@ -79,15 +87,17 @@ func (s *Service) reconcileRouteTables() error {
 					// Routes destination cidr blocks must be unique within a routing table.
 					// If there is a mistmatch, we replace the routing association.
 					specRoute := routes[i]
-					if *currentRoute.DestinationCidrBlock == *specRoute.DestinationCidrBlock &&
-						((currentRoute.GatewayId != nil && *currentRoute.GatewayId != *specRoute.GatewayId) ||
-							(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != *specRoute.NatGatewayId)) {
+					if aws.StringValue(currentRoute.DestinationCidrBlock) == aws.StringValue(specRoute.DestinationCidrBlock) &&
+						!routeTargetEqual(currentRoute, specRoute) {
 						if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 							if _, err := s.EC2Client.ReplaceRoute(&ec2.ReplaceRouteInput{
-								RouteTableId:         rt.RouteTableId,
-								DestinationCidrBlock: specRoute.DestinationCidrBlock,
-								GatewayId:            specRoute.GatewayId,
-								NatGatewayId:         specRoute.NatGatewayId,
+								RouteTableId:           rt.RouteTableId,
+								DestinationCidrBlock:   specRoute.DestinationCidrBlock,
+								GatewayId:              specRoute.GatewayId,
+								NatGatewayId:           specRoute.NatGatewayId,
+								TransitGatewayId:       specRoute.TransitGatewayId,
+								VpcPeeringConnectionId: specRoute.VpcPeeringConnectionId,
+								NetworkInterfaceId:     specRoute.NetworkInterfaceId,
 							}); err != nil {
 								return false, err
 							}
@@ -100,9 +110,13 @@ func (s *Service) reconcileRouteTables() error {
 				}
 			}
 
+			if err := s.reconcileBlackholeRoutes(rt); err != nil {
+				return err
+			}
+
 			// Make sure tags are up to date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				buildParams := s.getRouteTableTagParams(*rt.RouteTableId, sn.IsPublic, sn.AvailabilityZone)
+				buildParams := s.getRouteTableTagParams(*rt.RouteTableId, routeTableRole(sn), sn.AvailabilityZone)
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(converters.TagsToMap(rt.Tags)); err != nil {
 					return false, err
@@ -119,7 +133,7 @@ func (s *Service) reconcileRouteTables() error {
 
 		// For each subnet that doesn't have a routing table associated with it,
 		// create a new table with the appropriate default routes and associate it to the subnet.
-		rt, err := s.createRouteTableWithRoutes(routes, sn.IsPublic, sn.AvailabilityZone)
+		rt, err := s.createRouteTableWithRoutes(routes, routeTableRole(sn), sn.AvailabilityZone)
 		if err != nil {
 			return err
 		}
@@ -140,6 +154,63 @@ func (s *Service) reconcileRouteTables() error {
 	return nil
 }
 
+// reconcileBlackholeRoutes adds the blackhole routes missing from the route table, and removes the
+// ones that are no longer part of the network spec. Blackhole routes whose destination is already
+// routed elsewhere are replaced along with the other routes of the table.
+func (s *Service) reconcileBlackholeRoutes(rt *ec2.RouteTable) error {
+	eniID := s.scope.Network().BlackholeNetworkInterfaceID
+	if eniID == "" {
+		return nil
+	}
+
+	wanted := map[string]bool{}
+	for _, route := range s.getBlackholeRoutes() {
+		wanted[aws.StringValue(route.DestinationCidrBlock)] = true
+	}
+
+	current := map[string]bool{}
+	for _, route := range rt.Routes {
+		destination := aws.StringValue(route.DestinationCidrBlock)
+		current[destination] = true
+		if aws.StringValue(route.NetworkInterfaceId) != eniID || wanted[destination] {
+			continue
+		}
+
+		if _, err := s.EC2Client.DeleteRoute(&ec2.DeleteRouteInput{
+			RouteTableId:         rt.RouteTableId,
+			DestinationCidrBlock: route.DestinationCidrBlock,
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteRoute", "Failed to delete blackhole route %q from RouteTable %q: %v", destination, *rt.RouteTableId, err)
+			return errors.Wrapf(err, "failed to delete blackhole route %q from route table %q", destination, *rt.RouteTableId)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteRoute", "Deleted blackhole route %q from RouteTable %q", destination, *rt.RouteTableId)
+	}
+
+	for _, route := range s.getBlackholeRoutes() {
+		destination := aws.StringValue(route.DestinationCidrBlock)
+		if current[destination] {
+			continue
+		}
+
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.CreateRoute(&ec2.CreateRouteInput{
+				RouteTableId:         rt.RouteTableId,
+				DestinationCidrBlock: route.DestinationCidrBlock,
+				NetworkInterfaceId:   route.NetworkInterfaceId,
+			}); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.RouteTableNotFound); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateRoute", "Failed to create blackhole route %q for RouteTable %q: %v", destination, *rt.RouteTableId, err)
+			return errors.Wrapf(err, "failed to create blackhole route %q in route table %q", destination, *rt.RouteTableId)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateRoute", "Created blackhole route %q for RouteTable %q", destination, *rt.RouteTableId)
+	}
+
+	return nil
+}
+
 func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable, error) {
 	rts, err := s.describeVpcRouteTables()
 	if err != nil {
@@ -222,11 +293,11 @@ func (s *Service) describeVpcRouteTables() ([]*ec2.RouteTable, error) {
 	return out.RouteTables, nil
 }
 
-func (s *Service) createRouteTableWithRoutes(routes []*ec2.Route, isPublic bool, zone string) (*infrav1.RouteTable, error) {
+func (s *Service) createRouteTableWithRoutes(routes []*ec2.Route, role, zone string) (*infrav1.RouteTable, error) {
 	out, err := s.EC2Client.CreateRouteTable(&ec2.CreateRouteTableInput{
 		VpcId: aws.String(s.scope.VPC().ID),
 		TagSpecifications: []*ec2.TagSpecification{
-			tags.BuildParamsToTagSpecification(ec2.ResourceTypeRouteTable, s.getRouteTableTagParams(services.TemporaryResourceID, isPublic, zone))},
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeRouteTable, s.getRouteTableTagParams(services.TemporaryResourceID, role, zone))},
 	})
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateRouteTable", "Failed to create managed RouteTable: %v", err)
@@ -246,6 +317,7 @@ func (s *Service) createRouteTableWithRoutes(routes []*ec2.Route, isPublic bool,
 				InstanceId:                  route.InstanceId,
 				NatGatewayId:                route.NatGatewayId,
 				NetworkInterfaceId:          route.NetworkInterfaceId,
+				TransitGatewayId:            route.TransitGatewayId,
 				VpcPeeringConnectionId:      route.VpcPeeringConnectionId,
 			}); err != nil {
 				return false, err
@@ -293,16 +365,53 @@ func (s *Service) getGatewayPublicRoute() *ec2.Route {
 	}
 }
 
-func (s *Service) getRouteTableTagParams(id string, public bool, zone string) infrav1.BuildParams {
+// getSubnetGroupRoute returns the default route of the subnet group with the given name.
+func (s *Service) getSubnetGroupRoute(name string) (*ec2.Route, error) {
+	for _, group := range s.scope.SubnetGroups() {
+		if group.Name != name {
+			continue
+		}
+		return &ec2.Route{
+			DestinationCidrBlock:   aws.String(services.AnyIPv4CidrBlock),
+			GatewayId:              group.DefaultRoute.GatewayID,
+			NatGatewayId:           group.DefaultRoute.NatGatewayID,
+			TransitGatewayId:       group.DefaultRoute.TransitGatewayID,
+			VpcPeeringConnectionId: group.DefaultRoute.VPCPeeringConnectionID,
+			NetworkInterfaceId:     group.DefaultRoute.NetworkInterfaceID,
+		}, nil
+	}
+
+	return nil, errors.Errorf("failed to create routing tables: subnet group %q is not defined", name)
+}
+
+// routeTargetEqual returns true if both routes point at the same target.
+func routeTargetEqual(a, b *ec2.Route) bool {
+	return aws.StringValue(a.GatewayId) == aws.StringValue(b.GatewayId) &&
+		aws.StringValue(a.NatGatewayId) == aws.StringValue(b.NatGatewayId) &&
+		aws.StringValue(a.TransitGatewayId) == aws.StringValue(b.TransitGatewayId) &&
+		aws.StringValue(a.VpcPeeringConnectionId) == aws.StringValue(b.VpcPeeringConnectionId) &&
+		aws.StringValue(a.NetworkInterfaceId) == aws.StringValue(b.NetworkInterfaceId)
+}
+
+// routeTableRole returns the role of the route table of the subnet used in its name: public,
+// private or the name of its subnet group.
+func routeTableRole(sn *infrav1.SubnetSpec) string {
+	switch {
+	case sn.IsPublic:
+		return "public"
+	case sn.SubnetGroup != "":
+		return sn.SubnetGroup
+	default:
+		return "private"
+	}
+}
+
+func (s *Service) getRouteTableTagParams(id, role, zone string) infrav1.BuildParams {
 	var name strings.Builder
 
 	name.WriteString(s.scope.Name())
 	name.WriteString("-rt-")
-	if public {
-		name.WriteString("public")
-	} else {
-		name.WriteString("private")
-	}
+	name.WriteString(role)
 	name.WriteString("-")
 	name.WriteString(zone)
 
@@ -37,10 +37,11 @@ func TestReconcileRouteTables(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  *infrav1.NetworkSpec
-		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
-		err    error
+		name    string
+		input   *infrav1.NetworkSpec
+		network infrav1.Network
+		expect  func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		err     error
 	}{
 		{
 			name: "no routes existing, single private and single public, same AZ",
@@ -233,6 +234,205 @@ func TestReconcileRouteTables(t *testing.T) {
 					Return(nil, nil)
 			},
 		},
+		{
+			name: "no routes existing, subnet in a subnet group routes through its default route",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				SubnetGroups: []infrav1.SubnetGroupSpec{
+					{
+						Name:         "isolated",
+						DefaultRoute: infrav1.RouteTarget{TransitGatewayID: aws.String("tgw-01")},
+					},
+				},
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-routetables-isolated",
+						IsPublic:         false,
+						AvailabilityZone: "us-east-1a",
+						SubnetGroup:      "isolated",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				isolatedRouteTable := m.CreateRouteTable(matchRouteTableInput(&ec2.CreateRouteTableInput{VpcId: aws.String("vpc-routetables")})).
+					Return(&ec2.CreateRouteTableOutput{RouteTable: &ec2.RouteTable{RouteTableId: aws.String("rt-1")}}, nil)
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					TransitGatewayId:     aws.String("tgw-01"),
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					RouteTableId:         aws.String("rt-1"),
+				})).
+					After(isolatedRouteTable)
+
+				m.AssociateRouteTable(gomock.Eq(&ec2.AssociateRouteTableInput{
+					RouteTableId: aws.String("rt-1"),
+					SubnetId:     aws.String("subnet-routetables-isolated"),
+				})).
+					Return(&ec2.AssociateRouteTableOutput{}, nil).
+					After(isolatedRouteTable)
+			},
+		},
+		{
+			name: "routes exist, but subnet group default route points at a nat gateway, replaces it",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				SubnetGroups: []infrav1.SubnetGroupSpec{
+					{
+						Name:         "isolated",
+						DefaultRoute: infrav1.RouteTarget{TransitGatewayID: aws.String("tgw-01")},
+					},
+				},
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-routetables-isolated",
+						IsPublic:         false,
+						AvailabilityZone: "us-east-1a",
+						SubnetGroup:      "isolated",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("route-table-isolated"),
+								Associations: []*ec2.RouteTableAssociation{
+									{
+										SubnetId: aws.String("subnet-routetables-isolated"),
+									},
+								},
+								Routes: []*ec2.Route{
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										NatGatewayId:         aws.String("nat-01"),
+									},
+								},
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("common"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("test-cluster-rt-isolated-us-east-1a"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.ReplaceRoute(gomock.Eq(
+					&ec2.ReplaceRouteInput{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						RouteTableId:         aws.String("route-table-isolated"),
+						TransitGatewayId:     aws.String("tgw-01"),
+					},
+				)).
+					Return(nil, nil)
+			},
+		},
+		{
+			name: "routes exist, adds missing blackhole routes and removes stale ones",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-routetables-public",
+						IsPublic:         true,
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+				BlackholeRoutes: []infrav1.BlackholeRoute{
+					{DestinationCIDRBlock: "192.0.2.0/24"},
+					{DestinationCIDRBlock: "198.51.100.0/24"},
+				},
+			},
+			network: infrav1.Network{
+				BlackholeNetworkInterfaceID: "eni-blackhole",
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("route-table-public"),
+								Associations: []*ec2.RouteTableAssociation{
+									{
+										SubnetId: aws.String("subnet-routetables-public"),
+									},
+								},
+								Routes: []*ec2.Route{
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										GatewayId:            aws.String("igw-01"),
+									},
+									{
+										DestinationCidrBlock: aws.String("192.0.2.0/24"),
+										NetworkInterfaceId:   aws.String("eni-blackhole"),
+									},
+									{
+										DestinationCidrBlock: aws.String("203.0.113.0/24"),
+										NetworkInterfaceId:   aws.String("eni-blackhole"),
+									},
+								},
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("common"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("test-cluster-rt-public-us-east-1a"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DeleteRoute(gomock.Eq(&ec2.DeleteRouteInput{
+					DestinationCidrBlock: aws.String("203.0.113.0/24"),
+					RouteTableId:         aws.String("route-table-public"),
+				})).
+					Return(&ec2.DeleteRouteOutput{}, nil)
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					DestinationCidrBlock: aws.String("198.51.100.0/24"),
+					NetworkInterfaceId:   aws.String("eni-blackhole"),
+					RouteTableId:         aws.String("route-table-public"),
+				})).
+					Return(&ec2.CreateRouteOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -247,6 +447,9 @@ func TestReconcileRouteTables(t *testing.T) {
 					Spec: infrav1.AWSClusterSpec{
 						NetworkSpec: *tc.input,
 					},
+					Status: infrav1.AWSClusterStatus{
+						Network: tc.network,
+					},
 				},
 			})
 			if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package network
 
 import (
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
@@ -42,6 +43,12 @@ type Scope interface {
 	SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
 	// SecondaryCidrBlock returns the optional secondary CIDR block to use for pod IPs
 	SecondaryCidrBlock() *string
+	// SubnetGroups returns the groups of subnets with a dedicated default route.
+	SubnetGroups() []infrav1.SubnetGroupSpec
+	// BlackholeRoutes returns the routes dropping the traffic to their destination.
+	BlackholeRoutes() []infrav1.BlackholeRoute
+	// NatGatewayMonitoring returns the spec of the CloudWatch alarms on the managed NAT gateways.
+	NatGatewayMonitoring() *infrav1.NatGatewayMonitoringSpec
 
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
@@ -51,14 +58,16 @@ type Scope interface {
 // The interfaces are broken down like this to group functions together.
 // One alternative is to have a large list of functions from the ec2 client.
 type Service struct {
-	scope     Scope
-	EC2Client ec2iface.EC2API
+	scope            Scope
+	EC2Client        ec2iface.EC2API
+	CloudWatchClient cloudwatchiface.CloudWatchAPI
 }
 
 // NewService returns a new service given the ec2 api client.
 func NewService(networkScope Scope) *Service {
 	return &Service{
-		scope:     networkScope,
-		EC2Client: scope.NewEC2Client(networkScope, networkScope, networkScope, networkScope.InfraCluster()),
+		scope:            networkScope,
+		EC2Client:        scope.NewEC2Client(networkScope, networkScope, networkScope, networkScope.InfraCluster()),
+		CloudWatchClient: scope.NewCloudWatchClient(networkScope, networkScope, networkScope, networkScope.InfraCluster()),
 	}
 }
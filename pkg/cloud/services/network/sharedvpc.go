@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// reconcileSharedVPC makes sure the subnets of a VPC shared with the cluster's account through AWS
+// RAM are shared by the owner of the VPC, and records them on the network status. It only describes
+// the subnets, since the cluster's account cannot modify resources owned by another account.
+func (s *Service) reconcileSharedVPC() error {
+	owner := s.scope.VPC().OwnerAccountID
+	if owner == "" {
+		s.scope.Network().SharedVPC = nil
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling shared VPC subnets", "owner-account-id", owner)
+
+	ids := make([]string, 0, len(s.scope.Subnets()))
+	for _, sn := range s.scope.Subnets() {
+		ids = append(ids, sn.ID)
+	}
+	sort.Strings(ids)
+
+	out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(ids)})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDescribeSharedSubnets", "Failed to describe the subnets shared by account %q: %v", owner, err)
+		return errors.Wrapf(err, "failed to describe the subnets shared by account %q", owner)
+	}
+
+	found := map[string]*ec2.Subnet{}
+	for _, sn := range out.Subnets {
+		found[aws.StringValue(sn.SubnetId)] = sn
+	}
+	for _, id := range ids {
+		sn, ok := found[id]
+		if !ok {
+			return errors.Errorf("subnet %q is not shared with the cluster's account", id)
+		}
+		if aws.StringValue(sn.OwnerId) != owner {
+			record.Warnf(s.scope.InfraCluster(), "FailedSharedSubnet", "Subnet %q is owned by account %q rather than %q", id, aws.StringValue(sn.OwnerId), owner)
+			return errors.Errorf("subnet %q is owned by account %q, expected it to be shared by account %q", id, aws.StringValue(sn.OwnerId), owner)
+		}
+	}
+
+	s.scope.Network().SharedVPC = &infrav1.SharedVPCStatus{
+		OwnerAccountID: owner,
+		SubnetIDs:      ids,
+	}
+	return nil
+}
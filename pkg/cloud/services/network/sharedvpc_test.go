@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestReconcileSharedVPC(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const owner = "111111111111"
+
+	describeSubnets := func(m *mock_ec2iface.MockEC2APIMockRecorder, subnets ...*ec2.Subnet) {
+		m.DescribeSubnets(gomock.Eq(&ec2.DescribeSubnetsInput{
+			SubnetIds: aws.StringSlice([]string{"subnet-1", "subnet-2"}),
+		})).Return(&ec2.DescribeSubnetsOutput{Subnets: subnets}, nil)
+	}
+	subnet := func(id, owner string) *ec2.Subnet {
+		return &ec2.Subnet{SubnetId: aws.String(id), OwnerId: aws.String(owner)}
+	}
+
+	testCases := []struct {
+		name       string
+		owner      string
+		expect     func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		wantStatus *infrav1.SharedVPCStatus
+		wantErr    bool
+	}{
+		{
+			name:  "records the subnets shared by the owner of the VPC",
+			owner: owner,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeSubnets(m, subnet("subnet-1", owner), subnet("subnet-2", owner))
+			},
+			wantStatus: &infrav1.SharedVPCStatus{OwnerAccountID: owner, SubnetIDs: []string{"subnet-1", "subnet-2"}},
+		},
+		{
+			name:  "fails for subnets owned by another account",
+			owner: owner,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeSubnets(m, subnet("subnet-1", owner), subnet("subnet-2", "222222222222"))
+			},
+			wantErr: true,
+		},
+		{
+			name:  "fails for subnets that are not shared with the account",
+			owner: owner,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeSubnets(m, subnet("subnet-1", owner))
+			},
+			wantErr: true,
+		},
+		{
+			name:   "does not call EC2 for VPCs that are not shared",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID:             "vpc-shared",
+							OwnerAccountID: tc.owner,
+						},
+						Subnets: infrav1.Subnets{
+							{ID: "subnet-2", IsPublic: true},
+							{ID: "subnet-1"},
+						},
+					},
+				},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.reconcileSharedVPC()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if got := awsCluster.Status.Network.SharedVPC; !reflect.DeepEqual(got, tc.wantStatus) {
+				t.Fatalf("expected shared VPC status %v, got %v", tc.wantStatus, got)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
@@ -127,7 +128,7 @@ func (s *Service) reconcileSubnets() error {
 
 			// Update subnet spec with the existing subnet details
 			// TODO(vincepri): check if subnet needs to be updated.
-			existingSubnet.DeepCopyInto(sub)
+			updateSubnetSpec(sub, existingSubnet)
 		} else if unmanagedVPC {
 			// If there is no existing subnet and we have an umanaged vpc report an error
 			record.Warnf(s.scope.InfraCluster(), "FailedMatchSubnet", "Using unmanaged VPC and failed to find existing subnet for specified subnet id %d, cidr %q", sub.ID, sub.CidrBlock)
@@ -163,7 +164,24 @@ func (s *Service) reconcileSubnets() error {
 			if err != nil {
 				return err
 			}
-			nsn.DeepCopyInto(subnet)
+			updateSubnetSpec(subnet, nsn)
+		}
+	}
+
+	if !unmanagedVPC {
+		if err := s.reconcileSubnetAttributes(subnets); err != nil {
+			return err
+		}
+	}
+
+	// Now that the CIDR blocks of all subnets are known, make sure their private IP pools fit in them.
+	for i, subnet := range subnets {
+		if subnet.PrivateIPPool == nil {
+			continue
+		}
+		if errs := subnet.PrivateIPPool.Validate(subnet.CidrBlock, field.NewPath("spec", "networkSpec", "subnets").Index(i).Child("privateIPPool")); len(errs) > 0 {
+			record.Warnf(s.scope.InfraCluster(), "FailedSubnetPrivateIPPool", "Invalid private IP pool for subnet %q: %v", subnet.ID, errs.ToAggregate())
+			return errors.Wrapf(errs.ToAggregate(), "invalid private IP pool for subnet %q", subnet.ID)
 		}
 	}
 
@@ -172,6 +190,16 @@ func (s *Service) reconcileSubnets() error {
 	return nil
 }
 
+// updateSubnetSpec updates the subnet spec with the details observed in AWS, keeping the settings
+// that only exist in the spec.
+func updateSubnetSpec(spec, observed *infrav1.SubnetSpec) {
+	pool, group := spec.PrivateIPPool, spec.SubnetGroup
+	borderGroup, natBorderGroup := spec.NetworkBorderGroup, spec.NatGatewayNetworkBorderGroup
+	observed.DeepCopyInto(spec)
+	spec.PrivateIPPool, spec.SubnetGroup = pool, group
+	spec.NetworkBorderGroup, spec.NatGatewayNetworkBorderGroup = borderGroup, natBorderGroup
+}
+
 func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 	zones, err := s.getAvailableZones()
 	if err != nil {
@@ -231,6 +259,32 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 	return subnets, nil
 }
 
+// reconcileSubnetAttributes makes sure instances launched into subnets with an IPv6 CIDR block are
+// assigned an IPv6 address, reverting the attribute if it was changed outside of the provider.
+func (s *Service) reconcileSubnetAttributes(subnets infrav1.Subnets) error {
+	for _, subnet := range subnets {
+		if subnet.IPv6CidrBlock == "" || subnet.AssignIPv6AddressOnCreation {
+			continue
+		}
+
+		s.scope.V(2).Info("Enabling IPv6 address assignment on subnet", "subnet-id", subnet.ID, "ipv6-cidr-block", subnet.IPv6CidrBlock)
+		if _, err := s.EC2Client.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+			SubnetId: aws.String(subnet.ID),
+			AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
+				Value: aws.Bool(true),
+			},
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", subnet.ID, err)
+			return errors.Wrapf(err, "failed to enable IPv6 address assignment on subnet %q", subnet.ID)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", subnet.ID)
+
+		subnet.AssignIPv6AddressOnCreation = true
+	}
+
+	return nil
+}
+
 func (s *Service) deleteSubnets() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.V(4).Info("Skipping subnets deletion in unmanaged mode")
@@ -286,10 +340,19 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 	// We also look for a tag indicating that a particular subnet should be public, to try and determine whether a managed VPC's subnet should have such a route, but does not.
 	for _, ec2sn := range out.Subnets {
 		spec := &infrav1.SubnetSpec{
-			ID:               *ec2sn.SubnetId,
-			CidrBlock:        *ec2sn.CidrBlock,
-			AvailabilityZone: *ec2sn.AvailabilityZone,
-			Tags:             converters.TagsToMap(ec2sn.Tags),
+			ID:                          *ec2sn.SubnetId,
+			CidrBlock:                   *ec2sn.CidrBlock,
+			AvailabilityZone:            *ec2sn.AvailabilityZone,
+			OutpostARN:                  aws.StringValue(ec2sn.OutpostArn),
+			AssignIPv6AddressOnCreation: aws.BoolValue(ec2sn.AssignIpv6AddressOnCreation),
+			Tags:                        converters.TagsToMap(ec2sn.Tags),
+		}
+
+		for _, assoc := range ec2sn.Ipv6CidrBlockAssociationSet {
+			if assoc.Ipv6CidrBlockState != nil && aws.StringValue(assoc.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+				spec.IPv6CidrBlock = aws.StringValue(assoc.Ipv6CidrBlock)
+				break
+			}
 		}
 
 		// A subnet is public if it's tagged as such...
@@ -323,7 +386,7 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 }
 
 func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, error) {
-	out, err := s.EC2Client.CreateSubnet(&ec2.CreateSubnetInput{
+	input := &ec2.CreateSubnetInput{
 		VpcId:            aws.String(s.scope.VPC().ID),
 		CidrBlock:        aws.String(sn.CidrBlock),
 		AvailabilityZone: aws.String(sn.AvailabilityZone),
@@ -333,7 +396,12 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 				s.getSubnetTagParams(services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags),
 			),
 		},
-	})
+	}
+	if sn.OutpostARN != "" {
+		input.OutpostArn = aws.String(sn.OutpostARN)
+	}
+
+	out, err := s.EC2Client.CreateSubnet(input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateSubnet", "Failed creating new managed Subnet %v", err)
 		return nil, errors.Wrap(err, "failed to create subnet")
@@ -346,7 +414,8 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		return nil, errors.Wrapf(err, "failed to wait for subnet %q", *out.Subnet.SubnetId)
 	}
 
-	if sn.IsPublic {
+	// Outpost subnets use customer-owned IP addresses rather than public ones.
+	if sn.IsPublic && sn.OutpostARN == "" {
 		attReq := &ec2.ModifySubnetAttributeInput{
 			MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
 				Value: aws.Bool(true),
@@ -377,6 +446,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		AvailabilityZone: *out.Subnet.AvailabilityZone,
 		CidrBlock:        *out.Subnet.CidrBlock,
 		IsPublic:         sn.IsPublic,
+		OutpostARN:       aws.StringValue(out.Subnet.OutpostArn),
 	}, nil
 }
 
@@ -429,3 +499,25 @@ func (s *Service) getSubnetTagParams(id string, public bool, zone string, manual
 		Additional:  additionalTags,
 	}
 }
+
+// updateSubnetGroupStatus records the subnets and route tables of every subnet group in the network status.
+func (s *Service) updateSubnetGroupStatus() {
+	groups := s.scope.SubnetGroups()
+	if len(groups) == 0 {
+		s.scope.Network().SubnetGroups = nil
+		return
+	}
+
+	status := make([]infrav1.SubnetGroupStatus, 0, len(groups))
+	for _, group := range groups {
+		groupStatus := infrav1.SubnetGroupStatus{Name: group.Name}
+		for _, sn := range s.scope.Subnets().FilterBySubnetGroup(group.Name) {
+			groupStatus.SubnetIDs = append(groupStatus.SubnetIDs, sn.ID)
+			if sn.RouteTableID != nil {
+				groupStatus.RouteTableIDs = append(groupStatus.RouteTableIDs, *sn.RouteTableID)
+			}
+		}
+		status = append(status, groupStatus)
+	}
+	s.scope.Network().SubnetGroups = status
+}
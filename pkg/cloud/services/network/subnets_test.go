@@ -1165,6 +1165,92 @@ func TestReconcileSubnets(t *testing.T) {
 					After(zone1PrivateSubnet)
 			},
 		},
+		{
+			name: "Managed VPC, existing subnets with IPv6 CIDR blocks, should enable IPv6 address assignment where missing",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []*infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.0.0/17",
+						IsPublic:         true,
+					},
+					{
+						ID:               "subnet-2",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.128.0/17",
+						IsPublic:         false,
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:                       aws.String(subnetsVPCID),
+								SubnetId:                    aws.String("subnet-1"),
+								AvailabilityZone:            aws.String("us-east-1a"),
+								CidrBlock:                   aws.String("10.0.0.0/17"),
+								AssignIpv6AddressOnCreation: aws.Bool(false),
+								Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+									{
+										Ipv6CidrBlock: aws.String("2600:1f18:1::/64"),
+										Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+											State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+										},
+									},
+								},
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("public"),
+									},
+								},
+							},
+							{
+								VpcId:                       aws.String(subnetsVPCID),
+								SubnetId:                    aws.String("subnet-2"),
+								AvailabilityZone:            aws.String("us-east-1a"),
+								CidrBlock:                   aws.String("10.0.128.0/17"),
+								AssignIpv6AddressOnCreation: aws.Bool(true),
+								Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+									{
+										Ipv6CidrBlock: aws.String("2600:1f18:2::/64"),
+										Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+											State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).
+					AnyTimes()
+
+				m.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+					AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-1"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+			},
+		},
 		{
 			name: "Managed VPC, existing public subnet, 2 subnets in spec, should create 1 subnet",
 			input: &infrav1.NetworkSpec{
@@ -71,8 +71,14 @@ func (s *Service) reconcileVPC() error {
 	//
 	// NOTE: it may look like we are losing InternetGatewayID because it's not populated by describeVPC/createVPC or
 	// restored here, but that's ok. It is restored by reconcileInternetGateways, which is invoked after this.
+	// describeVPC/createVPC report the tenancy the VPC actually has, which is surfaced on the status
+	// before the tenancy asked for in the spec is restored.
+	s.scope.Network().VPCInstanceTenancy = vpc.InstanceTenancy
 	vpc.AvailabilityZoneSelection = s.scope.VPC().AvailabilityZoneSelection
 	vpc.AvailabilityZoneUsageLimit = s.scope.VPC().AvailabilityZoneUsageLimit
+	vpc.InstanceTenancy = s.scope.VPC().InstanceTenancy
+	vpc.DHCPOptionsSetID = s.scope.VPC().DHCPOptionsSetID
+	vpc.OwnerAccountID = s.scope.VPC().OwnerAccountID
 
 	if vpc.IsUnmanaged(s.scope.Name()) {
 		vpc.DeepCopyInto(s.scope.VPC())
@@ -180,6 +186,9 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 			tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpc, s.getVPCTagParams(services.TemporaryResourceID)),
 		},
 	}
+	if s.scope.VPC().InstanceTenancy != "" {
+		input.InstanceTenancy = aws.String(s.scope.VPC().InstanceTenancy)
+	}
 
 	out, err := s.EC2Client.CreateVpc(input)
 	if err != nil {
@@ -200,9 +209,10 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 	}
 
 	return &infrav1.VPCSpec{
-		ID:        *out.Vpc.VpcId,
-		CidrBlock: *out.Vpc.CidrBlock,
-		Tags:      converters.TagsToMap(out.Vpc.Tags),
+		ID:              *out.Vpc.VpcId,
+		CidrBlock:       *out.Vpc.CidrBlock,
+		Tags:            converters.TagsToMap(out.Vpc.Tags),
+		InstanceTenancy: aws.StringValue(out.Vpc.InstanceTenancy),
 	}, nil
 }
 
@@ -269,9 +279,10 @@ func (s *Service) describeVPC() (*infrav1.VPCSpec, error) {
 	}
 
 	return &infrav1.VPCSpec{
-		ID:        *out.Vpcs[0].VpcId,
-		CidrBlock: *out.Vpcs[0].CidrBlock,
-		Tags:      converters.TagsToMap(out.Vpcs[0].Tags),
+		ID:              *out.Vpcs[0].VpcId,
+		CidrBlock:       *out.Vpcs[0].CidrBlock,
+		Tags:            converters.TagsToMap(out.Vpcs[0].Tags),
+		InstanceTenancy: aws.StringValue(out.Vpcs[0].InstanceTenancy),
 	}, nil
 }
 
@@ -68,10 +68,11 @@ func TestReconcileVPC(t *testing.T) {
 	selection := infrav1.AZSelectionSchemeOrdered
 
 	testCases := []struct {
-		name     string
-		input    *infrav1.VPCSpec
-		expected *infrav1.VPCSpec
-		expect   func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		name            string
+		input           *infrav1.VPCSpec
+		expected        *infrav1.VPCSpec
+		expectedTenancy string
+		expect          func(m *mock_ec2iface.MockEC2APIMockRecorder)
 	}{
 		{
 			name:  "managed vpc exists",
@@ -191,6 +192,64 @@ func TestReconcileVPC(t *testing.T) {
 					Return(nil)
 			},
 		},
+		{
+			name:  "managed vpc with dedicated tenancy does not exist",
+			input: &infrav1.VPCSpec{AvailabilityZoneUsageLimit: &usageLimit, AvailabilityZoneSelection: &selection, InstanceTenancy: ec2.TenancyDedicated},
+			expected: &infrav1.VPCSpec{
+				ID:        "vpc-new",
+				CidrBlock: "10.0.0.0/16",
+				Tags: map[string]string{
+					"sigs.k8s.io/cluster-api-provider-aws/role": "common",
+					"Name": "test-cluster-vpc",
+					"sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster": "owned",
+				},
+				AvailabilityZoneUsageLimit: &usageLimit,
+				AvailabilityZoneSelection:  &selection,
+				InstanceTenancy:            ec2.TenancyDedicated,
+			},
+			expectedTenancy: ec2.TenancyDedicated,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeVpcs(gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+					Return(&ec2.DescribeVpcsOutput{}, nil)
+
+				m.CreateVpc(gomock.AssignableToTypeOf(&ec2.CreateVpcInput{})).
+					DoAndReturn(func(input *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
+						if aws.StringValue(input.InstanceTenancy) != ec2.TenancyDedicated {
+							t.Errorf("expected dedicated instance tenancy, got %v", input.InstanceTenancy)
+						}
+						return &ec2.CreateVpcOutput{
+							Vpc: &ec2.Vpc{
+								State:           aws.String("available"),
+								VpcId:           aws.String("vpc-new"),
+								CidrBlock:       input.CidrBlock,
+								InstanceTenancy: aws.String(ec2.TenancyDedicated),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("common"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("test-cluster-vpc"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						}, nil
+					})
+
+				m.DescribeVpcAttribute(gomock.AssignableToTypeOf(&ec2.DescribeVpcAttributeInput{})).
+					DoAndReturn(describeVpcAttributeTrue).AnyTimes()
+
+				m.WaitUntilVpcAvailable(gomock.Eq(&ec2.DescribeVpcsInput{
+					VpcIds: []*string{aws.String("vpc-new")},
+				})).
+					Return(nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -233,6 +292,10 @@ func TestReconcileVPC(t *testing.T) {
 			if !reflect.DeepEqual(tc.expected, &clusterScope.AWSCluster.Spec.NetworkSpec.VPC) {
 				t.Errorf("Actual/expected mismatch: %s", diff.ObjectDiff(tc.expected, clusterScope.AWSCluster.Spec.NetworkSpec.VPC))
 			}
+
+			if got := clusterScope.AWSCluster.Status.Network.VPCInstanceTenancy; got != tc.expectedTenancy {
+				t.Errorf("expected VPC instance tenancy %q, got %q", tc.expectedTenancy, got)
+			}
 		})
 	}
 }
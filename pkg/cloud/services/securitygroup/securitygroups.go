@@ -48,6 +48,9 @@ const (
 
 	// IPProtocolICMPv6 is how EC2 represents the ICMPv6 protocol in ingress rules
 	IPProtocolICMPv6 = "58"
+
+	// apiServerHealthCheckPort is the port the API server load balancer health checks the control plane instances on
+	apiServerHealthCheckPort = 6443
 )
 
 var (
@@ -150,7 +153,13 @@ func (s *Service) ReconcileSecurityGroups() error {
 		s.scope.V(2).Info("second pass security group reconciliation", "group-id", sg.ID, "name", sg.Name, "role", i)
 
 		if s.securityGroupIsOverridden(sg.ID) {
-			// skip rule/tag reconciliation on security groups that are overridden, assuming they're managed by another process
+			// skip rule/tag reconciliation on security groups that are overridden, assuming they're managed by another process,
+			// except for the access the API server load balancer's health checks need to bring up the cluster.
+			if i == infrav1.SecurityGroupControlPlane {
+				if err := s.reconcileLoadBalancerHealthCheckIngress(sg); err != nil {
+					return err
+				}
+			}
 			continue
 		}
 
@@ -158,13 +167,20 @@ func (s *Service) ReconcileSecurityGroups() error {
 			// skip rule reconciliation, as we expect the in-cluster cloud integration to manage them
 			continue
 		}
-		current := sg.IngressRules
+		current, err := s.pruneStaleIngressRules(sg)
+		if err != nil {
+			return err
+		}
 
 		want, err := s.getSecurityGroupIngressRules(i)
 		if err != nil {
 			return err
 		}
 
+		if err := s.validateIngressRuleSources(sg, want); err != nil {
+			return err
+		}
+
 		toRevoke := current.Difference(want)
 		if len(toRevoke) > 0 {
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
@@ -469,6 +485,58 @@ func (s *Service) revokeAllSecurityGroupIngressRules(id string) error {
 	return nil
 }
 
+// reconcileLoadBalancerHealthCheckIngress makes sure the control plane security group lets the API
+// server load balancer's health checks, which originate from the load balancer's security group,
+// reach the API server. It only ever adds the rule, leaving any other rules of the group untouched.
+func (s *Service) reconcileLoadBalancerHealthCheckIngress(sg infrav1.SecurityGroup) error {
+	lbSecurityGroupID := s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID
+	if lbSecurityGroupID == "" || ingressAllowsSecurityGroup(sg.IngressRules, apiServerHealthCheckPort, lbSecurityGroupID) {
+		return nil
+	}
+
+	rule := &infrav1.IngressRule{
+		Description:            "Kubernetes API load balancer health checks",
+		Protocol:               infrav1.SecurityGroupProtocolTCP,
+		FromPort:               apiServerHealthCheckPort,
+		ToPort:                 apiServerHealthCheckPort,
+		SourceSecurityGroupIDs: []string{lbSecurityGroupID},
+	}
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if err := s.authorizeSecurityGroupIngressRules(sg.ID, infrav1.IngressRules{rule}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.GroupNotFound); err != nil {
+		return errors.Wrapf(err, "failed to authorize load balancer health checks in security group %q", sg.ID)
+	}
+
+	s.scope.V(2).Info("Authorized load balancer health checks in security group", "security-group-id", sg.ID, "source-security-group-id", lbSecurityGroupID)
+	return nil
+}
+
+// ingressAllowsSecurityGroup returns true if any of the rules allows TCP traffic on the port from
+// the source security group.
+func ingressAllowsSecurityGroup(rules infrav1.IngressRules, port int64, sourceSecurityGroupID string) bool {
+	for _, rule := range rules {
+		switch rule.Protocol {
+		case infrav1.SecurityGroupProtocolAll:
+		case infrav1.SecurityGroupProtocolTCP:
+			if port < rule.FromPort || port > rule.ToPort {
+				continue
+			}
+		default:
+			continue
+		}
+
+		for _, id := range rule.SourceSecurityGroupIDs {
+			if id == sourceSecurityGroupID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (s *Service) defaultSSHIngressRule(sourceSecurityGroupID string) *infrav1.IngressRule {
 	return &infrav1.IngressRule{
 		Description:            "SSH",
@@ -584,6 +652,111 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 	return nil, errors.Errorf("Cannot determine ingress rules for unknown security group role %q", role)
 }
 
+// pruneStaleIngressRules revokes the references of the security group's ingress rules to source
+// security groups that no longer exist, such as the previous ID of a managed security group that was
+// deleted and recreated, and returns the ingress rules that are left. Rules are then authorized
+// against the current IDs of the managed security groups like any other missing rule.
+func (s *Service) pruneStaleIngressRules(sg infrav1.SecurityGroup) (infrav1.IngressRules, error) {
+	known := make(map[string]struct{}, len(s.scope.SecurityGroups()))
+	for _, group := range s.scope.SecurityGroups() {
+		if group.ID != "" {
+			known[group.ID] = struct{}{}
+		}
+	}
+
+	var unknown []string
+	for _, rule := range sg.IngressRules {
+		for _, id := range rule.SourceSecurityGroupIDs {
+			if _, ok := known[id]; !ok {
+				unknown = append(unknown, id)
+			}
+		}
+	}
+	if len(unknown) == 0 {
+		return sg.IngressRules, nil
+	}
+
+	existing, err := s.describeExistingSecurityGroupIDs(unknown)
+	if err != nil {
+		return nil, err
+	}
+
+	var remaining, stale infrav1.IngressRules
+	for _, rule := range sg.IngressRules {
+		kept := rule.DeepCopy()
+		kept.SourceSecurityGroupIDs = nil
+		var staleIDs []string
+		for _, id := range rule.SourceSecurityGroupIDs {
+			_, isKnown := known[id]
+			_, exists := existing[id]
+			if !isKnown && !exists {
+				staleIDs = append(staleIDs, id)
+				continue
+			}
+			kept.SourceSecurityGroupIDs = append(kept.SourceSecurityGroupIDs, id)
+		}
+
+		if len(staleIDs) > 0 {
+			staleRule := rule.DeepCopy()
+			staleRule.CidrBlocks = nil
+			staleRule.SourceSecurityGroupIDs = staleIDs
+			stale = append(stale, staleRule)
+		}
+		if len(kept.CidrBlocks) > 0 || len(kept.SourceSecurityGroupIDs) > 0 {
+			remaining = append(remaining, kept)
+		}
+	}
+	if len(stale) == 0 {
+		return sg.IngressRules, nil
+	}
+
+	// The source security groups are gone, so there is nothing to wait for.
+	if err := s.revokeSecurityGroupIngressRules(sg.ID, stale); err != nil {
+		return nil, errors.Wrapf(err, "failed to revoke stale ingress rules of security group %q", sg.ID)
+	}
+	s.scope.V(2).Info("Revoked ingress rules referencing deleted security groups", "revoked-ingress-rules", stale, "security-group-id", sg.ID)
+
+	return remaining, nil
+}
+
+// describeExistingSecurityGroupIDs returns the IDs of the given security groups that exist.
+func (s *Service) describeExistingSecurityGroupIDs(ids []string) (map[string]struct{}, error) {
+	out, err := s.EC2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{filter.EC2.SecurityGroupIDs(ids...)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security groups %v", ids)
+	}
+
+	existing := make(map[string]struct{}, len(out.SecurityGroups))
+	for _, sg := range out.SecurityGroups {
+		existing[aws.StringValue(sg.GroupId)] = struct{}{}
+	}
+	return existing, nil
+}
+
+// validateIngressRuleSources makes sure every security group referenced as the source of an ingress rule
+// is one of the cluster's reconciled security groups, so that rules are never authorized for an empty or
+// stale group ID.
+func (s *Service) validateIngressRuleSources(sg infrav1.SecurityGroup, rules infrav1.IngressRules) error {
+	known := make(map[string]struct{}, len(s.scope.SecurityGroups()))
+	for _, group := range s.scope.SecurityGroups() {
+		if group.ID != "" {
+			known[group.ID] = struct{}{}
+		}
+	}
+
+	for _, rule := range rules {
+		for _, id := range rule.SourceSecurityGroupIDs {
+			if _, ok := known[id]; !ok {
+				return errors.Errorf("ingress rule %q of security group %q references unknown source security group %q", rule.Description, sg.ID, id)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) getSecurityGroupName(clusterName string, role infrav1.SecurityGroupRole) string {
 	groupPrefix := clusterName
 	if strings.HasPrefix(clusterName, "sg-") {
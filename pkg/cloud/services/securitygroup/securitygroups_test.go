@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
@@ -266,6 +267,59 @@ func TestReconcileSecurityGroups(t *testing.T) {
 					infrav1.SecurityGroupNode:         "sg-node",
 				},
 			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{
+							{GroupId: aws.String("sg-bastion"), GroupName: aws.String("Bastion Security Group")},
+							{GroupId: aws.String("sg-apiserver-lb"), GroupName: aws.String("API load balancer Security Group")},
+							{GroupId: aws.String("sg-lb"), GroupName: aws.String("Load balancer Security Group")},
+							{
+								GroupId:   aws.String("sg-control"),
+								GroupName: aws.String("Control plane Security Group"),
+								IpPermissions: []*ec2.IpPermission{
+									{
+										IpProtocol:       aws.String("tcp"),
+										FromPort:         aws.Int64(6443),
+										ToPort:           aws.Int64(6443),
+										UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-apiserver-lb")}},
+									},
+								},
+							},
+							{GroupId: aws.String("sg-node"), GroupName: aws.String("Node Security Group")},
+						},
+					}, nil).AnyTimes()
+
+			},
+		},
+		{
+			name: "all overridden, control plane does not allow load balancer health checks, authorizes them",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-securitygroups",
+					InternetGatewayID: aws.String("igw-01"),
+				},
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-securitygroups-private",
+						IsPublic:         false,
+						AvailabilityZone: "us-east-1a",
+					},
+					&infrav1.SubnetSpec{
+						ID:               "subnet-securitygroups-public",
+						IsPublic:         true,
+						NatGatewayID:     aws.String("nat-01"),
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupBastion:      "sg-bastion",
+					infrav1.SecurityGroupAPIServerLB:  "sg-apiserver-lb",
+					infrav1.SecurityGroupLB:           "sg-lb",
+					infrav1.SecurityGroupControlPlane: "sg-control",
+					infrav1.SecurityGroupNode:         "sg-node",
+				},
+			},
 			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
 				m.DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
 					Return(&ec2.DescribeSecurityGroupsOutput{
@@ -278,6 +332,23 @@ func TestReconcileSecurityGroups(t *testing.T) {
 						},
 					}, nil).AnyTimes()
 
+				m.AuthorizeSecurityGroupIngress(gomock.Eq(&ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-control"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(6443),
+							ToPort:     aws.Int64(6443),
+							UserIdGroupPairs: []*ec2.UserIdGroupPair{
+								{
+									Description: aws.String("Kubernetes API load balancer health checks"),
+									GroupId:     aws.String("sg-apiserver-lb"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
 			},
 		},
 		{
@@ -385,3 +456,191 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 		}
 	}
 }
+
+func TestIntraClusterIngressRulesReferenceSecurityGroups(t *testing.T) {
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{CidrBlock: "10.0.0.0/16"},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.Network{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupBastion:      {ID: "sg-bastion"},
+						infrav1.SecurityGroupAPIServerLB:  {ID: "sg-apiserver-lb"},
+						infrav1.SecurityGroupLB:           {ID: "sg-lb"},
+						infrav1.SecurityGroupControlPlane: {ID: "sg-control"},
+						infrav1.SecurityGroupNode:         {ID: "sg-node"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	s := NewService(scope)
+	for _, role := range []infrav1.SecurityGroupRole{infrav1.SecurityGroupControlPlane, infrav1.SecurityGroupNode} {
+		rules, err := s.getSecurityGroupIngressRules(role)
+		if err != nil {
+			t.Fatalf("Failed to lookup %s security group ingress rules: %v", role, err)
+		}
+
+		for _, r := range rules {
+			if sets.NewString(r.CidrBlocks...).Has("10.0.0.0/16") {
+				t.Fatalf("Ingress rule %q of %s security group allows the VPC CIDR block", r.Description, role)
+			}
+		}
+
+		if err := s.validateIngressRuleSources(scope.SecurityGroups()[role], rules); err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+	}
+
+	delete(scope.SecurityGroups(), infrav1.SecurityGroupBastion)
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupNode)
+	if err != nil {
+		t.Fatalf("Failed to lookup node security group ingress rules: %v", err)
+	}
+	if err := s.validateIngressRuleSources(scope.SecurityGroups()[infrav1.SecurityGroupNode], rules); err == nil {
+		t.Fatal("expected an error for a rule referencing a missing security group")
+	}
+}
+
+func TestPruneStaleIngressRules(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	kubeletRule := &infrav1.IngressRule{
+		Description:            "Kubelet API",
+		Protocol:               infrav1.SecurityGroupProtocolTCP,
+		FromPort:               10250,
+		ToPort:                 10250,
+		SourceSecurityGroupIDs: []string{"sg-control-old", "sg-node"},
+	}
+	sshRule := &infrav1.IngressRule{
+		Description:            "SSH",
+		Protocol:               infrav1.SecurityGroupProtocolTCP,
+		FromPort:               22,
+		ToPort:                 22,
+		SourceSecurityGroupIDs: []string{"sg-bastion"},
+	}
+	externalRule := &infrav1.IngressRule{
+		Description:            "Monitoring",
+		Protocol:               infrav1.SecurityGroupProtocolTCP,
+		FromPort:               9100,
+		ToPort:                 9100,
+		SourceSecurityGroupIDs: []string{"sg-monitoring"},
+	}
+
+	testCases := []struct {
+		name   string
+		rules  infrav1.IngressRules
+		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		want   infrav1.IngressRules
+	}{
+		{
+			name:  "control plane security group was recreated, revokes the references to the deleted group",
+			rules: infrav1.IngressRules{kubeletRule, sshRule, externalRule},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Eq(&ec2.DescribeSecurityGroupsInput{
+					Filters: []*ec2.Filter{filter.EC2.SecurityGroupIDs("sg-control-old", "sg-monitoring")},
+				})).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-monitoring")}},
+				}, nil)
+				m.RevokeSecurityGroupIngress(gomock.Eq(&ec2.RevokeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-node"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(10250),
+							ToPort:     aws.Int64(10250),
+							UserIdGroupPairs: []*ec2.UserIdGroupPair{
+								{GroupId: aws.String("sg-control-old"), Description: aws.String("Kubelet API")},
+							},
+						},
+					},
+				})).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+			},
+			want: infrav1.IngressRules{
+				{
+					Description:            "Kubelet API",
+					Protocol:               infrav1.SecurityGroupProtocolTCP,
+					FromPort:               10250,
+					ToPort:                 10250,
+					SourceSecurityGroupIDs: []string{"sg-node"},
+				},
+				sshRule,
+				externalRule,
+			},
+		},
+		{
+			name:   "rules only reference security groups of the cluster",
+			rules:  infrav1.IngressRules{sshRule},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			want:   infrav1.IngressRules{sshRule},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Status: infrav1.AWSClusterStatus{
+						Network: infrav1.Network{
+							SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+								infrav1.SecurityGroupBastion:      {ID: "sg-bastion"},
+								infrav1.SecurityGroupAPIServerLB:  {ID: "sg-apiserver-lb"},
+								infrav1.SecurityGroupLB:           {ID: "sg-lb"},
+								infrav1.SecurityGroupControlPlane: {ID: "sg-control-new"},
+								infrav1.SecurityGroupNode:         {ID: "sg-node", IngressRules: tc.rules},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			sg := scope.SecurityGroups()[infrav1.SecurityGroupNode]
+			got, err := s.pruneStaleIngressRules(sg)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if len(got.Difference(tc.want)) > 0 || len(tc.want.Difference(got)) > 0 {
+				t.Fatalf("expected rules %v, got %v", tc.want, got)
+			}
+
+			want, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupNode)
+			if err != nil {
+				t.Fatalf("Failed to lookup node security group ingress rules: %v", err)
+			}
+			var authorizesCurrentGroup bool
+			for _, rule := range want.Difference(got) {
+				if sets.NewString(rule.SourceSecurityGroupIDs...).Has("sg-control-new") {
+					authorizesCurrentGroup = true
+				}
+			}
+			if !authorizesCurrentGroup {
+				t.Fatal("expected rules referencing the current control plane security group to be authorized")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssm
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+)
+
+const (
+	// logUploadDocument runs shell commands on Linux instances.
+	logUploadDocument = "AWS-RunShellScript"
+
+	// logUploadCommand prints the journal of the current boot, which SSM uploads to S3 as the output
+	// of the command.
+	logUploadCommand = "journalctl --no-pager --boot --output short-iso"
+)
+
+// logUploadPollInterval is how often the status of the log upload is checked.
+var logUploadPollInterval = 5 * time.Second
+
+// UploadInstanceLogs uploads the journal of the instance to the S3 bucket through SSM Run Command and
+// waits up to the timeout for the upload to complete. It returns the S3 location the output of the
+// command is uploaded under.
+func (s *Service) UploadInstanceLogs(instanceID, bucket, keyPrefix string, timeout time.Duration) (string, error) {
+	seconds := strconv.Itoa(int(timeout.Seconds()))
+	input := &ssm.SendCommandInput{
+		DocumentName: aws.String(logUploadDocument),
+		InstanceIds:  aws.StringSlice([]string{instanceID}),
+		Comment:      aws.String(fmt.Sprintf("Upload the journal of %s before it is terminated", instanceID)),
+		Parameters: map[string][]*string{
+			"commands":         aws.StringSlice([]string{logUploadCommand}),
+			"executionTimeout": aws.StringSlice([]string{seconds}),
+		},
+		TimeoutSeconds:     aws.Int64(int64(timeout.Seconds())),
+		OutputS3BucketName: aws.String(bucket),
+	}
+	if keyPrefix != "" {
+		input.OutputS3KeyPrefix = aws.String(keyPrefix)
+	}
+
+	out, err := s.SSMClient.SendCommand(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to send the log upload command to instance %q", instanceID)
+	}
+	commandID := aws.StringValue(out.Command.CommandId)
+
+	var status string
+	err = wait.PollImmediate(logUploadPollInterval, timeout, func() (bool, error) {
+		invocation, err := s.SSMClient.GetCommandInvocation(&ssm.GetCommandInvocationInput{
+			CommandId:  aws.String(commandID),
+			InstanceId: aws.String(instanceID),
+		})
+		if err != nil {
+			// The invocation shows up shortly after the command is sent.
+			if code, ok := awserrors.Code(errors.Cause(err)); ok && code == ssm.ErrCodeInvocationDoesNotExist {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to get the status of log upload command %q", commandID)
+		}
+
+		status = aws.StringValue(invocation.Status)
+		switch status {
+		case ssm.CommandInvocationStatusSuccess:
+			return true, nil
+		case ssm.CommandInvocationStatusPending, ssm.CommandInvocationStatusInProgress, ssm.CommandInvocationStatusDelayed:
+			return false, nil
+		default:
+			return false, errors.Errorf("log upload command %q ended with status %q: %s", commandID, status, aws.StringValue(invocation.StatusDetails))
+		}
+	})
+	if err == wait.ErrWaitTimeout {
+		return "", errors.Errorf("timed out after %s waiting for log upload command %q, last status %q", timeout, commandID, status)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return "s3://" + path.Join(bucket, keyPrefix, commandID, instanceID) + "/", nil
+}
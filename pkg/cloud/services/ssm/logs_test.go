@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/golang/mock/gomock"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm/mock_ssmiface"
+)
+
+func TestUploadInstanceLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logUploadPollInterval = time.Millisecond
+	defer func() { logUploadPollInterval = 5 * time.Second }()
+
+	invocation := func(m *mock_ssmiface.MockSSMAPIMockRecorder, status string) {
+		m.GetCommandInvocation(gomock.Eq(&ssm.GetCommandInvocationInput{
+			CommandId:  aws.String("cmd-1"),
+			InstanceId: aws.String("i-1234"),
+		})).Return(&ssm.GetCommandInvocationOutput{Status: aws.String(status)}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		keyPrefix    string
+		expect       func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		wantLocation string
+		wantErr      bool
+	}{
+		{
+			name:      "waits for the upload to complete",
+			keyPrefix: "terminated",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetCommandInvocation(gomock.Any()).Return(nil, awserr.New(ssm.ErrCodeInvocationDoesNotExist, "not yet", nil))
+				invocation(m, ssm.CommandInvocationStatusInProgress)
+				invocation(m, ssm.CommandInvocationStatusSuccess)
+			},
+			wantLocation: "s3://node-logs/terminated/cmd-1/i-1234/",
+		},
+		{
+			name: "fails when the upload fails",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				invocation(m, ssm.CommandInvocationStatusFailed)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			ssmMock.EXPECT().SendCommand(gomock.AssignableToTypeOf(&ssm.SendCommandInput{})).
+				DoAndReturn(func(in *ssm.SendCommandInput) (*ssm.SendCommandOutput, error) {
+					if aws.StringValue(in.OutputS3BucketName) != "node-logs" {
+						t.Errorf("unexpected bucket %q", aws.StringValue(in.OutputS3BucketName))
+					}
+					if aws.StringValue(in.OutputS3KeyPrefix) != tc.keyPrefix {
+						t.Errorf("unexpected key prefix %q", aws.StringValue(in.OutputS3KeyPrefix))
+					}
+					if aws.Int64Value(in.TimeoutSeconds) != 60 {
+						t.Errorf("unexpected timeout %d", aws.Int64Value(in.TimeoutSeconds))
+					}
+					return &ssm.SendCommandOutput{Command: &ssm.Command{CommandId: aws.String("cmd-1")}}, nil
+				})
+			tc.expect(ssmMock.EXPECT())
+
+			s := &Service{SSMClient: ssmMock}
+			location, err := s.UploadInstanceLogs("i-1234", "node-logs", tc.keyPrefix, time.Minute)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if location != tc.wantLocation {
+				t.Fatalf("expected location %q, got %q", tc.wantLocation, location)
+			}
+		})
+	}
+}
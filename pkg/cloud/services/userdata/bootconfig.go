@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"path"
+)
+
+const (
+	systemdUnitDir = "/etc/systemd/system"
+
+	// bootScriptPathFormat is where the boot scripts are written, by name.
+	bootScriptPathFormat = "/usr/local/bin/capa-boot-%s"
+)
+
+// SystemdUnit is a systemd unit installed on the node at boot.
+type SystemdUnit struct {
+	// Name of the unit file, including the unit type suffix.
+	Name string
+
+	// Content of the unit file.
+	Content string
+}
+
+// BootScript is an install script run on the node at boot.
+type BootScript struct {
+	// Name of the script.
+	Name string
+
+	// Content of the script, starting with an interpreter line.
+	Content string
+}
+
+// bootConfigFiles returns the files and commands that install and start the given systemd units,
+// then run the given boot scripts.
+func bootConfigFiles(units []SystemdUnit, scripts []BootScript) ([]Files, []string) {
+	var files []Files
+	var commands []string
+
+	if len(units) > 0 {
+		for _, unit := range units {
+			files = append(files, Files{
+				Path:        path.Join(systemdUnitDir, unit.Name),
+				Owner:       "root:root",
+				Permissions: "0644",
+				Content:     unit.Content,
+			})
+		}
+		commands = append(commands, "systemctl daemon-reload")
+		for _, unit := range units {
+			commands = append(commands, "systemctl enable --now "+unit.Name)
+		}
+	}
+
+	for _, script := range scripts {
+		scriptPath := fmt.Sprintf(bootScriptPathFormat, script.Name)
+		files = append(files, Files{
+			Path:        scriptPath,
+			Owner:       "root:root",
+			Permissions: "0755",
+			Content:     script.Content,
+		})
+		commands = append(commands, scriptPath)
+	}
+
+	return files, commands
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseCACertificates parses a bundle of PEM-encoded CA certificates and returns each
+// certificate PEM-encoded on its own. An error is returned if the bundle is empty or
+// contains anything other than valid certificates.
+func ParseCACertificates(bundle []byte) ([]string, error) {
+	var certs []string
+
+	rest := bytes.TrimSpace(bundle)
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("failed to parse CA certificates: data is not PEM-encoded")
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.Errorf("failed to parse CA certificates: unexpected PEM block type %q", block.Type)
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, errors.Wrap(err, "failed to parse CA certificates")
+		}
+
+		certs = append(certs, strings.TrimSpace(string(pem.EncodeToMemory(block))))
+		rest = bytes.TrimSpace(rest)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("failed to parse CA certificates: no certificates found")
+	}
+
+	return certs, nil
+}
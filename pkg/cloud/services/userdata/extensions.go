@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/internal/mime"
+)
+
+const (
+	// extensionsMergeType instructs cloud-init to merge the extensions part into the bootstrap
+	// cloud-config. Lists are prepended so that the extensions are applied before the bootstrap
+	// commands configure and start the kubelet.
+	extensionsMergeType = "list(prepend)+dict(no_replace,recurse_list)+str()"
+
+	// bootstrapScriptPath is where shell script bootstrap data is written to be run after the node configuration.
+	bootstrapScriptPath = "/usr/local/bin/capa-bootstrap.sh"
+
+	extensionsCloudConfig = `#cloud-config
+{{- if .TrustedCACertificates }}
+ca_certs:
+  trusted:
+{{- range .TrustedCACertificates }}
+  - |
+{{ . | Indent 4 }}
+{{- end }}
+{{- end }}
+{{- if .WriteFiles }}
+{{ template "files" .WriteFiles }}
+{{- end }}
+{{- if .RunCommands }}
+runcmd:
+{{- range .RunCommands }}
+  - {{ . }}
+{{- end }}
+{{- end }}
+`
+)
+
+// ExtensionsInput defines the additional node configuration that is merged into the
+// bootstrap data of a machine before it is launched.
+type ExtensionsInput struct {
+	// TrustedCACertificates is a list of PEM-encoded CA certificates to add to the node's trust store.
+	TrustedCACertificates []string
+
+	// NVIDIADriverVersion is the NVIDIA driver branch to install along with the container toolkit.
+	NVIDIADriverVersion string
+
+	// KernelModules is a list of kernel modules to load before the kubelet starts.
+	KernelModules []string
+
+	// Sysctls is a list of kernel parameters to set before the kubelet starts.
+	Sysctls []Sysctl
+
+	// RegistryCredentials is a list of credentials containerd uses to pull images from private registries.
+	RegistryCredentials []RegistryCredential
+
+	// RegistryMirrors is a list of mirrors containerd pulls images through.
+	RegistryMirrors []RegistryMirror
+
+	// ImageGC configures when the kubelet garbage collects unused container images.
+	ImageGC *ImageGC
+
+	// ContainerLogRotation configures how the kubelet rotates container logs.
+	ContainerLogRotation *ContainerLogRotation
+
+	// NodeLabels are the labels the node registers with.
+	NodeLabels map[string]string
+
+	// NodeTaints are the taints the node registers with.
+	NodeTaints []Taint
+
+	// EvictionThresholds configures when the kubelet evicts pods.
+	EvictionThresholds *EvictionThresholds
+
+	// NTP configures the time servers the node synchronizes its clock with.
+	NTP *NTP
+
+	// ContainerRuntimeVolume is the volume mounted at the data root of the container runtime.
+	ContainerRuntimeVolume *ContainerRuntimeVolume
+
+	// SystemdUnits are systemd units to install, enable and start at boot.
+	SystemdUnits []SystemdUnit
+
+	// BootScripts are install scripts to run at boot, after the systemd units are started.
+	BootScripts []BootScript
+}
+
+// kubeletArgs returns the additional flags to pass to the kubelet.
+func (i *ExtensionsInput) kubeletArgs() []string {
+	args := kubeletDiskPressureArgs(i.ImageGC, i.ContainerLogRotation)
+	args = append(args, kubeletEvictionArgs(i.EvictionThresholds)...)
+	return append(args, kubeletRegistrationArgs(i.NodeLabels, i.NodeTaints)...)
+}
+
+// IsEmpty returns true if there is no additional node configuration to merge.
+func (i *ExtensionsInput) IsEmpty() bool {
+	return i == nil || (!i.hasNodeConfiguration() && !i.hasBootConfig())
+}
+
+// hasNodeConfiguration returns true if there is node configuration for the extensions part.
+func (i *ExtensionsInput) hasNodeConfiguration() bool {
+	return len(i.TrustedCACertificates) > 0 ||
+		i.NVIDIADriverVersion != "" ||
+		len(i.KernelModules) > 0 ||
+		len(i.Sysctls) > 0 ||
+		len(i.RegistryCredentials) > 0 ||
+		len(i.RegistryMirrors) > 0 ||
+		i.NTP != nil ||
+		i.ContainerRuntimeVolume != nil ||
+		len(i.kubeletArgs()) > 0
+}
+
+// hasBootConfig returns true if there are systemd units or boot scripts for the boot config part.
+func (i *ExtensionsInput) hasBootConfig() bool {
+	return len(i.SystemdUnits) > 0 || len(i.BootScripts) > 0
+}
+
+type extensionsData struct {
+	*ExtensionsInput
+	WriteFiles  []Files
+	RunCommands []string
+}
+
+// WithExtensions merges the node configuration described by input into the given bootstrap data.
+// When there is nothing to merge the bootstrap data is returned unchanged, otherwise a multi-part
+// MIME document is returned holding the bootstrap data followed by a cloud-config part for the
+// systemd units and boot scripts and a cloud-config part for the rest of the node configuration.
+// Both parts prepend their commands, so the node configuration is applied first, then the units
+// and scripts are set up, then the bootstrap commands run. Shell script bootstrap data is run as the
+// last runcmd command for that, as cloud-init runs shell script parts before the runcmd commands.
+// Boothooks run before any of it.
+func WithExtensions(bootstrapData []byte, input *ExtensionsInput) ([]byte, error) {
+	if input.IsEmpty() {
+		return bootstrapData, nil
+	}
+
+	part, err := bootstrapPart(bootstrapData)
+	if err != nil {
+		return nil, err
+	}
+	parts := []mime.Part{part}
+
+	if input.hasBootConfig() {
+		bootConfig, err := generateBootConfig(input)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, mime.Part{
+			ContentType: "text/cloud-config",
+			MergeType:   extensionsMergeType,
+			Content:     []byte(bootConfig),
+		})
+	}
+
+	if input.hasNodeConfiguration() {
+		extensions, err := generateExtensions(input)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, mime.Part{
+			ContentType: "text/cloud-config",
+			MergeType:   extensionsMergeType,
+			Content:     []byte(extensions),
+		})
+	}
+
+	return mime.GenerateMultipartDocument(parts)
+}
+
+// generateBootConfig renders the cloud-config part holding the systemd units and boot scripts of input.
+func generateBootConfig(input *ExtensionsInput) (string, error) {
+	data := extensionsData{ExtensionsInput: &ExtensionsInput{}}
+	data.WriteFiles, data.RunCommands = bootConfigFiles(input.SystemdUnits, input.BootScripts)
+	return generate("boot-config", extensionsCloudConfig, data)
+}
+
+// generateExtensions renders the cloud-config part holding the node configuration described by input.
+func generateExtensions(input *ExtensionsInput) (string, error) {
+	data := extensionsData{ExtensionsInput: input}
+
+	// Kernel tuning goes first so that it is in place before any other command runs.
+	tuningFiles, tuningCommands := nodeTuningFiles(input.KernelModules, input.Sysctls)
+	data.WriteFiles = append(data.WriteFiles, tuningFiles...)
+	data.RunCommands = append(data.RunCommands, tuningCommands...)
+
+	// The clock must be in sync before the node validates any certificate.
+	if input.NTP != nil {
+		files, err := chronyConfigFiles(input.NTP)
+		if err != nil {
+			return "", err
+		}
+		data.WriteFiles = append(data.WriteFiles, files...)
+		data.RunCommands = append(data.RunCommands, chronyConfigScriptPath)
+	}
+
+	// The container runtime storage must be in place before any image is pulled.
+	if input.ContainerRuntimeVolume != nil {
+		files, err := runtimeVolumeFiles(input.ContainerRuntimeVolume)
+		if err != nil {
+			return "", err
+		}
+		data.WriteFiles = append(data.WriteFiles, files...)
+		data.RunCommands = append(data.RunCommands, runtimeVolumeScriptPath)
+	}
+
+	// Registry mirrors and credentials must be in place before the kubelet pulls any image. Both only
+	// need containerd restarted, which is done once.
+	var registryCommands []string
+	if len(input.RegistryMirrors) > 0 {
+		files, commands, err := registryMirrorFiles(input.RegistryMirrors)
+		if err != nil {
+			return "", err
+		}
+		data.WriteFiles = append(data.WriteFiles, files...)
+		registryCommands = commands
+	}
+	if len(input.RegistryCredentials) > 0 {
+		files, commands, err := registryAuthFiles(input.RegistryCredentials)
+		if err != nil {
+			return "", err
+		}
+		data.WriteFiles = append(data.WriteFiles, files...)
+		registryCommands = commands
+	}
+	data.RunCommands = append(data.RunCommands, registryCommands...)
+
+	// Kubelet flags must be in place before the bootstrap commands start the kubelet.
+	if args := input.kubeletArgs(); len(args) > 0 {
+		files, err := kubeletExtraArgsFiles(args)
+		if err != nil {
+			return "", err
+		}
+		data.WriteFiles = append(data.WriteFiles, files...)
+		data.RunCommands = append(data.RunCommands, kubeletExtraArgsScriptPath)
+	}
+
+	if input.NVIDIADriverVersion != "" {
+		files, err := nvidiaDriverInstallFiles(input.NVIDIADriverVersion)
+		if err != nil {
+			return "", err
+		}
+		data.WriteFiles = append(data.WriteFiles, files...)
+		data.RunCommands = append(data.RunCommands, nvidiaDriverInstallScriptPath)
+	}
+
+	return generate("extensions", extensionsCloudConfig, data)
+}
+
+// bootstrapPart returns the part holding the bootstrap data, which the extensions parts are merged into.
+func bootstrapPart(bootstrapData []byte) (mime.Part, error) {
+	switch {
+	case bytes.HasPrefix(bootstrapData, []byte("#cloud-config")):
+		return mime.Part{ContentType: "text/cloud-config", Content: bootstrapData}, nil
+	case bytes.HasPrefix(bootstrapData, []byte("#cloud-boothook")):
+		return mime.Part{ContentType: "text/cloud-boothook", Content: bootstrapData}, nil
+	case bytes.HasPrefix(bootstrapData, []byte("#!")):
+		data := extensionsData{
+			ExtensionsInput: &ExtensionsInput{},
+			WriteFiles: []Files{{
+				Path:        bootstrapScriptPath,
+				Owner:       "root:root",
+				Permissions: "0700",
+				Content:     string(bootstrapData),
+			}},
+			RunCommands: []string{bootstrapScriptPath},
+		}
+		bootstrapScript, err := generate("bootstrap-script", extensionsCloudConfig, data)
+		if err != nil {
+			return mime.Part{}, err
+		}
+		return mime.Part{ContentType: "text/cloud-config", Content: []byte(bootstrapScript)}, nil
+	}
+
+	return mime.Part{}, errors.New("additional node configuration can only be merged into cloud-config or shell script bootstrap data")
+}
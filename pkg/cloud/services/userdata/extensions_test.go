@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	stdmime "mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+)
+
+func TestWithExtensions(t *testing.T) {
+	bootstrapData := []byte("#cloud-config\nruncmd:\n  - kubeadm init\n")
+
+	testCases := []struct {
+		name     string
+		input    *ExtensionsInput
+		contains []string
+	}{
+		{
+			name: "trusted CA certificates",
+			input: &ExtensionsInput{
+				TrustedCACertificates: []string{"-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"},
+			},
+			contains: []string{"ca_certs:", "-----BEGIN CERTIFICATE-----"},
+		},
+		{
+			name: "NVIDIA driver",
+			input: &ExtensionsInput{
+				NVIDIADriverVersion: "470",
+			},
+			contains: []string{"write_files:", nvidiaDriverInstallScriptPath, "runcmd:"},
+		},
+		{
+			name: "kernel modules and sysctls",
+			input: &ExtensionsInput{
+				KernelModules: IPVSKernelModules,
+				Sysctls: []Sysctl{
+					{Name: "net.netfilter.nf_conntrack_max", Value: "1048576"},
+				},
+			},
+			contains: []string{kernelModulesPath, sysctlsPath, "modprobe -a ip_vs ip_vs_rr ip_vs_wrr ip_vs_sh nf_conntrack", "sysctl -p " + sysctlsPath},
+		},
+		{
+			name: "registry credentials",
+			input: &ExtensionsInput{
+				RegistryCredentials: []RegistryCredential{
+					{Host: "registry.example.com", Username: "user", Password: `pa"ss`},
+				},
+			},
+			contains: []string{registryAuthConfigPath, "permissions: '0600'", "systemctl restart containerd"},
+		},
+		{
+			name: "NTP servers",
+			input: &ExtensionsInput{
+				NTP: &NTP{Servers: []string{"ntp.example.com"}, AmazonTimeSync: true},
+			},
+			contains: []string{chronyConfigScriptPath, "runcmd:"},
+		},
+		{
+			name: "container runtime volume",
+			input: &ExtensionsInput{
+				ContainerRuntimeVolume: &ContainerRuntimeVolume{Device: "/dev/sdf", MountPath: "/var/lib/containerd"},
+			},
+			contains: []string{runtimeVolumeScriptPath, "runcmd:"},
+		},
+		{
+			name: "image GC and container log rotation",
+			input: &ExtensionsInput{
+				ImageGC:              &ImageGC{HighThresholdPercent: pointer.Int32Ptr(75)},
+				ContainerLogRotation: &ContainerLogRotation{MaxSize: "50Mi"},
+			},
+			contains: []string{kubeletExtraArgsScriptPath, "runcmd:"},
+		},
+		{
+			name: "systemd units and boot scripts",
+			input: &ExtensionsInput{
+				SystemdUnits: []SystemdUnit{
+					{Name: "node-problem-detector.service", Content: "[Unit]\nDescription=node-problem-detector\n"},
+				},
+				BootScripts: []BootScript{
+					{Name: "install-log-shipper", Content: "#!/bin/bash\necho install\n"},
+				},
+			},
+			contains: []string{
+				"/etc/systemd/system/node-problem-detector.service",
+				"systemctl enable --now node-problem-detector.service",
+				"/usr/local/bin/capa-boot-install-log-shipper",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := WithExtensions(bootstrapData, tc.input)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if !strings.Contains(string(out), string(bootstrapData)) {
+				t.Fatalf("expected output to contain the bootstrap data, got:\n%s", out)
+			}
+			for _, s := range tc.contains {
+				if !strings.Contains(string(out), s) {
+					t.Fatalf("expected output to contain %q, got:\n%s", s, out)
+				}
+			}
+
+			for _, generateFunc := range []func(*ExtensionsInput) (string, error){generateExtensions, generateBootConfig} {
+				cloudConfig, err := generateFunc(tc.input)
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				parsed := map[string]interface{}{}
+				if err := yaml.Unmarshal([]byte(cloudConfig), &parsed); err != nil {
+					t.Fatalf("extensions are not valid YAML: %v\n%s", err, cloudConfig)
+				}
+			}
+		})
+	}
+}
+
+func TestWithExtensionsEmpty(t *testing.T) {
+	bootstrapData := []byte("#!/bin/bash\necho hello\n")
+
+	out, err := WithExtensions(bootstrapData, &ExtensionsInput{})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if string(out) != string(bootstrapData) {
+		t.Fatalf("expected bootstrap data to be unchanged, got:\n%s", out)
+	}
+}
+
+func TestWithExtensionsShellScriptRunsLast(t *testing.T) {
+	bootstrapData := []byte("#!/bin/bash\n/etc/eks/bootstrap.sh my-cluster\n")
+	input := &ExtensionsInput{
+		Sysctls:    []Sysctl{{Name: "vm.max_map_count", Value: "262144"}},
+		NodeTaints: []Taint{{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"}},
+		BootScripts: []BootScript{
+			{Name: "install-log-shipper", Content: "#!/bin/bash\necho install\n"},
+		},
+	}
+
+	out, err := WithExtensions(bootstrapData, input)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewBuffer(out))
+	if err != nil {
+		t.Fatalf("cannot parse MIME document: %v", err)
+	}
+	_, params, err := stdmime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("cannot parse content type: %v", err)
+	}
+
+	// Merge the runcmd commands of the parts the way the merge type tells cloud-init to.
+	var runCommands []string
+	var files []Files
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("cannot read MIME part: %v", err)
+		}
+		if contentType := part.Header.Get("Content-Type"); contentType != "text/cloud-config" {
+			t.Fatalf("expected only cloud-config parts, got %q", contentType)
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cloudConfig := struct {
+			WriteFiles  []Files  `json:"write_files"`
+			RunCommands []string `json:"runcmd"`
+		}{}
+		if err := yaml.Unmarshal(content, &cloudConfig); err != nil {
+			t.Fatalf("part is not valid YAML: %v\n%s", err, content)
+		}
+		if part.Header.Get("Merge-Type") == extensionsMergeType {
+			runCommands = append(cloudConfig.RunCommands, runCommands...)
+		} else {
+			runCommands = append(runCommands, cloudConfig.RunCommands...)
+		}
+		files = append(files, cloudConfig.WriteFiles...)
+	}
+
+	if len(runCommands) == 0 || runCommands[len(runCommands)-1] != bootstrapScriptPath {
+		t.Fatalf("expected the bootstrap script to run last, got %v", runCommands)
+	}
+	for _, command := range []string{"sysctl -p " + sysctlsPath, kubeletExtraArgsScriptPath, "/usr/local/bin/capa-boot-install-log-shipper"} {
+		found := false
+		for _, c := range runCommands[:len(runCommands)-1] {
+			found = found || strings.Contains(c, command)
+		}
+		if !found {
+			t.Fatalf("expected %q to run before the bootstrap script, got %v", command, runCommands)
+		}
+	}
+
+	for _, f := range files {
+		if f.Path != bootstrapScriptPath {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(f.Content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != string(bootstrapData) {
+			t.Fatalf("expected the bootstrap script to hold the bootstrap data, got:\n%s", content)
+		}
+		return
+	}
+	t.Fatalf("expected the bootstrap script to be written, got files %v", files)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+const (
+	nvidiaDriverInstallScriptPath = "/usr/local/bin/capa-install-nvidia-driver.sh"
+
+	// nvidiaDriverInstallScript installs the NVIDIA driver and container toolkit on Ubuntu
+	// and configures containerd to use the NVIDIA runtime.
+	nvidiaDriverInstallScript = `{{.Header}}
+export DEBIAN_FRONTEND=noninteractive
+
+apt-get update
+apt-get install -y --no-install-recommends ca-certificates curl gnupg
+apt-get install -y --no-install-recommends nvidia-driver-{{.Version}}-server nvidia-utils-{{.Version}}-server
+
+curl -fsSL https://nvidia.github.io/libnvidia-container/gpgkey | gpg --dearmor -o /usr/share/keyrings/nvidia-container-toolkit-keyring.gpg
+curl -fsSL https://nvidia.github.io/libnvidia-container/stable/deb/nvidia-container-toolkit.list | \
+  sed 's#deb https://#deb [signed-by=/usr/share/keyrings/nvidia-container-toolkit-keyring.gpg] https://#g' > /etc/apt/sources.list.d/nvidia-container-toolkit.list
+apt-get update
+apt-get install -y --no-install-recommends nvidia-container-toolkit
+
+nvidia-ctk runtime configure --runtime=containerd --set-as-default
+systemctl restart containerd
+`
+)
+
+type nvidiaDriverInstallInput struct {
+	baseUserData
+	Version string
+}
+
+// nvidiaDriverInstallFiles returns the files that install the given NVIDIA driver version.
+func nvidiaDriverInstallFiles(version string) ([]Files, error) {
+	script, err := generate("nvidia-driver", nvidiaDriverInstallScript, nvidiaDriverInstallInput{
+		baseUserData: baseUserData{Header: defaultHeader},
+		Version:      version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []Files{
+		{
+			Path:        nvidiaDriverInstallScriptPath,
+			Owner:       "root:root",
+			Permissions: "0755",
+			Content:     script,
+		},
+	}, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	kubeletExtraArgsScriptPath = "/usr/local/bin/capa-kubelet-extra-args.sh"
+
+	// kubeletExtraArgsScript adds flags to KUBELET_EXTRA_ARGS, which the kubeadm drop-in of the
+	// kubelet service reads from /etc/default/kubelet, keeping any flags already set there.
+	kubeletExtraArgsScript = `{{.Header}}
+file=/etc/default/kubelet
+args='{{.Args}}'
+
+if grep -q '^KUBELET_EXTRA_ARGS=' "${file}" 2>/dev/null; then
+  sed -i "s|^KUBELET_EXTRA_ARGS=\"\{0,1\}|&${args} |" "${file}"
+else
+  echo "KUBELET_EXTRA_ARGS=${args}" >> "${file}"
+fi
+`
+)
+
+// ImageGC defines the disk usage thresholds, in percent, at which the kubelet garbage collects
+// unused container images. Unset thresholds keep the kubelet defaults.
+type ImageGC struct {
+	HighThresholdPercent *int32
+	LowThresholdPercent  *int32
+}
+
+// ContainerLogRotation defines how the kubelet rotates container logs. Unset options keep the
+// kubelet defaults.
+type ContainerLogRotation struct {
+	MaxSize  string
+	MaxFiles *int32
+}
+
+// Taint is a taint the node registers with.
+type Taint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// EvictionSignals defines eviction thresholds, as quantities or percentages, for the kubelet's
+// memory, nodefs and imagefs eviction signals.
+type EvictionSignals struct {
+	MemoryAvailable  string
+	NodeFSAvailable  string
+	ImageFSAvailable string
+}
+
+// EvictionThresholds defines when the kubelet evicts pods. Unset hard thresholds keep the kubelet
+// defaults, unset soft thresholds are not evicted on.
+type EvictionThresholds struct {
+	Hard            *EvictionSignals
+	Soft            *EvictionSignals
+	SoftGracePeriod time.Duration
+}
+
+// defaultHardEvictionSignals are the kubelet's default hard eviction thresholds. The kubelet drops
+// all of its defaults as soon as any hard threshold is set, so they are passed along with the
+// configured ones.
+var defaultHardEvictionSignals = EvictionSignals{
+	MemoryAvailable:  "100Mi",
+	NodeFSAvailable:  "10%",
+	ImageFSAvailable: "15%",
+}
+
+// signals returns the set thresholds keyed by the name of their eviction signal.
+func (s *EvictionSignals) signals() [][2]string {
+	var signals [][2]string
+	if s == nil {
+		return signals
+	}
+
+	for _, signal := range [][2]string{
+		{"memory.available", s.MemoryAvailable},
+		{"nodefs.available", s.NodeFSAvailable},
+		{"imagefs.available", s.ImageFSAvailable},
+	} {
+		if signal[1] != "" {
+			signals = append(signals, signal)
+		}
+	}
+	return signals
+}
+
+type kubeletExtraArgsInput struct {
+	baseUserData
+	Args string
+}
+
+// kubeletDiskPressureArgs returns the kubelet flags applying the given image garbage collection
+// and container log rotation settings.
+func kubeletDiskPressureArgs(imageGC *ImageGC, logRotation *ContainerLogRotation) []string {
+	var args []string
+
+	if imageGC != nil {
+		if imageGC.HighThresholdPercent != nil {
+			args = append(args, fmt.Sprintf("--image-gc-high-threshold=%d", *imageGC.HighThresholdPercent))
+		}
+		if imageGC.LowThresholdPercent != nil {
+			args = append(args, fmt.Sprintf("--image-gc-low-threshold=%d", *imageGC.LowThresholdPercent))
+		}
+	}
+
+	if logRotation != nil {
+		if logRotation.MaxSize != "" {
+			args = append(args, "--container-log-max-size="+logRotation.MaxSize)
+		}
+		if logRotation.MaxFiles != nil {
+			args = append(args, fmt.Sprintf("--container-log-max-files=%d", *logRotation.MaxFiles))
+		}
+	}
+
+	return args
+}
+
+// kubeletRegistrationArgs returns the kubelet flags registering the node with the given labels
+// and taints.
+func kubeletRegistrationArgs(labels map[string]string, taints []Taint) []string {
+	var args []string
+
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels))
+		for key, value := range labels {
+			pairs = append(pairs, key+"="+value)
+		}
+		sort.Strings(pairs)
+		args = append(args, "--node-labels="+strings.Join(pairs, ","))
+	}
+
+	if len(taints) > 0 {
+		specs := make([]string, 0, len(taints))
+		for _, taint := range taints {
+			spec := taint.Key
+			if taint.Value != "" {
+				spec += "=" + taint.Value
+			}
+			specs = append(specs, spec+":"+taint.Effect)
+		}
+		args = append(args, "--register-with-taints="+strings.Join(specs, ","))
+	}
+
+	return args
+}
+
+// kubeletEvictionArgs returns the kubelet flags applying the given eviction thresholds.
+func kubeletEvictionArgs(thresholds *EvictionThresholds) []string {
+	var args []string
+	if thresholds == nil {
+		return args
+	}
+
+	if thresholds.Hard != nil {
+		hard := defaultHardEvictionSignals
+		if thresholds.Hard.MemoryAvailable != "" {
+			hard.MemoryAvailable = thresholds.Hard.MemoryAvailable
+		}
+		if thresholds.Hard.NodeFSAvailable != "" {
+			hard.NodeFSAvailable = thresholds.Hard.NodeFSAvailable
+		}
+		if thresholds.Hard.ImageFSAvailable != "" {
+			hard.ImageFSAvailable = thresholds.Hard.ImageFSAvailable
+		}
+
+		var specs []string
+		for _, signal := range hard.signals() {
+			specs = append(specs, signal[0]+"<"+signal[1])
+		}
+		args = append(args, "--eviction-hard="+strings.Join(specs, ","))
+	}
+
+	if soft := thresholds.Soft.signals(); len(soft) > 0 {
+		specs := make([]string, 0, len(soft))
+		gracePeriods := make([]string, 0, len(soft))
+		for _, signal := range soft {
+			specs = append(specs, signal[0]+"<"+signal[1])
+			gracePeriods = append(gracePeriods, signal[0]+"="+thresholds.SoftGracePeriod.String())
+		}
+		args = append(args,
+			"--eviction-soft="+strings.Join(specs, ","),
+			"--eviction-soft-grace-period="+strings.Join(gracePeriods, ","),
+		)
+	}
+
+	return args
+}
+
+// kubeletExtraArgsFiles returns the files that pass the given flags to the kubelet.
+func kubeletExtraArgsFiles(args []string) ([]Files, error) {
+	script, err := generate("kubelet-extra-args", kubeletExtraArgsScript, kubeletExtraArgsInput{
+		baseUserData: baseUserData{Header: defaultHeader},
+		Args:         strings.Join(args, " "),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []Files{
+		{
+			Path:        kubeletExtraArgsScriptPath,
+			Owner:       "root:root",
+			Permissions: "0755",
+			Content:     script,
+		},
+	}, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/utils/pointer"
+)
+
+func TestKubeletDiskPressureArgs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		imageGC     *ImageGC
+		logRotation *ContainerLogRotation
+		want        []string
+	}{
+		{
+			name: "nothing configured",
+		},
+		{
+			name:        "empty options",
+			imageGC:     &ImageGC{},
+			logRotation: &ContainerLogRotation{},
+		},
+		{
+			name:        "all options",
+			imageGC:     &ImageGC{HighThresholdPercent: pointer.Int32Ptr(90), LowThresholdPercent: pointer.Int32Ptr(70)},
+			logRotation: &ContainerLogRotation{MaxSize: "20Mi", MaxFiles: pointer.Int32Ptr(3)},
+			want: []string{
+				"--image-gc-high-threshold=90",
+				"--image-gc-low-threshold=70",
+				"--container-log-max-size=20Mi",
+				"--container-log-max-files=3",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := kubeletDiskPressureArgs(tc.imageGC, tc.logRotation)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestKubeletRegistrationArgs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		labels map[string]string
+		taints []Taint
+		want   []string
+	}{
+		{
+			name: "nothing configured",
+		},
+		{
+			name:   "labels and taints",
+			labels: map[string]string{"pool": "gpu", "node.kubernetes.io/role": "worker"},
+			taints: []Taint{
+				{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"},
+				{Key: "node.example.com/unconfigured", Effect: "NoExecute"},
+			},
+			want: []string{
+				"--node-labels=node.kubernetes.io/role=worker,pool=gpu",
+				"--register-with-taints=dedicated=gpu:NoSchedule,node.example.com/unconfigured:NoExecute",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := kubeletRegistrationArgs(tc.labels, tc.taints)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestKubeletEvictionArgs(t *testing.T) {
+	testCases := []struct {
+		name       string
+		thresholds *EvictionThresholds
+		want       []string
+	}{
+		{
+			name: "nothing configured",
+		},
+		{
+			name: "hard thresholds keep the defaults of unset signals",
+			thresholds: &EvictionThresholds{
+				Hard: &EvictionSignals{MemoryAvailable: "500Mi"},
+			},
+			want: []string{
+				"--eviction-hard=memory.available<500Mi,nodefs.available<10%,imagefs.available<15%",
+			},
+		},
+		{
+			name: "soft thresholds",
+			thresholds: &EvictionThresholds{
+				Soft:            &EvictionSignals{MemoryAvailable: "1Gi", ImageFSAvailable: "20%"},
+				SoftGracePeriod: 90 * time.Second,
+			},
+			want: []string{
+				"--eviction-soft=memory.available<1Gi,imagefs.available<20%",
+				"--eviction-soft-grace-period=memory.available=1m30s,imagefs.available=1m30s",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := kubeletEvictionArgs(tc.thresholds)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestKubeletExtraArgsFiles(t *testing.T) {
+	files, err := kubeletExtraArgsFiles([]string{"--image-gc-high-threshold=90", "--container-log-max-files=3"})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != kubeletExtraArgsScriptPath || files[0].Permissions != "0755" {
+		t.Fatalf("unexpected files: %v", files)
+	}
+	if !strings.Contains(files[0].Content, "args='--image-gc-high-threshold=90 --container-log-max-files=3'") {
+		t.Fatalf("expected script to set the kubelet flags, got:\n%s", files[0].Content)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// AmazonTimeSyncServiceAddress is the link-local address the Amazon Time Sync Service is
+	// reachable at from every instance.
+	AmazonTimeSyncServiceAddress = "169.254.169.123"
+
+	chronyConfigScriptPath = "/usr/local/bin/capa-chrony-config.sh"
+
+	// chronyConfigScript replaces the time sources of the image's chrony configuration, which lives
+	// in /etc/chrony/chrony.conf on Debian based images and in /etc/chrony.conf otherwise.
+	chronyConfigScript = `{{.Header}}
+file=/etc/chrony.conf
+if [ -f /etc/chrony/chrony.conf ]; then
+  file=/etc/chrony/chrony.conf
+fi
+
+if [ -f "${file}" ]; then
+  sed -i -E 's/^(server|pool|peer) /# &/' "${file}"
+fi
+cat >> "${file}" <<'SOURCES'
+{{.Sources}}
+SOURCES
+
+systemctl restart chronyd 2>/dev/null || systemctl restart chrony
+`
+)
+
+// NTP defines the time servers chrony synchronizes the node's clock with.
+type NTP struct {
+	// Servers are the hostnames or IP addresses of the NTP servers.
+	Servers []string
+
+	// AmazonTimeSync adds the Amazon Time Sync Service as the preferred time source.
+	AmazonTimeSync bool
+}
+
+type chronyConfigInput struct {
+	baseUserData
+	Sources string
+}
+
+// chronySources returns the chrony directives for the given time servers.
+func chronySources(ntp *NTP) []string {
+	var sources []string
+	if ntp.AmazonTimeSync {
+		sources = append(sources, fmt.Sprintf("server %s prefer iburst minpoll 4 maxpoll 4", AmazonTimeSyncServiceAddress))
+	}
+	for _, server := range ntp.Servers {
+		sources = append(sources, fmt.Sprintf("server %s iburst", server))
+	}
+	return sources
+}
+
+// chronyConfigFiles returns the files that configure chrony to synchronize with the given time servers.
+func chronyConfigFiles(ntp *NTP) ([]Files, error) {
+	script, err := generate("chrony-config", chronyConfigScript, chronyConfigInput{
+		baseUserData: baseUserData{Header: defaultHeader},
+		Sources:      strings.Join(chronySources(ntp), "\n"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []Files{
+		{
+			Path:        chronyConfigScriptPath,
+			Owner:       "root:root",
+			Permissions: "0755",
+			Content:     script,
+		},
+	}, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChronySources(t *testing.T) {
+	testCases := []struct {
+		name string
+		ntp  *NTP
+		want []string
+	}{
+		{
+			name: "Amazon Time Sync Service only",
+			ntp:  &NTP{AmazonTimeSync: true},
+			want: []string{"server 169.254.169.123 prefer iburst minpoll 4 maxpoll 4"},
+		},
+		{
+			name: "Amazon Time Sync Service is preferred over other servers",
+			ntp:  &NTP{Servers: []string{"ntp1.example.com", "10.0.0.10"}, AmazonTimeSync: true},
+			want: []string{
+				"server 169.254.169.123 prefer iburst minpoll 4 maxpoll 4",
+				"server ntp1.example.com iburst",
+				"server 10.0.0.10 iburst",
+			},
+		},
+		{
+			name: "other servers only",
+			ntp:  &NTP{Servers: []string{"ntp1.example.com"}},
+			want: []string{"server ntp1.example.com iburst"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := chronySources(tc.ntp); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, expected %v", got, tc.want)
+			}
+		})
+	}
+}
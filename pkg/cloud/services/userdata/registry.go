@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// registryAuthConfigPath is a containerd configuration drop-in, which is imported by the
+	// containerd configuration of the images built for Cluster API.
+	registryAuthConfigPath = "/etc/containerd/conf.d/capa-registry-auth.toml"
+
+	registryAuthConfig = `version = 2
+{{ range . }}
+[plugins."io.containerd.grpc.v1.cri".registry.configs."{{ .Host }}".auth]
+  username = "{{ .Username | TOMLEscape }}"
+  password = "{{ .Password | TOMLEscape }}"
+{{ end }}`
+)
+
+// RegistryCredential holds the credentials used to pull images from a container registry.
+// Its string representation never includes the credentials themselves.
+type RegistryCredential struct {
+	Host     string
+	Username string
+	Password string
+}
+
+// String returns the registry host with the credentials redacted.
+func (c RegistryCredential) String() string {
+	return fmt.Sprintf("%s:<redacted>", c.Host)
+}
+
+// GoString returns the registry host with the credentials redacted.
+func (c RegistryCredential) GoString() string {
+	return c.String()
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// ParseRegistryCredentials parses registry credentials in the Docker config JSON format used by
+// Secrets of type kubernetes.io/dockerconfigjson. Errors never include the credentials.
+func ParseRegistryCredentials(data []byte) ([]RegistryCredential, error) {
+	config := dockerConfigJSON{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.New("failed to parse registry credentials: data is not in Docker config JSON format")
+	}
+	if len(config.Auths) == 0 {
+		return nil, errors.New("failed to parse registry credentials: no registries found")
+	}
+
+	creds := make([]RegistryCredential, 0, len(config.Auths))
+	for server, entry := range config.Auths {
+		host := registryHost(server)
+		if host == "" || strings.ContainsAny(host, "\"\\ ") {
+			return nil, errors.Errorf("failed to parse registry credentials: invalid registry %q", server)
+		}
+
+		username, password := entry.Username, entry.Password
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, errors.Errorf("failed to parse registry credentials for %q: auth is not base64-encoded", host)
+			}
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("failed to parse registry credentials for %q: auth is not of the form username:password", host)
+			}
+			username, password = parts[0], parts[1]
+		}
+
+		if username == "" || password == "" {
+			return nil, errors.Errorf("failed to parse registry credentials for %q: username and password must be set", host)
+		}
+		if !isPrintable(username) || !isPrintable(password) {
+			return nil, errors.Errorf("failed to parse registry credentials for %q: credentials contain non-printable characters", host)
+		}
+
+		creds = append(creds, RegistryCredential{Host: host, Username: username, Password: password})
+	}
+
+	sort.Slice(creds, func(i, j int) bool { return creds[i].Host < creds[j].Host })
+	return creds, nil
+}
+
+// registryHost returns the host of a registry server address, which may be given as a URL.
+func registryHost(server string) string {
+	host := strings.TrimSpace(server)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// registryAuthFiles returns the files and commands that configure containerd to authenticate to
+// the given registries before the kubelet starts pulling images.
+func registryAuthFiles(creds []RegistryCredential) ([]Files, []string, error) {
+	config, err := generate("registry-auth", registryAuthConfig, creds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []Files{
+		{
+			Path:        registryAuthConfigPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     config,
+		},
+	}, []string{"systemctl restart containerd"}, nil
+}
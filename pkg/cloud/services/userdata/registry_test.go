@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRegistryCredentials(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      string
+		want      []RegistryCredential
+		wantError bool
+	}{
+		{
+			name: "auth and username/password entries",
+			data: `{"auths": {
+				"https://registry.example.com/v1/": {"auth": "dXNlcjpwYSQkOndvcmQ="},
+				"mirror.example.com:5000": {"username": "robot", "password": "s3\"cret"}
+			}}`,
+			want: []RegistryCredential{
+				{Host: "mirror.example.com:5000", Username: "robot", Password: `s3"cret`},
+				{Host: "registry.example.com", Username: "user", Password: "pa$$:word"},
+			},
+		},
+		{
+			name:      "not JSON",
+			data:      "user:password",
+			wantError: true,
+		},
+		{
+			name:      "no registries",
+			data:      `{"auths": {}}`,
+			wantError: true,
+		},
+		{
+			name:      "auth not base64-encoded",
+			data:      `{"auths": {"registry.example.com": {"auth": "user:password"}}}`,
+			wantError: true,
+		},
+		{
+			name:      "missing password",
+			data:      `{"auths": {"registry.example.com": {"username": "user"}}}`,
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRegistryCredentials([]byte(tc.data))
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if strings.Contains(err.Error(), "user:password") {
+					t.Fatalf("expected error not to include the credentials, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %#v, expected %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegistryCredentialRedacted(t *testing.T) {
+	cred := RegistryCredential{Host: "registry.example.com", Username: "user", Password: "secret"}
+
+	for _, s := range []string{fmt.Sprintf("%v", cred), fmt.Sprintf("%+v", cred), fmt.Sprintf("%#v", cred), fmt.Sprintf("%v", []RegistryCredential{cred})} {
+		if strings.Contains(s, "user") || strings.Contains(s, "secret") {
+			t.Fatalf("expected credentials to be redacted, got %q", s)
+		}
+	}
+}
+
+func TestRegistryAuthFiles(t *testing.T) {
+	files, commands, err := registryAuthFiles([]RegistryCredential{
+		{Host: "registry.example.com", Username: "user", Password: `pa"ss\word`},
+	})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != registryAuthConfigPath {
+		t.Fatalf("expected the registry auth config file, got %v", files)
+	}
+	if !reflect.DeepEqual(commands, []string{"systemctl restart containerd"}) {
+		t.Fatalf("expected containerd to be restarted, got %v", commands)
+	}
+
+	for _, s := range []string{
+		`[plugins."io.containerd.grpc.v1.cri".registry.configs."registry.example.com".auth]`,
+		`username = "user"`,
+		`password = "pa\"ss\\word"`,
+	} {
+		if !strings.Contains(files[0].Content, s) {
+			t.Fatalf("expected config to contain %q, got:\n%s", s, files[0].Content)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// registryMirrorConfigPath is a containerd configuration drop-in pointing containerd at the
+	// registry host configuration directory.
+	registryMirrorConfigPath = "/etc/containerd/conf.d/capa-registry-mirrors.toml"
+
+	// registryHostsDir holds a directory per registry with the hosts.toml file listing its mirrors.
+	registryHostsDir = "/etc/containerd/certs.d"
+
+	registryMirrorConfig = `version = 2
+
+[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "{{ . }}"
+`
+
+	registryHostsConfig = `{{- range . }}
+[host."{{ .Endpoint | TOMLEscape }}"]
+  capabilities = ["pull", "resolve"]
+{{- if .CAPath }}
+  ca = "{{ .CAPath }}"
+{{- end }}
+{{- if .InsecureSkipVerify }}
+  skip_verify = true
+{{- end }}
+{{ end }}`
+)
+
+// RegistryMirror is a mirror containerd pulls the images of a registry through.
+type RegistryMirror struct {
+	// Registry is the host of the registry the mirror serves the images of.
+	Registry string
+
+	// Endpoint is the URL of the mirror.
+	Endpoint string
+
+	// CACertificates is a list of PEM-encoded CA certificates the certificate of the mirror is
+	// verified against.
+	CACertificates []string
+
+	// InsecureSkipVerify disables the verification of the certificate of the mirror.
+	InsecureSkipVerify bool
+}
+
+type mirrorHost struct {
+	Endpoint           string
+	CAPath             string
+	InsecureSkipVerify bool
+}
+
+// registryMirrorFiles returns the files and commands that configure containerd to pull images
+// through the given mirrors before the kubelet starts pulling images. Mirrors of the same registry
+// keep their order.
+func registryMirrorFiles(mirrors []RegistryMirror) ([]Files, []string, error) {
+	config, err := generate("registry-mirrors", registryMirrorConfig, registryHostsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := []Files{
+		{
+			Path:        registryMirrorConfigPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     config,
+		},
+	}
+
+	hosts := map[string][]mirrorHost{}
+	for _, mirror := range mirrors {
+		dir := path.Join(registryHostsDir, mirror.Registry)
+		host := mirrorHost{Endpoint: mirror.Endpoint, InsecureSkipVerify: mirror.InsecureSkipVerify}
+		if len(mirror.CACertificates) > 0 {
+			host.CAPath = path.Join(dir, fmt.Sprintf("mirror-%d-ca.crt", len(hosts[mirror.Registry])))
+			files = append(files, Files{
+				Path:        host.CAPath,
+				Owner:       "root:root",
+				Permissions: "0644",
+				Content:     strings.Join(mirror.CACertificates, "\n") + "\n",
+			})
+		}
+		hosts[mirror.Registry] = append(hosts[mirror.Registry], host)
+	}
+
+	registries := make([]string, 0, len(hosts))
+	for registry := range hosts {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+
+	for _, registry := range registries {
+		config, err := generate("registry-hosts", registryHostsConfig, hosts[registry])
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, Files{
+			Path:        path.Join(registryHostsDir, registry, "hosts.toml"),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     config,
+		})
+	}
+
+	return files, []string{"systemctl restart containerd"}, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistryMirrorFiles(t *testing.T) {
+	files, commands, err := registryMirrorFiles([]RegistryMirror{
+		{Registry: "docker.io", Endpoint: "https://mirror.example.com:5000", CACertificates: []string{"-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"}},
+		{Registry: "docker.io", Endpoint: "https://fallback.example.com", InsecureSkipVerify: true},
+		{Registry: "quay.io", Endpoint: "http://mirror.example.com:5001"},
+	})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if !reflect.DeepEqual(commands, []string{"systemctl restart containerd"}) {
+		t.Fatalf("expected containerd to be restarted, got %v", commands)
+	}
+
+	var paths []string
+	content := map[string]string{}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		content[f.Path] = f.Content
+	}
+	wantPaths := []string{
+		registryMirrorConfigPath,
+		"/etc/containerd/certs.d/docker.io/mirror-0-ca.crt",
+		"/etc/containerd/certs.d/docker.io/hosts.toml",
+		"/etc/containerd/certs.d/quay.io/hosts.toml",
+	}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Fatalf("expected files %v, got %v", wantPaths, paths)
+	}
+
+	if !strings.Contains(content[registryMirrorConfigPath], `config_path = "/etc/containerd/certs.d"`) {
+		t.Fatalf("expected containerd to use the registry host configuration, got:\n%s", content[registryMirrorConfigPath])
+	}
+
+	dockerHosts := content["/etc/containerd/certs.d/docker.io/hosts.toml"]
+	for _, s := range []string{
+		`[host."https://mirror.example.com:5000"]`,
+		`ca = "/etc/containerd/certs.d/docker.io/mirror-0-ca.crt"`,
+		`[host."https://fallback.example.com"]`,
+		`skip_verify = true`,
+	} {
+		if !strings.Contains(dockerHosts, s) {
+			t.Fatalf("expected hosts.toml to contain %q, got:\n%s", s, dockerHosts)
+		}
+	}
+	if strings.Index(dockerHosts, "mirror.example.com") > strings.Index(dockerHosts, "fallback.example.com") {
+		t.Fatalf("expected mirrors to keep their order, got:\n%s", dockerHosts)
+	}
+
+	quayHosts := content["/etc/containerd/certs.d/quay.io/hosts.toml"]
+	if strings.Contains(quayHosts, "ca = ") || strings.Contains(quayHosts, "skip_verify") {
+		t.Fatalf("expected no TLS settings for the mirror, got:\n%s", quayHosts)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+const (
+	runtimeVolumeScriptPath = "/usr/local/bin/capa-mount-runtime-volume.sh"
+
+	// runtimeVolumeScript formats the container runtime volume unless it already holds a file system,
+	// and mounts it at the data root with the content the image shipped there. On Nitro instances EBS
+	// volumes show up as NVMe devices, which report the requested device name in the vendor specific
+	// data of their controller.
+	runtimeVolumeScript = `{{.Header}}
+device='{{.Device}}'
+mount_path='{{.MountPath}}'
+
+for _ in $(seq 1 60); do
+  if [ -b "${device}" ]; then
+    break
+  fi
+  for nvme in /dev/nvme*n1; do
+    if [ -b "${nvme}" ] && nvme id-ctrl --vendor-specific "${nvme}" 2>/dev/null | grep -q "${device#/dev/}"; then
+      device="${nvme}"
+      break 2
+    fi
+  done
+  sleep 1
+done
+
+if [ ! -b "${device}" ]; then
+  echo "container runtime volume ${device} not found" >&2
+  exit 1
+fi
+
+if ! blkid "${device}" >/dev/null 2>&1; then
+  mkfs.ext4 -L capa-runtime "${device}"
+fi
+
+for service in docker containerd; do
+  systemctl stop "${service}" 2>/dev/null || true
+done
+
+mkdir -p "${mount_path}"
+staging=$(mktemp -d)
+mount "${device}" "${staging}"
+cp -a "${mount_path}/." "${staging}/"
+umount "${staging}"
+rmdir "${staging}"
+
+echo "UUID=$(blkid -s UUID -o value "${device}") ${mount_path} ext4 defaults,nofail 0 2" >> /etc/fstab
+mount "${mount_path}"
+
+for service in containerd docker; do
+  if systemctl is-enabled "${service}" >/dev/null 2>&1; then
+    systemctl start "${service}"
+  fi
+done
+`
+)
+
+// ContainerRuntimeVolume is a volume that holds the storage of the container runtime.
+type ContainerRuntimeVolume struct {
+	// Device is the device name the volume is attached as.
+	Device string
+
+	// MountPath is the data root of the container runtime the volume is mounted at.
+	MountPath string
+}
+
+type runtimeVolumeInput struct {
+	baseUserData
+	*ContainerRuntimeVolume
+}
+
+// runtimeVolumeFiles returns the files that format and mount the container runtime volume.
+func runtimeVolumeFiles(volume *ContainerRuntimeVolume) ([]Files, error) {
+	script, err := generate("runtime-volume", runtimeVolumeScript, runtimeVolumeInput{
+		baseUserData:           baseUserData{Header: defaultHeader},
+		ContainerRuntimeVolume: volume,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []Files{
+		{
+			Path:        runtimeVolumeScriptPath,
+			Owner:       "root:root",
+			Permissions: "0755",
+			Content:     script,
+		},
+	}, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	kernelModulesPath = "/etc/modules-load.d/capa.conf"
+	sysctlsPath       = "/etc/sysctl.d/90-capa.conf"
+
+	conntrackModule       = "nf_conntrack"
+	conntrackSysctlPrefix = "net.netfilter.nf_conntrack_"
+)
+
+// IPVSKernelModules are the kernel modules kube-proxy needs to run in IPVS mode.
+var IPVSKernelModules = []string{"ip_vs", "ip_vs_rr", "ip_vs_wrr", "ip_vs_sh", conntrackModule}
+
+// Sysctl is a kernel parameter to set on the node.
+type Sysctl struct {
+	Name  string
+	Value string
+}
+
+// nodeTuningFiles returns the files and commands that load the given kernel modules and apply the
+// given kernel parameters, both at boot and on every later boot of the node.
+func nodeTuningFiles(modules []string, sysctls []Sysctl) ([]Files, []string) {
+	var (
+		files    []Files
+		commands []string
+	)
+
+	// The conntrack parameters only exist once the module is loaded.
+	for _, sysctl := range sysctls {
+		if strings.HasPrefix(sysctl.Name, conntrackSysctlPrefix) {
+			modules = appendUnique(modules, conntrackModule)
+			break
+		}
+	}
+
+	if len(modules) > 0 {
+		files = append(files, Files{
+			Path:        kernelModulesPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     strings.Join(modules, "\n") + "\n",
+		})
+		commands = append(commands, "modprobe -a "+strings.Join(modules, " "))
+	}
+
+	if len(sysctls) > 0 {
+		var content strings.Builder
+		for _, sysctl := range sysctls {
+			fmt.Fprintf(&content, "%s = %s\n", sysctl.Name, sysctl.Value)
+		}
+		files = append(files, Files{
+			Path:        sysctlsPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     content.String(),
+		})
+		commands = append(commands, "sysctl -p "+sysctlsPath)
+	}
+
+	return files, commands
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
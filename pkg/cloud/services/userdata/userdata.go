@@ -23,6 +23,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// MaxUserDataSize is the maximum size in bytes of the user data EC2 accepts, before it is base64 encoded.
+const MaxUserDataSize = 16384
+
 const (
 	defaultHeader = `#!/usr/bin/env bash
 
@@ -19,7 +19,9 @@ package userdata
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"strings"
 	"text/template"
 
@@ -29,6 +31,7 @@ import (
 var defaultTemplateFuncMap = template.FuncMap{
 	"Base64Encode": templateBase64Encode,
 	"Indent":       templateYAMLIndent,
+	"TOMLEscape":   templateTOMLEscape,
 }
 
 func templateBase64Encode(s string) string {
@@ -41,6 +44,11 @@ func templateYAMLIndent(i int, input string) string {
 	return strings.Repeat(" ", i) + strings.Join(split, ident)
 }
 
+// templateTOMLEscape escapes s for use in a TOML basic string.
+func templateTOMLEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
 // GzipBytes will gzip a byte array
 func GzipBytes(dat []byte) ([]byte, error) {
 	var buf bytes.Buffer
@@ -55,3 +63,9 @@ func GzipBytes(dat []byte) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+// ComputeHash returns the hex-encoded SHA-256 hash of the user data.
+func ComputeHash(userData []byte) string {
+	sum := sha256.Sum256(userData)
+	return hex.EncodeToString(sum[:])
+}
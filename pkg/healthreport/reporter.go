@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthreport delivers machine lifecycle and health transitions to external HTTP endpoints.
+package healthreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+)
+
+// Transition is a lifecycle or health transition of a machine.
+type Transition string
+
+var (
+	// TransitionCreated is reported once the machine's instance has been launched.
+	TransitionCreated = Transition("created")
+
+	// TransitionRunning is reported when the instance is running and passes its status checks.
+	TransitionRunning = Transition("running")
+
+	// TransitionUnhealthy is reported when the instance is running but its status checks are impaired.
+	TransitionUnhealthy = Transition("unhealthy")
+
+	// TransitionTerminated is reported when the instance is shutting down or terminated.
+	TransitionTerminated = Transition("terminated")
+)
+
+// Event is the JSON document POSTed to an endpoint for every transition.
+type Event struct {
+	Cluster          string     `json:"cluster"`
+	Namespace        string     `json:"namespace"`
+	Machine          string     `json:"machine"`
+	InstanceID       string     `json:"instanceID,omitempty"`
+	InstanceState    string     `json:"instanceState,omitempty"`
+	AvailabilityZone string     `json:"availabilityZone,omitempty"`
+	Transition       Transition `json:"transition"`
+	Message          string     `json:"message,omitempty"`
+	Time             time.Time  `json:"time"`
+}
+
+// Endpoint is where events are delivered to.
+type Endpoint struct {
+	// URL the events are POSTed to.
+	URL string
+
+	// Authorization is the value of the Authorization header sent with every request, if any.
+	Authorization string
+}
+
+// Options configures the delivery of events.
+type Options struct {
+	// QueueSize is how many events may wait for delivery before new ones are dropped.
+	QueueSize int
+
+	// Workers is how many events are delivered concurrently.
+	Workers int
+
+	// Timeout bounds every delivery attempt.
+	Timeout time.Duration
+
+	// MaxRetries is how many times a failed delivery is retried before the event is dropped.
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry, doubled on every further retry.
+	RetryBackoff time.Duration
+}
+
+// DefaultOptions are the options used for any zero value in the options passed to NewReporter.
+var DefaultOptions = Options{
+	QueueSize:    1000,
+	Workers:      2,
+	Timeout:      10 * time.Second,
+	MaxRetries:   3,
+	RetryBackoff: time.Second,
+}
+
+type delivery struct {
+	endpoint Endpoint
+	event    Event
+}
+
+// Reporter queues events and delivers them in the background, so that reporting never blocks the
+// caller. It must be started, e.g. by adding it to the controller manager, for events to be delivered.
+type Reporter struct {
+	log    logr.Logger
+	client *http.Client
+	opts   Options
+	queue  chan delivery
+}
+
+// NewReporter returns a reporter delivering events with the given options.
+func NewReporter(log logr.Logger, opts Options) *Reporter {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultOptions.QueueSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultOptions.Workers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = DefaultOptions.MaxRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultOptions.RetryBackoff
+	}
+
+	return &Reporter{
+		log:    log,
+		client: &http.Client{Timeout: opts.Timeout},
+		opts:   opts,
+		queue:  make(chan delivery, opts.QueueSize),
+	}
+}
+
+// Report queues the event for delivery to the endpoint and returns immediately. It returns false
+// if the event was dropped because the queue is full or the reporter is nil.
+func (r *Reporter) Report(endpoint Endpoint, event Event) bool {
+	if r == nil {
+		return false
+	}
+
+	select {
+	case r.queue <- delivery{endpoint: endpoint, event: event}:
+		return true
+	default:
+		r.log.Info("Health report queue is full, dropping event", "machine", event.Machine, "transition", event.Transition)
+		return false
+	}
+}
+
+// Start delivers queued events until stop is closed. Events still queued at that point are dropped.
+func (r *Reporter) Start(stop <-chan struct{}) error {
+	done := make(chan struct{})
+	for i := 0; i < r.opts.Workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-stop:
+					return
+				case d := <-r.queue:
+					if err := r.deliver(stop, d); err != nil {
+						r.log.Error(err, "Failed to report machine health", "url", d.endpoint.URL, "machine", d.event.Machine, "transition", d.event.Transition)
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < r.opts.Workers; i++ {
+		<-done
+	}
+	return nil
+}
+
+// deliver POSTs the event, retrying with exponential backoff when the request fails or the endpoint
+// responds with a server error or asks to slow down.
+func (r *Reporter) deliver(stop <-chan struct{}, d delivery) error {
+	body, err := json.Marshal(d.event)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode health report")
+	}
+
+	backoff := r.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := r.post(d.endpoint, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= r.opts.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-stop:
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends a single delivery attempt and returns whether a failed attempt is worth retrying.
+func (r *Reporter) post(endpoint Endpoint, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to build health report request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if endpoint.Authorization != "" {
+		req.Header.Set("Authorization", endpoint.Authorization)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to send health report")
+	}
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, errors.Errorf("health report endpoint responded with %s", resp.Status)
+	default:
+		return false, errors.Errorf("health report endpoint rejected report with %s", resp.Status)
+	}
+}
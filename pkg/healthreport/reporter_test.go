@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthreport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+)
+
+func TestReporterDelivers(t *testing.T) {
+	g := NewWithT(t)
+
+	received := make(chan Event, 1)
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Fail the first attempt to exercise retries.
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var event Event
+		if err := json.NewDecoder(req.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	r := NewReporter(klogr.New(), Options{RetryBackoff: time.Millisecond})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() { _ = r.Start(stop) }()
+
+	g.Expect(r.Report(Endpoint{URL: server.URL, Authorization: "Bearer token"}, Event{
+		Cluster:    "test-cluster",
+		Machine:    "test-machine",
+		InstanceID: "i-1",
+		Transition: TransitionRunning,
+	})).To(BeTrue())
+
+	var event Event
+	g.Eventually(received, 5*time.Second).Should(Receive(&event))
+	g.Expect(event.Machine).To(Equal("test-machine"))
+	g.Expect(event.InstanceID).To(Equal("i-1"))
+	g.Expect(event.Transition).To(Equal(TransitionRunning))
+	g.Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(2))
+}
+
+func TestReporterDoesNotRetryRejectedReports(t *testing.T) {
+	g := NewWithT(t)
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	r := NewReporter(klogr.New(), Options{RetryBackoff: time.Millisecond})
+	err := r.deliver(make(chan struct{}), delivery{endpoint: Endpoint{URL: server.URL}, event: Event{Transition: TransitionCreated}})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(1))
+}
+
+func TestReporterDropsEventsWhenQueueIsFull(t *testing.T) {
+	g := NewWithT(t)
+
+	// The reporter is not started, so nothing drains the queue.
+	r := NewReporter(klogr.New(), Options{QueueSize: 1})
+	g.Expect(r.Report(Endpoint{URL: "http://example.com"}, Event{Transition: TransitionCreated})).To(BeTrue())
+	g.Expect(r.Report(Endpoint{URL: "http://example.com"}, Event{Transition: TransitionRunning})).To(BeFalse())
+
+	var nilReporter *Reporter
+	g.Expect(nilReporter.Report(Endpoint{URL: "http://example.com"}, Event{})).To(BeFalse())
+}
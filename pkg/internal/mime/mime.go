@@ -45,6 +45,18 @@ var (
 	}, "\n")
 )
 
+// Part is a single part of a multi-part MIME user data document.
+type Part struct {
+	// ContentType is the MIME content type of the part, e.g. text/cloud-config.
+	ContentType string
+
+	// MergeType, when set, is added as the cloud-init Merge-Type header of the part.
+	MergeType string
+
+	// Content is the body of the part.
+	Content []byte
+}
+
 type scriptVariables struct {
 	SecretPrefix string
 	Chunks       int32
@@ -98,3 +110,35 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 
 	return buf.Bytes(), nil
 }
+
+// GenerateMultipartDocument combines the given parts into a single multi-part
+// MIME document, in order, suitable for consumption by cloud-init.
+func GenerateMultipartDocument(parts []Part) ([]byte, error) {
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+
+	for _, part := range parts {
+		header := textproto.MIMEHeader{
+			"content-type": {part.ContentType},
+		}
+		if part.MergeType != "" {
+			header["merge-type"] = []string{part.MergeType}
+		}
+
+		partWriter, err := mpWriter.CreatePart(header)
+		if err != nil {
+			return []byte{}, err
+		}
+
+		if _, err := partWriter.Write(part.Content); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -18,6 +18,9 @@ package mime
 
 import (
 	"bytes"
+	"io"
+	stdmime "mime"
+	"mime/multipart"
 	"net/mail"
 	"testing"
 )
@@ -31,3 +34,43 @@ func TestGenerateInitDocument(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestGenerateMultipartDocument(t *testing.T) {
+	doc, err := GenerateMultipartDocument([]Part{
+		{ContentType: "text/cloud-config", Content: []byte("#cloud-config\n")},
+		{ContentType: "text/cloud-config", MergeType: "list(append)+dict(no_replace,recurse_list)+str()", Content: []byte("#cloud-config\nruncmd: []\n")},
+	})
+	if err != nil {
+		t.Fatalf("Cannot generate MIME doc: %+v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewBuffer(doc))
+	if err != nil {
+		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+	}
+
+	mediaType, params, err := stdmime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Expected a multipart/mixed document, got %q: %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	count := 0
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Cannot read MIME part: %+v", err)
+		}
+		if part.Header.Get("Content-Type") != "text/cloud-config" {
+			t.Fatalf("Unexpected content type %q", part.Header.Get("Content-Type"))
+		}
+		count++
+	}
+
+	if count != 2 {
+		t.Fatalf("Expected 2 parts, got %d", count)
+	}
+}